@@ -45,9 +45,73 @@ enum value_type_t {
 	TYPE_BOOL,
 	TYPE_CSTRING,
     TYPE_INT16,
-    TYPE_UINT16
+    TYPE_UINT16,
+	TYPE_HISTOGRAM,
+	TYPE_SUMMARY
 };
 
+typedef struct {
+	double upper_bound; // inclusive, INFINITY for the last bucket
+	unsigned long long count; // cumulative
+} histogram_bucket_t;
+
+typedef struct {
+	histogram_bucket_t * buckets;
+	int buckets_length;
+	double sum;
+	unsigned long long count;
+} histogram_t;
+
+typedef struct {
+	double quantile;
+	double value;
+} summary_quantile_t;
+
+typedef struct {
+	summary_quantile_t * quantiles;
+	int quantiles_length;
+	double sum;
+	unsigned long long count;
+} summary_t;
+
+static inline histogram_t * alloc_histogram_t(int buckets_length) {
+	histogram_t * h = malloc(sizeof(histogram_t));
+	h->buckets = malloc(sizeof(histogram_bucket_t) * buckets_length);
+	h->buckets_length = buckets_length;
+	return h;
+}
+
+static inline void free_histogram_t(histogram_t * h) {
+	free(h->buckets);
+	free(h);
+}
+
+static inline histogram_bucket_t * get_histogram_bucket(histogram_t * h, int index) { return &h->buckets[index]; }
+
+static inline void set_histogram_bucket(histogram_t * h, int index, double upper_bound, unsigned long long count) {
+	h->buckets[index].upper_bound = upper_bound;
+	h->buckets[index].count = count;
+}
+
+static inline summary_t * alloc_summary_t(int quantiles_length) {
+	summary_t * s = malloc(sizeof(summary_t));
+	s->quantiles = malloc(sizeof(summary_quantile_t) * quantiles_length);
+	s->quantiles_length = quantiles_length;
+	return s;
+}
+
+static inline void free_summary_t(summary_t * s) {
+	free(s->quantiles);
+	free(s);
+}
+
+static inline summary_quantile_t * get_summary_quantile(summary_t * s, int index) { return &s->quantiles[index]; }
+
+static inline void set_summary_quantile(summary_t * s, int index, double quantile, double value) {
+	s->quantiles[index].quantile = quantile;
+	s->quantiles[index].value = value;
+}
+
 typedef struct {
 	union  {
 		long long v_int64;
@@ -60,6 +124,8 @@ typedef struct {
 		char * v_cstring;
         short int v_int16;
 		unsigned short int v_uint16;
+		histogram_t * v_histogram;
+		summary_t * v_summary;
 	} value;
 	int vtype; // value_type_t;
 } value_t;
@@ -73,6 +139,10 @@ static inline value_t * alloc_value_t(enum value_type_t t) {
 static inline void free_value_t(value_t * v) {
 	if (v->vtype == TYPE_CSTRING) {
 		free(v->value.v_cstring);
+	} else if (v->vtype == TYPE_HISTOGRAM) {
+		free_histogram_t(v->value.v_histogram);
+	} else if (v->vtype == TYPE_SUMMARY) {
+		free_summary_t(v->value.v_summary);
 	}
 	free(v);
 }
@@ -87,6 +157,8 @@ static inline int value_t_bool(value_t * v) { return v->value.v_bool; }
 static inline char * value_t_cstring(value_t * v) { return v->value.v_cstring; }
 static inline short int value_t_shortint(value_t * v) { return v->value.v_int16; }
 static inline short int value_t_ushortint(value_t * v) { return v->value.v_uint16; }
+static inline histogram_t * value_t_histogram(value_t * v) { return v->value.v_histogram; }
+static inline summary_t * value_t_summary(value_t * v) { return v->value.v_summary; }
 
 static inline void set_value_t_long_long(value_t * v, long long v_int64) { v->value.v_int64 = v_int64; }
 static inline void set_value_t_ulong_long(value_t * v, unsigned long long v_uint64) { v->value.v_uint64 = v_uint64; }
@@ -98,6 +170,8 @@ static inline void set_value_t_bool(value_t * v, int v_bool) { v->value.v_bool =
 static inline void set_value_t_cstring(value_t * v, char * v_cstring) { v->value.v_cstring = v_cstring; }
 static inline void set_value_t_shortint(value_t * v, short int v_int16) { v->value.v_int16 = v_int16; }
 static inline void set_value_t_ushortint(value_t * v, unsigned short int v_uint16) { v->value.v_uint16 = v_uint16; }
+static inline void set_value_t_histogram(value_t * v, histogram_t * v_histogram) { v->value.v_histogram = v_histogram; }
+static inline void set_value_t_summary(value_t * v, summary_t * v_summary) { v->value.v_summary = v_summary; }
 
 typedef struct {
 	char * key;
@@ -316,6 +390,46 @@ static inline void free_metric_arr(metric_t** mt_array, int size) {
    free(mt_array);
 }
 
+typedef struct {
+	char * title;
+	char * text;
+	int severity;
+	map_t * tags;
+	time_with_ns_t * timestamp;
+} event_t;
+
+static inline event_t** alloc_event_pointer_array(int size) {
+	event_t ** arrPtr = malloc(sizeof(event_t*) * size);
+	int i;
+	for(i=0; i < size; i++) {
+		arrPtr[i] = malloc(sizeof(event_t));
+	}
+	return arrPtr;
+}
+
+static inline void set_event_values(event_t** ev_array, int index, char* title, char* text, int severity, map_t* tags, time_with_ns_t* timestamp) {
+	ev_array[index]->title = title;
+	ev_array[index]->text = text;
+	ev_array[index]->severity = severity;
+	ev_array[index]->tags = tags;
+	ev_array[index]->timestamp = timestamp;
+}
+
+static inline void free_event_arr(event_t** ev_array, int size) {
+	if (ev_array == NULL) return;
+	int i;
+	for (i=0; i< size; i++) {
+		if (ev_array[i] != NULL ) {
+			free(ev_array[i]->title);
+			free(ev_array[i]->text);
+			free_map_t(ev_array[i]->tags);
+			free_time_with_ns_t(ev_array[i]->timestamp);
+			free(ev_array[i]);
+		}
+	}
+	free(ev_array);
+}
+
 #line 1 "cgo-generated-wrapper"
 
 
@@ -375,12 +489,18 @@ extern void dealloc_error(error_t* p0);
 
 extern void dealloc_metric_array(metric_t** p0, GoInt p1);
 
+extern void dealloc_event_array(event_t** p0, GoInt p1);
+
 extern error_t* ctx_add_metric(char* p0, char* p1, value_t* p2, modifiers_t* p3);
 
+extern error_t* ctx_add_event(char* p0, char* p1, char* p2, int p3, map_t* p4, time_with_ns_t* p5);
+
 extern error_t* ctx_always_apply(char* p0, char* p1, modifiers_t* p2);
 
 extern void ctx_dismiss_all_modifiers(char* p0);
 
+extern error_t* ctx_flush(char* p0);
+
 extern GoInt ctx_should_process(char* p0, char* p1);
 
 extern char** ctx_requested_metrics(char* p0);
@@ -389,6 +509,10 @@ extern GoInt ctx_count(char* p0);
 
 extern metric_t** ctx_list_all_metrics(char* p0);
 
+extern GoInt ctx_events_count(char* p0);
+
+extern event_t** ctx_list_all_events(char* p0);
+
 extern char* ctx_config_value(char* p0, char* p1);
 
 extern char** ctx_config_keys(char* p0);
@@ -407,6 +531,8 @@ extern void define_group(char* p0, char* p1);
 
 extern error_t* define_example_config(char* p0);
 
+extern error_t* define_flush_threshold(GoInt p0);
+
 extern void define_tasks_per_instance_limit(GoInt p0);
 
 extern void define_instances_limit(GoInt p0);
@@ -81,6 +81,23 @@ namespace SnapPluginLib
             throw new NotImplementedException(NoImplementedError);
         }
 
+        internal static IntPtr /* NativeError */
+            ctx_add_event(string taskId, string title, string text, int severity, IntPtr /* NativeMap */ tags,
+                NativeTimeWithNs timestamp)
+        {
+            if (IsWindows())
+            {
+                return CBridgeWin.ctx_add_event(taskId, title, text, severity, tags, timestamp);
+            }
+
+            if (IsLinux())
+            {
+                return CBridgeLinux.ctx_add_event(taskId, title, text, severity, tags, timestamp);
+            }
+
+            throw new NotImplementedException(NoImplementedError);
+        }
+
         internal static IntPtr /* NativeError */
             ctx_always_apply(string taskId, string ns, NativeModifiers nativeModifiers)
         {
@@ -55,6 +55,11 @@ namespace SnapPluginLib
         internal static extern IntPtr /* NativeError */
             ctx_add_metric(string taskId, string ns, NativeValue nativeValue, NativeModifiers nativeModifiers);
 
+        [DllImport(PluginLibDllName, CharSet = CharSet.Ansi, SetLastError = true)]
+        internal static extern IntPtr /* NativeError */
+            ctx_add_event(string taskId, string title, string text, int severity, IntPtr /* NativeMap */ tags,
+                NativeTimeWithNs timestamp);
+
         [DllImport(PluginLibDllName, CharSet = CharSet.Ansi, SetLastError = true)]
         internal static extern IntPtr /* NativeError */
             ctx_always_apply(string taskId, string ns, NativeModifiers nativeModifiers);
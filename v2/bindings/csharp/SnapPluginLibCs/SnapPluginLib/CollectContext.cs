@@ -14,6 +14,7 @@
     limitations under the License.
 */
 
+using System;
 using System.Collections.Generic;
 using System.Runtime.InteropServices;
 
@@ -82,6 +83,22 @@ namespace SnapPluginLib
             AddMetricWithNativeValue(ns, nativeValue, modifiers);
         }
 
+        public void AddEvent(string title, string text, EventSeverity severity, Dictionary<string, string> tags = null,
+            DateTime? timestamp = null)
+        {
+            var nativeTags = tags != null ? Convertions.DictionaryToNativeMapMem(tags) : IntPtr.Zero;
+            var nativeTimestamp = timestamp.HasValue ? Convertions.DateTimeToNativeTimeWithNs(timestamp.Value) : null;
+
+            var errPtr = CBridge.ctx_add_event(TaskId, title, text, (int) severity, nativeTags, nativeTimestamp);
+
+            if (nativeTags != IntPtr.Zero)
+            {
+                Memory.FreeNativeMap(nativeTags);
+            }
+
+            Exceptions.ThrowExceptionIfError(errPtr);
+        }
+
         public void AlwaysApply(string ns, params Modifier[] modifiers)
         {
             var nativeModifiers = ToNativeModifiers(modifiers);
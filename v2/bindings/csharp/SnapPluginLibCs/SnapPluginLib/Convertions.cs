@@ -50,6 +50,19 @@ namespace SnapPluginLib
             return nativeMapAsMemBlock;
         }
 
+        // Conversion: DateTime -> time_with_ns_t* (NativeTimeWithNs)
+        public static NativeTimeWithNs DateTimeToNativeTimeWithNs(DateTime dt)
+        {
+            var unixTime = new DateTimeOffset(dt.ToUniversalTime());
+            var ticks = unixTime.UtcTicks % TimeSpan.TicksPerSecond;
+
+            return new NativeTimeWithNs
+            {
+                sec = (int) unixTime.ToUnixTimeSeconds(),
+                nsec = (int) (ticks * 100) // 1 tick = 100ns
+            };
+        }
+
         // Conversion: char** -> List<string>
         public static List<string> NativeStringArrayToList(IntPtr arrPtr)
         {
@@ -14,6 +14,7 @@
     limitations under the License.
 */
 
+using System;
 using System.Collections.Generic;
 
 namespace SnapPluginLib
@@ -25,6 +26,8 @@ namespace SnapPluginLib
         void AddMetric(string ns, uint value, params Modifier[] modifiers);
         void AddMetric(string ns, bool value, params Modifier[] modifiers);
         void AddMetric(string ns, string value, params Modifier[] modifiers);
+        void AddEvent(string title, string text, EventSeverity severity, Dictionary<string, string> tags = null,
+            DateTime? timestamp = null);
         void AlwaysApply(string ns, params Modifier[] modifiers);
         void DismissAllModifiers();
         bool ShouldProcess(string ns);
@@ -42,9 +42,73 @@ enum value_type_t {
 	TYPE_BOOL,
 	TYPE_CSTRING,
 	TYPE_INT16,
-	TYPE_UINT16
+	TYPE_UINT16,
+	TYPE_HISTOGRAM,
+	TYPE_SUMMARY
 };
 
+typedef struct {
+	double upper_bound; // inclusive, INFINITY for the last bucket
+	unsigned long long count; // cumulative
+} histogram_bucket_t;
+
+typedef struct {
+	histogram_bucket_t * buckets;
+	int buckets_length;
+	double sum;
+	unsigned long long count;
+} histogram_t;
+
+typedef struct {
+	double quantile;
+	double value;
+} summary_quantile_t;
+
+typedef struct {
+	summary_quantile_t * quantiles;
+	int quantiles_length;
+	double sum;
+	unsigned long long count;
+} summary_t;
+
+static inline histogram_t * alloc_histogram_t(int buckets_length) {
+	histogram_t * h = malloc(sizeof(histogram_t));
+	h->buckets = malloc(sizeof(histogram_bucket_t) * buckets_length);
+	h->buckets_length = buckets_length;
+	return h;
+}
+
+static inline void free_histogram_t(histogram_t * h) {
+	free(h->buckets);
+	free(h);
+}
+
+static inline histogram_bucket_t * get_histogram_bucket(histogram_t * h, int index) { return &h->buckets[index]; }
+
+static inline void set_histogram_bucket(histogram_t * h, int index, double upper_bound, unsigned long long count) {
+	h->buckets[index].upper_bound = upper_bound;
+	h->buckets[index].count = count;
+}
+
+static inline summary_t * alloc_summary_t(int quantiles_length) {
+	summary_t * s = malloc(sizeof(summary_t));
+	s->quantiles = malloc(sizeof(summary_quantile_t) * quantiles_length);
+	s->quantiles_length = quantiles_length;
+	return s;
+}
+
+static inline void free_summary_t(summary_t * s) {
+	free(s->quantiles);
+	free(s);
+}
+
+static inline summary_quantile_t * get_summary_quantile(summary_t * s, int index) { return &s->quantiles[index]; }
+
+static inline void set_summary_quantile(summary_t * s, int index, double quantile, double value) {
+	s->quantiles[index].quantile = quantile;
+	s->quantiles[index].value = value;
+}
+
 typedef struct {
 	union  {
 		long long v_int64;
@@ -57,6 +121,8 @@ typedef struct {
 		char * v_cstring;
 		short int v_int16;
 		unsigned short int v_uint16;
+		histogram_t * v_histogram;
+		summary_t * v_summary;
 	} value;
 	int vtype; // value_type_t;
 } value_t;
@@ -70,6 +136,10 @@ static inline value_t * alloc_value_t(enum value_type_t t) {
 static inline void free_value_t(value_t * v) {
 	if (v->vtype == TYPE_CSTRING) {
 		free(v->value.v_cstring);
+	} else if (v->vtype == TYPE_HISTOGRAM) {
+		free_histogram_t(v->value.v_histogram);
+	} else if (v->vtype == TYPE_SUMMARY) {
+		free_summary_t(v->value.v_summary);
 	}
 	free(v);
 }
@@ -84,6 +154,8 @@ static inline int value_t_bool(value_t * v) { return v->value.v_bool; }
 static inline char * value_t_cstring(value_t * v) { return v->value.v_cstring; }
 static inline short int value_t_shortint(value_t * v) { return v->value.v_int16; }
 static inline short int value_t_ushortint(value_t * v) { return v->value.v_uint16; }
+static inline histogram_t * value_t_histogram(value_t * v) { return v->value.v_histogram; }
+static inline summary_t * value_t_summary(value_t * v) { return v->value.v_summary; }
 
 static inline void set_value_t_long_long(value_t * v, long long v_int64) { v->value.v_int64 = v_int64; }
 static inline void set_value_t_ulong_long(value_t * v, unsigned long long v_uint64) { v->value.v_uint64 = v_uint64; }
@@ -95,6 +167,8 @@ static inline void set_value_t_bool(value_t * v, int v_bool) { v->value.v_bool =
 static inline void set_value_t_cstring(value_t * v, char * v_cstring) { v->value.v_cstring = v_cstring; }
 static inline void set_value_t_shortint(value_t * v, short int v_int16) { v->value.v_int16 = v_int16; }
 static inline void set_value_t_ushortint(value_t * v, unsigned short int v_uint16) { v->value.v_uint16 = v_uint16; }
+static inline void set_value_t_histogram(value_t * v, histogram_t * v_histogram) { v->value.v_histogram = v_histogram; }
+static inline void set_value_t_summary(value_t * v, summary_t * v_summary) { v->value.v_summary = v_summary; }
 
 typedef struct {
 	char * key;
@@ -312,6 +386,46 @@ static inline void free_metric_arr(metric_t** mt_array, int size) {
    }
    free(mt_array);
 }
+
+typedef struct {
+	char * title;
+	char * text;
+	int severity;
+	map_t * tags;
+	time_with_ns_t * timestamp;
+} event_t;
+
+static inline event_t** alloc_event_pointer_array(int size) {
+	event_t ** arrPtr = malloc(sizeof(event_t*) * size);
+	int i;
+	for(i=0; i < size; i++) {
+		arrPtr[i] = malloc(sizeof(event_t));
+	}
+	return arrPtr;
+}
+
+static inline void set_event_values(event_t** ev_array, int index, char* title, char* text, int severity, map_t* tags, time_with_ns_t* timestamp) {
+	ev_array[index]->title = title;
+	ev_array[index]->text = text;
+	ev_array[index]->severity = severity;
+	ev_array[index]->tags = tags;
+	ev_array[index]->timestamp = timestamp;
+}
+
+static inline void free_event_arr(event_t** ev_array, int size) {
+	if (ev_array == NULL) return;
+	int i;
+	for (i=0; i< size; i++) {
+		if (ev_array[i] != NULL ) {
+			free(ev_array[i]->title);
+			free(ev_array[i]->text);
+			free_map_t(ev_array[i]->tags);
+			free_time_with_ns_t(ev_array[i]->timestamp);
+			free(ev_array[i]);
+		}
+	}
+	free(ev_array);
+}
 */
 import "C"
 
@@ -510,6 +624,22 @@ func toCvalue_t(v interface{}) *C.value_t {
 		cvalue_t_ptr := C.alloc_value_t(C.TYPE_UINT16)
 		C.set_value_t_ushortint(cvalue_t_ptr, C.ushort(n))
 		return cvalue_t_ptr
+	case plugin.Histogram:
+		cvalue_t_ptr := C.alloc_value_t(C.TYPE_HISTOGRAM)
+		C.set_value_t_histogram(cvalue_t_ptr, toChistogram_t(&n))
+		return cvalue_t_ptr
+	case *plugin.Histogram:
+		cvalue_t_ptr := C.alloc_value_t(C.TYPE_HISTOGRAM)
+		C.set_value_t_histogram(cvalue_t_ptr, toChistogram_t(n))
+		return cvalue_t_ptr
+	case plugin.Summary:
+		cvalue_t_ptr := C.alloc_value_t(C.TYPE_SUMMARY)
+		C.set_value_t_summary(cvalue_t_ptr, toCsummary_t(&n))
+		return cvalue_t_ptr
+	case *plugin.Summary:
+		cvalue_t_ptr := C.alloc_value_t(C.TYPE_SUMMARY)
+		C.set_value_t_summary(cvalue_t_ptr, toCsummary_t(n))
+		return cvalue_t_ptr
 	default:
 		panic(fmt.Sprintf("Not supported metric type %T", v))
 	}
@@ -537,11 +667,69 @@ func toGoValue(v *C.value_t) interface{} {
 		return int16(C.value_t_shortint(v))
 	case C.TYPE_UINT16:
 		return uint16(C.value_t_ushortint(v))
+	case C.TYPE_HISTOGRAM:
+		return toGoHistogram(C.value_t_histogram(v))
+	case C.TYPE_SUMMARY:
+		return toGoSummary(C.value_t_summary(v))
 	}
 
 	panic(fmt.Sprintf("Invalid type %v", (*v).vtype))
 }
 
+func toChistogram_t(h *plugin.Histogram) *C.histogram_t {
+	histogram_ptr := C.alloc_histogram_t(C.int(len(h.Buckets)))
+	for i, b := range h.Buckets {
+		C.set_histogram_bucket(histogram_ptr, C.int(i), C.double(b.UpperBound), C.ulonglong(b.Count))
+	}
+	histogram_ptr.sum = C.double(h.Sum)
+	histogram_ptr.count = C.ulonglong(h.Count)
+	return histogram_ptr
+}
+
+func toGoHistogram(h *C.histogram_t) plugin.Histogram {
+	buckets := make([]plugin.HistogramBucket, 0, int(h.buckets_length))
+	for i := 0; i < int(h.buckets_length); i++ {
+		b := C.get_histogram_bucket(h, C.int(i))
+		buckets = append(buckets, plugin.HistogramBucket{
+			UpperBound: float64(b.upper_bound),
+			Count:      uint64(b.count),
+		})
+	}
+
+	return plugin.Histogram{
+		Buckets: buckets,
+		Sum:     float64(h.sum),
+		Count:   uint64(h.count),
+	}
+}
+
+func toCsummary_t(s *plugin.Summary) *C.summary_t {
+	summary_ptr := C.alloc_summary_t(C.int(len(s.Quantiles)))
+	for i, q := range s.Quantiles {
+		C.set_summary_quantile(summary_ptr, C.int(i), C.double(q.Quantile), C.double(q.Value))
+	}
+	summary_ptr.sum = C.double(s.Sum)
+	summary_ptr.count = C.ulonglong(s.Count)
+	return summary_ptr
+}
+
+func toGoSummary(s *C.summary_t) plugin.Summary {
+	quantiles := make([]plugin.SummaryQuantile, 0, int(s.quantiles_length))
+	for i := 0; i < int(s.quantiles_length); i++ {
+		q := C.get_summary_quantile(s, C.int(i))
+		quantiles = append(quantiles, plugin.SummaryQuantile{
+			Quantile: float64(q.quantile),
+			Value:    float64(q.value),
+		})
+	}
+
+	return plugin.Summary{
+		Quantiles: quantiles,
+		Sum:       float64(s.sum),
+		Count:     uint64(s.count),
+	}
+}
+
 func toGoModifiers(modifiers *C.modifiers_t) []plugin.MetricModifier {
 	var appliedModifiers []plugin.MetricModifier
 
@@ -598,6 +786,11 @@ func dealloc_metric_array(p **C.metric_t, size int) {
 	C.free_metric_arr(p, C.int(size))
 }
 
+//export dealloc_event_array
+func dealloc_event_array(p **C.event_t, size int) {
+	C.free_event_arr(p, C.int(size))
+}
+
 /*****************************************************************************/
 // C API - Collect related functions
 
@@ -614,6 +807,22 @@ func ctx_add_metric(ctxID *C.char, ns *C.char, v *C.value_t, modifiers *C.modifi
 	return toCError(err)
 }
 
+//export ctx_add_event
+func ctx_add_event(ctxID *C.char, title *C.char, text *C.char, severity C.int, tags *C.map_t, timestamp *C.time_with_ns_t) *C.error_t {
+	var modifiers []plugin.EventModifier
+
+	if tags != nil {
+		modifiers = append(modifiers, plugin.EventTags(toGoMap(tags)))
+	}
+
+	if timestamp != nil {
+		modifiers = append(modifiers, plugin.EventTimestamp(time.Unix(int64(timestamp.sec), int64(timestamp.nsec))))
+	}
+
+	err := collContextObject(ctxID).AddEvent(C.GoString(title), C.GoString(text), plugin.EventSeverity(severity), modifiers...)
+	return toCError(err)
+}
+
 //export ctx_always_apply
 func ctx_always_apply(ctxID *C.char, ns *C.char, modifiers *C.modifiers_t) *C.error_t {
 	_, err := collContextObject(ctxID).AlwaysApply(C.GoString(ns), toGoModifiers(modifiers)...)
@@ -625,6 +834,12 @@ func ctx_dismiss_all_modifiers(ctxID *C.char) {
 	collContextObject(ctxID).DismissAllModifiers()
 }
 
+//export ctx_flush
+func ctx_flush(ctxID *C.char) *C.error_t {
+	err := collContextObject(ctxID).Flush()
+	return toCError(err)
+}
+
 //export ctx_should_process
 func ctx_should_process(ctxID *C.char, ns *C.char) int {
 	return boolToInt(collContextObject(ctxID).ShouldProcess(C.GoString(ns)))
@@ -656,6 +871,26 @@ func ctx_list_all_metrics(ctxID *C.char) **C.metric_t {
 	return mtPtArr
 }
 
+//export ctx_events_count
+func ctx_events_count(ctxID *C.char) int {
+	return len(publContextObject(ctxID).ListAllEvents())
+}
+
+//export ctx_list_all_events
+func ctx_list_all_events(ctxID *C.char) **C.event_t {
+	events := publContextObject(ctxID).ListAllEvents()
+	evPtArr := C.alloc_event_pointer_array(C.int(len(events)))
+
+	for i, el := range events {
+		evTitle := (*C.char)(C.CString(el.Title()))
+		evText := (*C.char)(C.CString(el.Text()))
+		evTags := toCmap_t(el.Tags())
+		evTimestamp := time_to_ctimewithns(el.Timestamp())
+		C.set_event_values(evPtArr, C.int(i), evTitle, evText, C.int(el.Severity()), evTags, evTimestamp)
+	}
+	return evPtArr
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 //export ctx_config_value
@@ -726,6 +961,12 @@ func define_example_config(cfg *C.char) *C.error_t {
 	return toCError(err)
 }
 
+//export define_flush_threshold
+func define_flush_threshold(metricsCount int) *C.error_t {
+	err := collectorDef.DefineFlushThreshold(metricsCount)
+	return toCError(err)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 //export define_tasks_per_instance_limit
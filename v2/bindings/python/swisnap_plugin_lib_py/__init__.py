@@ -8,4 +8,8 @@ from .snap_ctypes import (
     LOGLEVEL_INFO,
     LOGLEVEL_DEBUG,
     LOGLEVEL_TRACE,
+    EVENT_SEVERITY_INFO,
+    EVENT_SEVERITY_WARNING,
+    EVENT_SEVERITY_ERROR,
+    EVENT_SEVERITY_CRITICAL,
 )
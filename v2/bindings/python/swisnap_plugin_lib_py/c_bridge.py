@@ -7,8 +7,8 @@ from .convertions import (
     cstrarray_to_list,
     time_to_ctimewithns,
 )
-from .metric import Metric
-from .snap_ctypes import CMetricStruct, CError, Modifiers
+from .metric import Metric, Event
+from .snap_ctypes import CMetricStruct, CEventStruct, CError, Modifiers
 from .dynamic_lib import PLUGIN_LIB_OBJ
 from .exceptions import throw_exception_if_error, throw_exception_if_null
 
@@ -21,8 +21,10 @@ global plugin_py
 # C functions metadata
 
 PLUGIN_LIB_OBJ.ctx_list_all_metrics.restype = POINTER(POINTER(CMetricStruct))
+PLUGIN_LIB_OBJ.ctx_list_all_events.restype = POINTER(POINTER(CEventStruct))
 
 PLUGIN_LIB_OBJ.ctx_add_metric.restype = POINTER(CError)
+PLUGIN_LIB_OBJ.ctx_add_event.restype = POINTER(CError)
 PLUGIN_LIB_OBJ.ctx_always_apply.restype = POINTER(CError)
 PLUGIN_LIB_OBJ.ctx_dismiss_all_modifiers.restype = c_void_p
 PLUGIN_LIB_OBJ.ctx_should_process.restype = c_longlong
@@ -139,6 +141,17 @@ class PublishContext(Context):
     def count(self) -> int:
         return PLUGIN_LIB_OBJ.ctx_count(self._ctx_id())
 
+    def list_all_events(self) -> []:
+        _evs_count = PLUGIN_LIB_OBJ.ctx_events_count(self._ctx_id())
+        _evs_ptr = PLUGIN_LIB_OBJ.ctx_list_all_events(self._ctx_id())
+
+        _ev_list = []
+        for i in range(_evs_count):
+            _ev_list.append(Event.unpack_from_event_struct(_evs_ptr[i].contents))
+
+        PLUGIN_LIB_OBJ.dealloc_event_array(_evs_ptr, _evs_count)
+        return _ev_list
+
     def _c_mt_array_to_list(self, mt_arr_ptr, mt_arr_size: int) -> []:
         """Converts C **metric_t to list of python managed objects of Metric class"""
         result_list = []
@@ -169,6 +182,17 @@ class CollectContext(Context):
             self.__create_modifiers(tags, None, timestamp, description, unit),
         )
 
+    @throw_exception_if_error
+    def add_event(self, title, text, severity, *, tags=None, timestamp=None):
+        return PLUGIN_LIB_OBJ.ctx_add_event(
+            self._ctx_id(),
+            string_to_bytes(title),
+            string_to_bytes(text),
+            severity,
+            dict_to_cmap(tags) if tags is not None else None,
+            time_to_ctimewithns(timestamp) if timestamp is not None else None,
+        )
+
     def always_apply(
             self,
             namespace,
@@ -24,7 +24,9 @@ from .snap_ctypes import (
     TYPE_INT32,
     TYPE_UINT32,
     TYPE_INT16,
-    TYPE_UINT16
+    TYPE_UINT16,
+    TYPE_HISTOGRAM,
+    TYPE_SUMMARY
 )
 
 from .exceptions import PluginLibException
@@ -155,5 +157,27 @@ def unpack_value_t(val_ptr):
     elif v_type == TYPE_UINT16:
         value = val_ptr.contents.value.v_uint16
         unit = int
+    elif v_type == TYPE_HISTOGRAM:
+        h = val_ptr.contents.value.v_histogram.contents
+        value = {
+            "buckets": [
+                (h.buckets[i].upper_bound, h.buckets[i].count)
+                for i in range(h.buckets_length)
+            ],
+            "sum": h.sum,
+            "count": h.count,
+        }
+        unit = dict
+    elif v_type == TYPE_SUMMARY:
+        s = val_ptr.contents.value.v_summary.contents
+        value = {
+            "quantiles": [
+                (s.quantiles[i].quantile, s.quantiles[i].value)
+                for i in range(s.quantiles_length)
+            ],
+            "sum": s.sum,
+            "count": s.count,
+        }
+        unit = dict
 
     return (value, unit)
@@ -104,3 +104,31 @@ class Metric:
             _time,
             _tags,
         )
+
+
+class Event:
+    def __init__(self, title="", text="", severity=0, tags=None, timestamp=""):
+        self.title = title
+        self.text = text
+        self.severity = severity
+        self.tags = tags if tags is not None else {}
+        self.timestamp = timestamp
+
+    def __repr__(self) -> str:
+        return "{} {} {} {} {}".format(
+            self.severity,
+            self.title,
+            self.text,
+            self.tags,
+            datetime.utcfromtimestamp(self.timestamp),
+        )
+
+    @classmethod
+    def unpack_from_event_struct(cls, ev_struct):
+        return cls(
+            ev_struct.title.decode(encoding="utf-8"),
+            ev_struct.text.decode(encoding="utf-8"),
+            ev_struct.severity,
+            cmap_to_dict(ev_struct.tags),
+            ctimewithns_to_time(ev_struct.timestamp),
+        )
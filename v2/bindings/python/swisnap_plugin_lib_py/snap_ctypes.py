@@ -26,8 +26,10 @@ max_uint = 18446744073709551615
     TYPE_BOOL,
     TYPE_CSTRING,
     TYPE_INT16,
-    TYPE_UINT16
-) = range(11)
+    TYPE_UINT16,
+    TYPE_HISTOGRAM,
+    TYPE_SUMMARY
+) = range(13)
 
 (
     _,
@@ -40,6 +42,13 @@ max_uint = 18446744073709551615
     LOGLEVEL_TRACE,
 ) = range(8)
 
+(
+    EVENT_SEVERITY_INFO,
+    EVENT_SEVERITY_WARNING,
+    EVENT_SEVERITY_ERROR,
+    EVENT_SEVERITY_CRITICAL,
+) = range(4)
+
 
 class MapElement(Structure):
     _fields_ = [("key", c_char_p), ("value", c_char_p)]
@@ -67,6 +76,32 @@ class CError(Structure):
     _fields_ = [("msg", c_char_p)]
 
 
+class CHistogramBucket(Structure):
+    _fields_ = [("upper_bound", c_double), ("count", c_ulonglong)]
+
+
+class CHistogram(Structure):
+    _fields_ = [
+        ("buckets", POINTER(CHistogramBucket)),
+        ("buckets_length", c_int),
+        ("sum", c_double),
+        ("count", c_ulonglong),
+    ]
+
+
+class CSummaryQuantile(Structure):
+    _fields_ = [("quantile", c_double), ("value", c_double)]
+
+
+class CSummary(Structure):
+    _fields_ = [
+        ("quantiles", POINTER(CSummaryQuantile)),
+        ("quantiles_length", c_int),
+        ("sum", c_double),
+        ("count", c_ulonglong),
+    ]
+
+
 class ValueUnion(Union):
     _fields_ = [
         ("v_int64", c_longlong),
@@ -77,6 +112,8 @@ class ValueUnion(Union):
         ("v_double", c_double),
         ("v_bool", c_int),
         ("v_cstring", c_char_p),
+        ("v_histogram", POINTER(CHistogram)),
+        ("v_summary", POINTER(CSummary)),
     ]
 
 
@@ -108,3 +145,13 @@ class CMetricStruct(Structure):
         ("timestamp", POINTER(TimeWithNs)),
         ("tags", POINTER(Map)),
     ]
+
+
+class CEventStruct(Structure):
+    _fields_ = [
+        ("title", c_char_p),
+        ("text", c_char_p),
+        ("severity", c_int),
+        ("tags", POINTER(Map)),
+        ("timestamp", POINTER(TimeWithNs)),
+    ]
@@ -0,0 +1,283 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	commonProxy "github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/proxy"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	defaultSeriesWindow  = 1 * time.Hour // used when limit per window is set without window duration
+	maxReportedOffenders = 3             // number of top namespaces and tag keys reported when series are dropped
+)
+
+// Limits of distinct series (namespace + tags) emitted by a task (0 - no limit)
+type SeriesLimits struct {
+	PerCollect int           // maximum number of distinct series in a single collect request
+	PerWindow  int           // maximum number of distinct series within rolling window
+	Window     time.Duration // duration of rolling window
+}
+
+func (l SeriesLimits) enabled() bool {
+	return l.PerCollect > 0 || l.PerWindow > 0
+}
+
+// Apply limits set in task configuration (reserved keys) over plugin defaults
+func seriesLimitsFromConfig(ctx *commonProxy.Context, defaults SeriesLimits) (SeriesLimits, error) {
+	limits := defaults
+
+	for key, limit := range map[string]*int{
+		plugin.MaxSeriesPerCollectConfigKey: &limits.PerCollect,
+		plugin.MaxSeriesPerWindowConfigKey:  &limits.PerWindow,
+	} {
+		if _, ok := ctx.ConfigValue(key); !ok {
+			continue
+		}
+
+		v, err := ctx.ConfigInt(key)
+		if err != nil {
+			return limits, err
+		}
+		if v < 0 {
+			return limits, fmt.Errorf("config field %s: limit can't be negative", key)
+		}
+		*limit = v
+	}
+
+	if _, ok := ctx.ConfigValue(plugin.SeriesWindowConfigKey); ok {
+		window, err := ctx.ConfigDuration(plugin.SeriesWindowConfigKey)
+		if err != nil {
+			return limits, err
+		}
+		if window < 0 {
+			return limits, fmt.Errorf("config field %s: window can't be negative", plugin.SeriesWindowConfigKey)
+		}
+		limits.Window = window
+	}
+
+	return limits, nil
+}
+
+// Series dropped due to exceeded limits (since last report)
+type droppedSeries struct {
+	count         int
+	topNamespaces map[string]int
+	topTagKeys    map[string]int
+}
+
+func (ds droppedSeries) String() string {
+	return fmt.Sprintf("%d new series dropped (top namespaces: %s; top tag keys: %s)",
+		ds.count, countsToString(ds.topNamespaces), countsToString(ds.topTagKeys))
+}
+
+// Keeps series emitted by a task. New series are rejected when limits are exceeded, while known ones are still accepted.
+type seriesLimiter struct {
+	limits SeriesLimits
+
+	collectSeries map[string]struct{}      // series emitted in current collection
+	windowSeries  map[string]*list.Element // series emitted within window -> element of windowOrder
+	windowOrder   *list.List               // windowEntry ordered by last time series was seen (the oldest first)
+
+	droppedCount  int
+	droppedByNs   map[string]int // namespace (with groups, ie. /plugin/[disk]/io_time) -> number of dropped series
+	droppedByTags map[string]int // tag key -> number of dropped series
+}
+
+type windowEntry struct {
+	key      string
+	lastSeen time.Time
+}
+
+func newSeriesLimiter(limits SeriesLimits) *seriesLimiter {
+	if limits.PerWindow > 0 && limits.Window <= 0 {
+		limits.Window = defaultSeriesWindow
+	}
+
+	return &seriesLimiter{
+		limits:        limits,
+		collectSeries: map[string]struct{}{},
+		windowSeries:  map[string]*list.Element{},
+		windowOrder:   list.New(),
+		droppedByNs:   map[string]int{},
+		droppedByTags: map[string]int{},
+	}
+}
+
+// Should be called when new collection is started
+func (sl *seriesLimiter) nextCollection(now time.Time) {
+	sl.collectSeries = map[string]struct{}{}
+	sl.expire(now)
+}
+
+// Returns false when metric starts new series which exceeds any limit
+func (sl *seriesLimiter) admit(mt *types.Metric, now time.Time) bool {
+	if !sl.limits.enabled() {
+		return true
+	}
+
+	key := seriesKey(mt)
+
+	_, inCollect := sl.collectSeries[key]
+	if !inCollect && sl.limits.PerCollect > 0 && len(sl.collectSeries) >= sl.limits.PerCollect {
+		sl.drop(mt)
+		return false
+	}
+
+	if sl.limits.PerWindow > 0 {
+		sl.expire(now)
+
+		el, inWindow := sl.windowSeries[key]
+		if !inWindow && len(sl.windowSeries) >= sl.limits.PerWindow {
+			sl.drop(mt)
+			return false
+		}
+
+		if inWindow {
+			el.Value.(*windowEntry).lastSeen = now
+			sl.windowOrder.MoveToBack(el)
+		} else {
+			sl.windowSeries[key] = sl.windowOrder.PushBack(&windowEntry{key: key, lastSeen: now})
+		}
+	}
+
+	sl.collectSeries[key] = struct{}{}
+	return true
+}
+
+// Return summary of series dropped since last call (false if there were none)
+func (sl *seriesLimiter) report() (droppedSeries, bool) {
+	if sl.droppedCount == 0 {
+		return droppedSeries{}, false
+	}
+
+	ds := droppedSeries{
+		count:         sl.droppedCount,
+		topNamespaces: topCounts(sl.droppedByNs, maxReportedOffenders),
+		topTagKeys:    topCounts(sl.droppedByTags, maxReportedOffenders),
+	}
+
+	sl.droppedCount = 0
+	sl.droppedByNs = map[string]int{}
+	sl.droppedByTags = map[string]int{}
+
+	return ds, true
+}
+
+func (sl *seriesLimiter) drop(mt *types.Metric) {
+	sl.droppedCount++
+	sl.droppedByNs[namespaceTemplate(mt)]++
+
+	for k := range mt.Tags_ {
+		sl.droppedByTags[k]++
+	}
+}
+
+func (sl *seriesLimiter) expire(now time.Time) {
+	if sl.limits.PerWindow <= 0 {
+		return
+	}
+
+	// only series which have expired are visited
+	for el := sl.windowOrder.Front(); el != nil; el = sl.windowOrder.Front() {
+		entry := el.Value.(*windowEntry)
+		if now.Sub(entry.lastSeen) <= sl.limits.Window {
+			return
+		}
+
+		sl.windowOrder.Remove(el)
+		delete(sl.windowSeries, entry.key)
+	}
+}
+
+// Namespace of metric with dynamic elements replaced by group names, ie. /plugin/[disk]/io_time
+func namespaceTemplate(mt *types.Metric) string {
+	sb := strings.Builder{}
+
+	for _, el := range mt.Namespace_ {
+		sb.WriteString("/")
+		if el.Name_ != "" {
+			sb.WriteString("[" + el.Name_ + "]")
+		} else {
+			sb.WriteString(el.Value_)
+		}
+	}
+
+	return sb.String()
+}
+
+// Select n entries with the highest counts
+func topCounts(counts map[string]int, n int) map[string]int {
+	keys := sortedByCount(counts)
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+
+	top := make(map[string]int, len(keys))
+	for _, k := range keys {
+		top[k] = counts[k]
+	}
+
+	return top
+}
+
+// Format counts in descending order, ie. "request_id=1200, path=10"
+func countsToString(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+
+	elems := make([]string, 0, len(counts))
+	for _, k := range sortedByCount(counts) {
+		elems = append(elems, fmt.Sprintf("%s=%d", k, counts[k]))
+	}
+
+	return strings.Join(elems, ", ")
+}
+
+func sortedByCount(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Summary of series dropped since last call (false if there were none)
+func (pc *PluginContext) DroppedSeries() (droppedSeries, bool) {
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	return pc.series.report()
+}
@@ -0,0 +1,174 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func newLimitedPluginContext(defaults SeriesLimits, config string) (*PluginContext, error) {
+	statsController, _ := stats.NewEmptyController()
+	cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", &diskCollector{}), statsController)
+	cm.SeriesLimits = defaults
+
+	err := cm.LoadTask("task-1", []byte(config), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pcI, _ := cm.contextMap.Load("task-1")
+	return pcI.(*PluginContext), nil
+}
+
+func TestSeriesLimits(t *testing.T) {
+	Convey("Validate that number of distinct series emitted by task is limited", t, func() {
+		Convey("New series above limit per collect are dropped, while existing ones are accepted", func() {
+			// Arrange
+			pc, err := newLimitedPluginContext(SeriesLimits{PerCollect: 2}, "{}")
+			So(err, ShouldBeNil)
+			pc.ClearCollectorSession()
+
+			// Act
+			for i := 0; i < 5; i++ {
+				_ = pc.AddMetric("/plugin/[disk=sda]/io_time", 1, plugin.MetricTag("request_id", fmt.Sprintf("%d", i)))
+			}
+			_ = pc.AddMetric("/plugin/[disk=sda]/io_time", 2, plugin.MetricTag("request_id", "0"))
+
+			// Assert
+			So(len(pc.Metrics(false)), ShouldEqual, 3)
+
+			ds, ok := pc.DroppedSeries()
+			So(ok, ShouldBeTrue)
+			So(ds.count, ShouldEqual, 3)
+			So(ds.topNamespaces, ShouldResemble, map[string]int{"/plugin/[disk]/io_time": 3})
+			So(ds.topTagKeys, ShouldResemble, map[string]int{"request_id": 3})
+			So(ds.String(), ShouldEqual, "3 new series dropped (top namespaces: /plugin/[disk]/io_time=3; top tag keys: request_id=3)")
+
+			_, ok = pc.DroppedSeries()
+			So(ok, ShouldBeFalse)
+
+			// Act - limit is applied per collection
+			pc.ClearCollectorSession()
+			_ = pc.AddMetric("/plugin/[disk=sdb]/io_time", 1)
+
+			// Assert
+			So(len(pc.Metrics(false)), ShouldEqual, 1)
+		})
+
+		Convey("Series above limit per window are dropped until they expire", func() {
+			// Arrange
+			pc, err := newLimitedPluginContext(SeriesLimits{}, `{"__maxSeriesPerWindow": 2, "__seriesWindow": "100ms"}`)
+			So(err, ShouldBeNil)
+
+			// Act
+			pc.ClearCollectorSession()
+			_ = pc.AddMetric("/plugin/[disk=sda]/io_time", 1)
+			_ = pc.AddMetric("/plugin/[disk=sdb]/io_time", 1)
+
+			pc.ClearCollectorSession()
+			_ = pc.AddMetric("/plugin/[disk=sdc]/io_time", 1)
+			_ = pc.AddMetric("/plugin/[disk=sda]/io_time", 1)
+
+			// Assert
+			So(len(pc.Metrics(false)), ShouldEqual, 1)
+
+			ds, ok := pc.DroppedSeries()
+			So(ok, ShouldBeTrue)
+			So(ds.count, ShouldEqual, 1)
+
+			// Act - window has passed
+			time.Sleep(150 * time.Millisecond)
+
+			pc.ClearCollectorSession()
+			_ = pc.AddMetric("/plugin/[disk=sdc]/io_time", 1)
+
+			// Assert
+			So(len(pc.Metrics(false)), ShouldEqual, 1)
+		})
+
+		Convey("Series seen again within window don't expire", func() {
+			// Arrange
+			pc, err := newLimitedPluginContext(SeriesLimits{}, `{"__maxSeriesPerWindow": 2, "__seriesWindow": "100ms"}`)
+			So(err, ShouldBeNil)
+
+			now := time.Now()
+			sl := pc.series
+			mt := func(disk string) *types.Metric {
+				return &types.Metric{Namespace_: []types.NamespaceElement{{Value_: "plugin"}, {Name_: "disk", Value_: disk}, {Value_: "io_time"}}}
+			}
+
+			// Act
+			sl.nextCollection(now)
+			So(sl.admit(mt("sda"), now), ShouldBeTrue)
+			So(sl.admit(mt("sdb"), now), ShouldBeTrue)
+
+			sl.nextCollection(now.Add(60 * time.Millisecond))
+			So(sl.admit(mt("sda"), now.Add(60*time.Millisecond)), ShouldBeTrue)
+			So(sl.admit(mt("sdc"), now.Add(60*time.Millisecond)), ShouldBeFalse)
+
+			sl.nextCollection(now.Add(120 * time.Millisecond))
+
+			// Assert
+			So(sl.admit(mt("sdc"), now.Add(120*time.Millisecond)), ShouldBeTrue)
+			So(sl.admit(mt("sdd"), now.Add(120*time.Millisecond)), ShouldBeFalse)
+			So(sl.windowSeries, ShouldContainKey, seriesKey(mt("sda")))
+		})
+
+		Convey("Dropping series doesn't slow down with size of window", func() {
+			// Arrange
+			pc, err := newLimitedPluginContext(SeriesLimits{}, `{"__maxSeriesPerWindow": 50000}`)
+			So(err, ShouldBeNil)
+
+			pc.ClearCollectorSession()
+			for i := 0; i < 50000; i++ {
+				_ = pc.AddMetric(fmt.Sprintf("/plugin/[disk=sd%d]/io_time", i), 1)
+			}
+
+			// Act
+			startTime := time.Now()
+			for i := 50000; i < 60000; i++ {
+				_ = pc.AddMetric(fmt.Sprintf("/plugin/[disk=sd%d]/io_time", i), 1)
+			}
+
+			// Assert
+			So(time.Since(startTime), ShouldBeLessThan, 2*time.Second)
+
+			ds, ok := pc.DroppedSeries()
+			So(ok, ShouldBeTrue)
+			So(ds.count, ShouldEqual, 10000)
+		})
+
+		Convey("Task configuration overrides plugin defaults", func() {
+			pc, err := newLimitedPluginContext(SeriesLimits{PerCollect: 1}, `{"__maxSeriesPerCollect": 0}`)
+			So(err, ShouldBeNil)
+			So(pc.series.limits.enabled(), ShouldBeFalse)
+
+			_, err = newLimitedPluginContext(SeriesLimits{}, `{"__maxSeriesPerCollect": -1}`)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
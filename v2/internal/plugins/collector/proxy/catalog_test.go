@@ -0,0 +1,52 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+)
+
+func TestMetricsCatalog(t *testing.T) {
+	Convey("Validate that catalog contains metrics defined by plugin", t, func() {
+		// Arrange
+		statsController, _ := stats.NewEmptyController()
+		cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", &diskCollector{}), statsController)
+
+		// Act
+		catalog := cm.MetricsCatalog()
+
+		// Assert
+		So(catalog, ShouldHaveLength, 2)
+		So(catalog[0], ShouldResemble, types.MetricDefinition{
+			Namespace:   "/plugin/[disk]/io_time",
+			Groups:      []types.MetricGroup{{Name: "disk", Description: "Disk name", Position: 1}},
+			Description: "Time spent doing I/O",
+			Unit:        "ms",
+			IsDefault:   true,
+		})
+		So(catalog[1].Namespace, ShouldEqual, "/plugin/[disk]/read_ops")
+		So(catalog[1].Unit, ShouldEqual, "")
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -49,15 +50,38 @@ func (m *modifiersMetadata) Dismiss() {
 
 ///////////////////////////////////////////////////////////////////////////////
 
+// Namespace of added metric validated against plugin definition (namespace handles keep it as a template)
+type resolvedNamespace struct {
+	elements []types.NamespaceElement
+	meta     metricMetadata
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
 type PluginContext struct {
 	*commonProxy.Context
 	ctx context.Context
 
 	taskID          string
+	forGroup        bool                      // context of shared collection (series limits and statistics are applied per task)
+	settingsMutex   sync.RWMutex              // guards settings replaced when task is reconfigured (configKey, metricsFilters, collectTimeout)
+	configKey       string                    // hash of task configuration (tasks with the same key may share collection)
 	metricsFilters  *metrictree.TreeValidator // metric filters defined by task (yaml)
 	sessionMtsMutex sync.RWMutex
 	sessionMts      []*types.Metric
+	sessionEvents   []*types.Event // events added during collection (guarded by sessionMtsMutex)
 	modifiersTable  []*modifiersMetadata
+	counters        *counterState        // previous values of series for which rate/delta is calculated
+	series          *seriesLimiter       // distinct series emitted by task (guarded by sessionMtsMutex)
+	flushCh         chan []*types.Metric // metrics sent with Flush() during collection
+	flushedMtsCount int64
+	collectTimeout  time.Duration   // maximum duration of collect request set in task config (0 - plugin default is used)
+	nsHandles       sync.Map        // namespace template -> *namespaceHandle
+	filtersGen      uint64          // incremented when filters are replaced (handles drop cached filtering results)
+	goPool          *workerPool     // pool used by Go()
+	workers         sync.WaitGroup  // functions running in background (started by Go() or worker pools)
+	collectMutex    sync.Mutex      // guards collectDone
+	collectDone     chan struct{}   // closed when user-defined Collect has returned (nil - it was never called)
 	ctxManager      *ContextManager // back-reference to context manager
 }
 
@@ -75,51 +99,158 @@ func NewPluginContext(ctxManager *ContextManager, taskID string, rawConfig []byt
 		Context:        baseContext,
 		ctx:            ctxManager.ctx,
 		taskID:         taskID,
+		configKey:      configKey(rawConfig),
 		metricsFilters: metrictree.NewMetricFilter(ctxManager.metricsDefinition),
+		counters:       newCounterState(),
+		flushCh:        make(chan []*types.Metric),
 		ctxManager:     ctxManager,
 		sessionMts:     nil,
 	}
 
+	limits, err := seriesLimitsFromConfig(baseContext, ctxManager.SeriesLimits)
+	if err != nil {
+		return nil, err
+	}
+	pc.series = newSeriesLimiter(limits)
+	pc.goPool = newWorkerPool(pc, defaultWorkersLimit, true)
+
+	if _, ok := baseContext.ConfigValue(plugin.CollectTimeoutConfigKey); ok {
+		pc.collectTimeout, err = baseContext.ConfigDuration(plugin.CollectTimeoutConfigKey)
+		if err != nil {
+			return nil, err
+		}
+		if pc.collectTimeout < 0 {
+			return nil, fmt.Errorf("config field %s: timeout can't be negative", plugin.CollectTimeoutConfigKey)
+		}
+	}
+
 	return pc, nil
 }
 
+func (pc *PluginContext) addFilters(mtsFilter []string) error {
+	for _, mtFilter := range mtsFilter {
+		// If requested metrics are not provided in config, snap sends requested metric in a form of "/*"
+		if mtFilter == RequestAllMetricsFilter {
+			continue
+		}
+
+		err := pc.metricsFilters.AddRule(mtFilter)
+		if err != nil {
+			return fmt.Errorf("wrong filtering rule (%v): %v", mtFilter, err)
+		}
+	}
+
+	return nil
+}
+
+// Take configuration and settings derived from it (filters, timeout, series limits) from context created for new configuration
+func (pc *PluginContext) applyConfig(newPc *PluginContext) {
+	pc.Context.SwapConfig(newPc.Context)
+
+	pc.settingsMutex.Lock()
+	pc.configKey = newPc.configKey
+	pc.metricsFilters = newPc.metricsFilters
+	pc.collectTimeout = newPc.collectTimeout
+	pc.settingsMutex.Unlock()
+
+	pc.sessionMtsMutex.Lock()
+	if pc.series.limits != newPc.series.limits {
+		pc.series = newPc.series
+	}
+	pc.sessionMtsMutex.Unlock()
+
+	// handles (also the ones held by user code) keep results of filtering
+	atomic.AddUint64(&pc.filtersGen, 1)
+}
+
+// Hash of current task configuration (changed when task is reconfigured)
+func (pc *PluginContext) taskConfigKey() string {
+	pc.settingsMutex.RLock()
+	defer pc.settingsMutex.RUnlock()
+
+	return pc.configKey
+}
+
+// Current task filters (validator is replaced, not modified, when task is reconfigured)
+func (pc *PluginContext) filters() *metrictree.TreeValidator {
+	pc.settingsMutex.RLock()
+	defer pc.settingsMutex.RUnlock()
+
+	return pc.metricsFilters
+}
+
+func (pc *PluginContext) taskCollectTimeout() time.Duration {
+	pc.settingsMutex.RLock()
+	defer pc.settingsMutex.RUnlock()
+
+	return pc.collectTimeout
+}
+
 func (pc *PluginContext) AddMetric(ns string, v interface{}, modifiers ...plugin.MetricModifier) error {
-	logF := log.WithCtx(pc.ctx).WithFields(moduleFields).WithField("service", "metrics")
+	err := pc.addMetric(ns, v, modifiers...)
+	if err != nil {
+		return err
+	}
+
+	if pc.flushThresholdExceeded() {
+		return pc.Flush()
+	}
+
+	return nil
+}
+
+func (pc *PluginContext) addMetric(ns string, v interface{}, modifiers ...plugin.MetricModifier) error {
+	err := pc.validateMetricValue(ns, v)
+	if err != nil {
+		return err
+	}
+
+	rns, err := pc.resolveNamespace(ns)
+	if err != nil {
+		return err
+	}
+
+	if !pc.matchFilters(ns) {
+		return nil // don't throw error when metric is just filtered
+	}
 
+	return pc.appendMetric(ns, rns.elements, &rns.meta, v, modifiers)
+}
+
+func (pc *PluginContext) validateMetricValue(ns string, v interface{}) error {
 	if pc.IsDone() {
 		return fmt.Errorf("task has been canceled")
 	}
 
-	if !pc.isValidValueType(v) {
+	if !types.IsValidValueType(v) {
 		return fmt.Errorf("invalid value type (%T) for metric: %s", v, ns)
 	}
+	if err := types.ValidateValue(v); err != nil {
+		return fmt.Errorf("invalid value for metric %s: %v", ns, err)
+	}
+
+	return nil
+}
 
+// Parse namespace of added metric and validate it against plugin definition
+func (pc *PluginContext) resolveNamespace(ns string) (*resolvedNamespace, error) {
 	parsedNs, err := metrictree.ParseNamespace(ns, false)
 	if err != nil {
-		return fmt.Errorf("invalid format of namespace: %v", err)
+		return nil, fmt.Errorf("invalid format of namespace: %v", err)
 	}
 	if !parsedNs.IsUsableForAddition(pc.ctxManager.metricsDefinition.HasRules(), false) {
-		return fmt.Errorf("invalid namespace (some elements can't be used when adding metric): %v", err)
+		return nil, fmt.Errorf("invalid namespace (some elements can't be used when adding metric): %v", err)
 	}
 
 	matchDefinition, groupPositions := pc.ctxManager.metricsDefinition.IsValid(ns)
-	matchFilters, _ := pc.metricsFilters.IsValid(ns)
-
 	if !matchDefinition {
-		return fmt.Errorf("couldn't match metric with plugin definition: %v", ns)
-	}
-
-	if !matchFilters {
-		if logrus.IsLevelEnabled(logrus.TraceLevel) {
-			logF.WithField("ns", ns).Trace("couldn't match metrics with plugin filters")
-		}
-		return nil // don't throw error when metric is just filtered
+		return nil, fmt.Errorf("couldn't match metric with plugin definition: %v", ns)
 	}
 
 	var mtNamespace []types.NamespaceElement
 	nsDefFormat, nsSeparator, err := metrictree.SplitNamespace(ns)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	nsDefFormat = nsDefFormat[1:]
 
@@ -137,7 +268,25 @@ func (pc *PluginContext) AddMetric(ns string, v interface{}, modifiers ...plugin
 	}
 
 	nsDescKey := nsSeparator + strings.Join(nsDefFormat, nsSeparator)
-	mtMeta := pc.metricMeta(nsDescKey)
+
+	return &resolvedNamespace{
+		elements: mtNamespace,
+		meta:     pc.metricMeta(nsDescKey),
+	}, nil
+}
+
+// Check if metric matches task filters
+func (pc *PluginContext) matchFilters(ns string) bool {
+	matchFilters, _ := pc.filters().IsValid(ns)
+	if !matchFilters && logrus.IsLevelEnabled(logrus.TraceLevel) {
+		logF := log.WithCtx(pc.ctx).WithFields(moduleFields).WithField("service", "metrics")
+		logF.WithField("ns", ns).Trace("couldn't match metrics with plugin filters")
+	}
+
+	return matchFilters
+}
+
+func (pc *PluginContext) appendMetric(ns string, mtNamespace []types.NamespaceElement, mtMeta *metricMetadata, v interface{}, modifiers []plugin.MetricModifier) error {
 
 	// if performance would suffer at some point in future proposed solution (indefinite chan) may be introduced
 	// https://github.com/solarwinds/snap-plugin-lib/pull/49/files#r390325795
@@ -151,6 +300,7 @@ func (pc *PluginContext) AddMetric(ns string, v interface{}, modifiers ...plugin
 		Unit_:        mtMeta.unit,
 		Timestamp_:   time.Now(),
 		Description_: mtMeta.description,
+		Kind_:        mtMeta.kind,
 	}
 
 	// modifiers related to AddMetric
@@ -164,19 +314,78 @@ func (pc *PluginContext) AddMetric(ns string, v interface{}, modifiers ...plugin
 			continue
 		}
 
-		isValid, _ := modElement.validator.IsValid(mt.Namespace().String())
-		if isValid {
+		if modElement.validator.IsValidWithTags(mt.Namespace().String(), mt.Tags()) {
 			for _, modifier := range modElement.modifiers {
 				modifier.UpdateMetric(mt)
 			}
 		}
 	}
 
+	// tag predicates of task filters are evaluated when all modifiers have been applied
+	if filters := pc.filters(); filters.HasTagPredicates() && !filters.IsValidWithTags(mt.Namespace().String(), mt.Tags()) {
+		return nil
+	}
+
+	if mt.Conversion_ != plugin.NoConversion {
+		emit, err := pc.counters.convert(mt)
+		if err != nil {
+			return fmt.Errorf("can't convert value of metric %s: %v", ns, err)
+		}
+		if !emit {
+			return nil
+		}
+	}
+
+	if !pc.series.admit(mt, time.Now()) {
+		return nil // new series above limit are dropped (reported with warning when collection is completed)
+	}
+
 	pc.sessionMts = append(pc.sessionMts, mt)
 
 	return nil
 }
 
+// Send metrics gathered so far (without waiting for the end of collection)
+func (pc *PluginContext) Flush() error {
+	if pc.IsDone() {
+		return fmt.Errorf("task has been canceled")
+	}
+
+	pc.sessionMtsMutex.Lock()
+	mts := pc.sessionMts
+	pc.sessionMts = nil
+	pc.sessionMtsMutex.Unlock()
+
+	if len(mts) == 0 {
+		return nil
+	}
+
+	select {
+	case pc.flushCh <- mts:
+		atomic.AddInt64(&pc.flushedMtsCount, int64(len(mts)))
+		return nil
+	case <-pc.Done():
+		return fmt.Errorf("task has been canceled")
+	}
+}
+
+func (pc *PluginContext) flushThresholdExceeded() bool {
+	threshold := pc.ctxManager.flushThreshold
+	if threshold <= 0 {
+		return false
+	}
+
+	pc.sessionMtsMutex.RLock()
+	defer pc.sessionMtsMutex.RUnlock()
+
+	return len(pc.sessionMts) >= threshold
+}
+
+// Number of metrics sent with Flush() during current collection
+func (pc *PluginContext) FlushedMetricsCount() int {
+	return int(atomic.LoadInt64(&pc.flushedMtsCount))
+}
+
 func (pc *PluginContext) ShouldProcess(ns string) bool {
 	parsedNs, err := metrictree.ParseNamespace(ns, false)
 	if err != nil {
@@ -187,7 +396,7 @@ func (pc *PluginContext) ShouldProcess(ns string) bool {
 	}
 
 	defValid := pc.ctxManager.metricsDefinition.IsPartiallyValid(ns)
-	shouldProcess := defValid && pc.metricsFilters.IsPartiallyValid(ns)
+	shouldProcess := defValid && pc.filters().IsPartiallyValid(ns)
 
 	return shouldProcess
 }
@@ -242,12 +451,57 @@ func (pc *PluginContext) extractStaticValue(s string) string {
 	return s
 }
 
+// Reserve context for user-defined Collect (false when the previous one hasn't returned yet, ie. it ignored timeout)
+func (pc *PluginContext) beginCollect() bool {
+	pc.collectMutex.Lock()
+	defer pc.collectMutex.Unlock()
+
+	if pc.collectDone != nil {
+		select {
+		case <-pc.collectDone:
+		default:
+			return false
+		}
+	}
+
+	pc.collectDone = make(chan struct{})
+	pc.ctxManager.runningCollects.Store(pc, struct{}{})
+	return true
+}
+
+// Should be called when user-defined Collect (started after beginCollect) has returned
+func (pc *PluginContext) endCollect() {
+	pc.collectMutex.Lock()
+	defer pc.collectMutex.Unlock()
+
+	close(pc.collectDone)
+	pc.ctxManager.runningCollects.Delete(pc)
+}
+
+// Channel closed when user-defined Collect isn't running
+func (pc *PluginContext) collectCompleted() <-chan struct{} {
+	pc.collectMutex.Lock()
+	defer pc.collectMutex.Unlock()
+
+	if pc.collectDone == nil {
+		doneCh := make(chan struct{})
+		close(doneCh)
+		return doneCh
+	}
+
+	return pc.collectDone
+}
+
 func (pc *PluginContext) ClearCollectorSession() {
 	pc.sessionMtsMutex.Lock()
 	defer pc.sessionMtsMutex.Unlock()
 
 	pc.sessionMts = nil
+	pc.sessionEvents = nil
 	pc.modifiersTable = nil
+	pc.counters.nextGeneration()
+	pc.series.nextCollection(time.Now())
+	atomic.StoreInt64(&pc.flushedMtsCount, 0)
 }
 
 func (pc *PluginContext) Metrics(clear bool) []*types.Metric {
@@ -262,33 +516,9 @@ func (pc *PluginContext) Metrics(clear bool) []*types.Metric {
 }
 
 func (pc *PluginContext) RequestedMetrics() []string {
-	return pc.metricsFilters.ListRules()
+	return pc.filters().ListRules()
 }
 
 func (pc *PluginContext) TaskID() string {
 	return pc.taskID
 }
-
-func (pc *PluginContext) isValidValueType(value interface{}) bool {
-	// when adding new type(s) apply changes also in toGRPCValue() function
-	switch value.(type) {
-	case string:
-	case float64:
-	case float32:
-	case int32:
-	case int:
-	case int64:
-	case uint32:
-	case uint64:
-	case uint:
-	case []byte:
-	case bool:
-	case int16:
-	case uint16:
-	case nil:
-	default:
-		return false
-	}
-
-	return true
-}
@@ -0,0 +1,188 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	staleSeriesCollects = 10 // number of collections after which previous value of not updated series is removed
+)
+
+type numericClass int
+
+const (
+	classSigned numericClass = iota
+	classUnsigned
+	classFloat
+)
+
+type counterSample struct {
+	class      numericClass
+	i          int64
+	u          uint64
+	f          float64
+	timestamp  time.Time
+	generation uint64
+}
+
+// Keeps previous values of series (namespace + tags) for which rate or delta is calculated
+type counterState struct {
+	mutex      sync.Mutex
+	samples    map[string]*counterSample
+	generation uint64
+}
+
+func newCounterState() *counterState {
+	return &counterState{
+		samples: map[string]*counterSample{},
+	}
+}
+
+// Should be called when new collection is started. Removes values of series which weren't updated recently.
+func (cs *counterState) nextGeneration() {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	cs.generation++
+
+	for key, sample := range cs.samples {
+		if cs.generation-sample.generation > staleSeriesCollects {
+			delete(cs.samples, key)
+		}
+	}
+}
+
+// Replace counter value of metric with rate or delta (according to requested conversion).
+// Returns false when metric shouldn't be emitted (first value of series).
+func (cs *counterState) convert(mt *types.Metric) (bool, error) {
+	current, ok := toCounterSample(mt.Value_)
+	if !ok {
+		return false, fmt.Errorf("value of type %T can't be used as a counter", mt.Value_)
+	}
+	current.timestamp = mt.Timestamp_
+
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+
+	current.generation = cs.generation
+
+	key := seriesKey(mt)
+	previous, found := cs.samples[key]
+	if found && previous.generation == cs.generation {
+		return false, nil // the same series was already added during current collection
+	}
+
+	cs.samples[key] = current
+	if !found || previous.class != current.class {
+		return false, nil
+	}
+
+	delta, deltaF := counterDelta(previous, current)
+
+	switch mt.Conversion_ {
+	case plugin.RateConversion:
+		elapsed := current.timestamp.Sub(previous.timestamp).Seconds()
+		if elapsed <= 0 {
+			return false, nil // rate can't be calculated (ie. timestamp set explicitly by user hasn't changed)
+		}
+		mt.Value_ = deltaF / elapsed
+		mt.Kind_ = plugin.MetricKindGauge
+	case plugin.DeltaConversion:
+		mt.Value_ = delta
+		mt.Kind_ = plugin.MetricKindDelta
+	}
+
+	return true, nil
+}
+
+// Calculate difference between samples (as a value of the same class and as float).
+// When current value is lower than previous one, counter is treated as reset (to 0)
+func counterDelta(previous, current *counterSample) (interface{}, float64) {
+	switch current.class {
+	case classSigned:
+		d := current.i - previous.i
+		if current.i < previous.i {
+			d = current.i
+		}
+		return d, float64(d)
+	case classUnsigned:
+		d := current.u - previous.u
+		if current.u < previous.u {
+			d = current.u
+		}
+		return d, float64(d)
+	default:
+		d := current.f - previous.f
+		if current.f < previous.f {
+			d = current.f
+		}
+		return d, d
+	}
+}
+
+func toCounterSample(v interface{}) (*counterSample, bool) {
+	switch n := v.(type) {
+	case int:
+		return &counterSample{class: classSigned, i: int64(n)}, true
+	case int16:
+		return &counterSample{class: classSigned, i: int64(n)}, true
+	case int32:
+		return &counterSample{class: classSigned, i: int64(n)}, true
+	case int64:
+		return &counterSample{class: classSigned, i: n}, true
+	case uint:
+		return &counterSample{class: classUnsigned, u: uint64(n)}, true
+	case uint16:
+		return &counterSample{class: classUnsigned, u: uint64(n)}, true
+	case uint32:
+		return &counterSample{class: classUnsigned, u: uint64(n)}, true
+	case uint64:
+		return &counterSample{class: classUnsigned, u: n}, true
+	case float32:
+		return &counterSample{class: classFloat, f: float64(n)}, true
+	case float64:
+		return &counterSample{class: classFloat, f: n}, true
+	}
+
+	return nil, false
+}
+
+// Series is identified by namespace and tags
+func seriesKey(mt *types.Metric) string {
+	keys := make([]string, 0, len(mt.Tags_))
+	for k := range mt.Tags_ {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sb := strings.Builder{}
+	sb.WriteString(mt.Namespace().String())
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf(";%s=%s", k, mt.Tags_[k]))
+	}
+
+	return sb.String()
+}
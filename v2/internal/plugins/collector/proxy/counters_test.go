@@ -0,0 +1,126 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func counterMetric(v interface{}, ts time.Time, conversion plugin.CounterConversion, tags map[string]string) *types.Metric {
+	return &types.Metric{
+		Namespace_:  []types.NamespaceElement{{Value_: "net"}, {Value_: "eth0"}, {Value_: "rx_bytes"}},
+		Value_:      v,
+		Tags_:       tags,
+		Timestamp_:  ts,
+		Kind_:       plugin.MetricKindCounter,
+		Conversion_: conversion,
+	}
+}
+
+func TestCounterState(t *testing.T) {
+	Convey("Validate that rate and delta are calculated from consecutive counter values", t, func() {
+		cs := newCounterState()
+		t0 := time.Now()
+
+		Convey("Rate", func() {
+			mt := counterMetric(uint64(1000), t0, plugin.RateConversion, nil)
+			emit, err := cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeFalse)
+
+			cs.nextGeneration()
+			mt = counterMetric(uint64(1600), t0.Add(2*time.Second), plugin.RateConversion, nil)
+			emit, err = cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeTrue)
+			So(mt.Value(), ShouldEqual, 300.0)
+			So(mt.Kind(), ShouldEqual, plugin.MetricKindGauge)
+
+			// counter reset
+			cs.nextGeneration()
+			mt = counterMetric(uint64(100), t0.Add(4*time.Second), plugin.RateConversion, nil)
+			emit, err = cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeTrue)
+			So(mt.Value(), ShouldEqual, 50.0)
+
+			// the same timestamp in next collection
+			cs.nextGeneration()
+			mt = counterMetric(uint64(200), t0.Add(4*time.Second), plugin.RateConversion, nil)
+			emit, err = cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeFalse)
+		})
+
+		Convey("Delta", func() {
+			mt := counterMetric(10, t0, plugin.DeltaConversion, map[string]string{"dir": "in"})
+			emit, _ := cs.convert(mt)
+			So(emit, ShouldBeFalse)
+
+			// different series (tags)
+			mt = counterMetric(15, t0.Add(time.Second), plugin.DeltaConversion, map[string]string{"dir": "out"})
+			emit, _ = cs.convert(mt)
+			So(emit, ShouldBeFalse)
+
+			cs.nextGeneration()
+			mt = counterMetric(25, t0.Add(time.Second), plugin.DeltaConversion, map[string]string{"dir": "in"})
+			emit, err := cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeTrue)
+			So(mt.Value(), ShouldEqual, int64(15))
+			So(mt.Kind(), ShouldEqual, plugin.MetricKindDelta)
+
+			// series added twice during the same collection (even with newer timestamp)
+			mt = counterMetric(30, t0.Add(2*time.Second), plugin.DeltaConversion, map[string]string{"dir": "in"})
+			emit, err = cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeFalse)
+			So(mt.Value(), ShouldEqual, 30)
+
+			// delta is calculated from value added first
+			cs.nextGeneration()
+			mt = counterMetric(40, t0.Add(3*time.Second), plugin.DeltaConversion, map[string]string{"dir": "in"})
+			emit, err = cs.convert(mt)
+			So(err, ShouldBeNil)
+			So(emit, ShouldBeTrue)
+			So(mt.Value(), ShouldEqual, int64(15))
+		})
+
+		Convey("Invalid value", func() {
+			_, err := cs.convert(counterMetric("text", t0, plugin.DeltaConversion, nil))
+			So(err, ShouldBeError)
+		})
+
+		Convey("Stale series are removed", func() {
+			_, _ = cs.convert(counterMetric(10, t0, plugin.DeltaConversion, nil))
+			So(cs.samples, ShouldHaveLength, 1)
+
+			for i := 0; i <= staleSeriesCollects; i++ {
+				cs.nextGeneration()
+			}
+			So(cs.samples, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	maxNoOfEvents = 1000 // maximum number of events added during one collect operation
+)
+
+func (pc *PluginContext) AddEvent(title string, text string, severity plugin.EventSeverity, modifiers ...plugin.EventModifier) error {
+	if pc.IsDone() {
+		return fmt.Errorf("task has been canceled")
+	}
+
+	if title == "" {
+		return fmt.Errorf("event title can't be empty")
+	}
+
+	if severity < plugin.EventSeverityInfo || severity > plugin.EventSeverityCritical {
+		return fmt.Errorf("invalid severity (%d) of event: %s", severity, title)
+	}
+
+	ev := &types.Event{
+		Title_:     title,
+		Text_:      text,
+		Severity_:  severity,
+		Timestamp_: time.Now(),
+	}
+
+	for _, m := range modifiers {
+		m.UpdateEvent(ev)
+	}
+
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	if len(pc.sessionEvents) >= maxNoOfEvents {
+		return fmt.Errorf("maximum number of events (%d) added during collection has been reached", maxNoOfEvents)
+	}
+
+	pc.sessionEvents = append(pc.sessionEvents, ev)
+
+	return nil
+}
+
+func (pc *PluginContext) Events(clear bool) []*types.Event {
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	evs := pc.sessionEvents
+	if clear {
+		pc.sessionEvents = nil
+	}
+	return evs
+}
@@ -0,0 +1,79 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func TestAddEvent(t *testing.T) {
+	Convey("Validate that events can be added during collection", t, func() {
+		pc := newDiskPluginContext(nil)
+
+		Convey("Events are kept with tags and timestamp", func() {
+			// Arrange
+			ts := time.Unix(1600000000, 0)
+
+			// Act
+			err1 := pc.AddEvent("Disk replaced", "sda was replaced", plugin.EventSeverityWarning,
+				plugin.EventTag("disk", "sda"), plugin.EventTimestamp(ts))
+			err2 := pc.AddEvent("Disk failure", "", plugin.EventSeverityCritical,
+				plugin.EventTags(map[string]string{"disk": "sdb", "dc": "1"}))
+
+			// Assert
+			So(err1, ShouldBeNil)
+			So(err2, ShouldBeNil)
+
+			events := pc.Events(true)
+			So(len(events), ShouldEqual, 2)
+			So(events[0].Title(), ShouldEqual, "Disk replaced")
+			So(events[0].Text(), ShouldEqual, "sda was replaced")
+			So(events[0].Severity(), ShouldEqual, plugin.EventSeverityWarning)
+			So(events[0].Tags(), ShouldResemble, map[string]string{"disk": "sda"})
+			So(events[0].Timestamp(), ShouldEqual, ts)
+			So(events[1].Severity().String(), ShouldEqual, "critical")
+			So(events[1].Tags(), ShouldResemble, map[string]string{"disk": "sdb", "dc": "1"})
+			So(events[1].Timestamp(), ShouldNotBeZeroValue)
+
+			So(pc.Events(false), ShouldBeEmpty)
+		})
+
+		Convey("Invalid events are rejected", func() {
+			So(pc.AddEvent("", "no title", plugin.EventSeverityInfo), ShouldNotBeNil)
+			So(pc.AddEvent("Title", "", plugin.EventSeverity(10)), ShouldNotBeNil)
+			So(pc.Events(false), ShouldBeEmpty)
+		})
+
+		Convey("Number of events added during collection is limited", func() {
+			for i := 0; i < maxNoOfEvents; i++ {
+				So(pc.AddEvent("Title", "", plugin.EventSeverityInfo), ShouldBeNil)
+			}
+
+			So(pc.AddEvent("Title", "", plugin.EventSeverityInfo), ShouldNotBeNil)
+
+			pc.ClearCollectorSession()
+			So(pc.Events(false), ShouldBeEmpty)
+			So(pc.AddEvent("Title", "", plugin.EventSeverityInfo), ShouldBeNil)
+		})
+	})
+}
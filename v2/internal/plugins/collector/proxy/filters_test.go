@@ -0,0 +1,100 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func TestTagFilters(t *testing.T) {
+	Convey("Validate that task filters with tag predicates are evaluated after modifiers", t, func() {
+		pc := newDiskPluginContext([]string{"/plugin/[disk]/io_time{env=prod,disk_type!~loop.*}"})
+
+		_, err := pc.AlwaysApply("/plugin/[disk=sda]/*", plugin.MetricTag("disk_type", "hdd"))
+		So(err, ShouldBeNil)
+		_, err = pc.AlwaysApply("/plugin/[disk=loop0]/*", plugin.MetricTag("disk_type", "loop"))
+		So(err, ShouldBeNil)
+
+		So(pc.AddMetric("/plugin/[disk=sda]/io_time", 10, plugin.MetricTag("env", "prod")), ShouldBeNil)
+		So(pc.AddMetric("/plugin/[disk=sdb]/io_time", 20, plugin.MetricTag("env", "dev")), ShouldBeNil)
+		So(pc.AddMetric("/plugin/[disk=loop0]/io_time", 30, plugin.MetricTag("env", "prod")), ShouldBeNil)
+		So(pc.AddMetric("/plugin/[disk=sda]/read_ops", 40, plugin.MetricTag("env", "prod")), ShouldBeNil)
+		So(pc.Namespace("/plugin/[disk]/io_time").AddWithModifiers(50, []string{"sdc"}, plugin.MetricTag("env", "prod")), ShouldBeNil)
+
+		mts := pc.Metrics(true)
+		So(mts, ShouldHaveLength, 2)
+		So(mts[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/io_time")
+		So(mts[1].Namespace().String(), ShouldEqual, "/plugin/[disk=sdc]/io_time")
+
+		So(pc.ShouldProcess("/plugin/[disk=sdb]/io_time"), ShouldBeTrue) // tags are not known yet
+		So(pc.ShouldProcess("/plugin/[disk=sdb]/read_ops"), ShouldBeFalse)
+	})
+
+	Convey("Validate that modifiers can be applied to metrics selected by tags", t, func() {
+		pc := newDiskPluginContext(nil)
+
+		_, err := pc.AlwaysApply("/plugin/*/*{env=prod}", plugin.MetricTag("critical", "true"))
+		So(err, ShouldBeNil)
+		_, err = pc.AlwaysApply("/plugin/*/*{env=~[}", plugin.MetricTag("critical", "true"))
+		So(err, ShouldBeError)
+
+		So(pc.AddMetric("/plugin/[disk=sda]/io_time", 10, plugin.MetricTag("env", "prod")), ShouldBeNil)
+		So(pc.AddMetric("/plugin/[disk=sdb]/io_time", 20, plugin.MetricTag("env", "dev")), ShouldBeNil)
+
+		mts := pc.Metrics(true)
+		So(mts, ShouldHaveLength, 2)
+		So(mts[0].Tags(), ShouldResemble, map[string]string{"env": "prod", "critical": "true"})
+		So(mts[1].Tags(), ShouldResemble, map[string]string{"env": "dev"})
+	})
+}
+
+func TestExcludingFilters(t *testing.T) {
+	Convey("Validate that metrics matching negated filters are not gathered", t, func() {
+		pc := newDiskPluginContext([]string{"/plugin/**", "!/plugin/[disk=loop0]/**", "!/plugin/[disk]/read_ops"})
+
+		So(pc.RequestedMetrics(), ShouldResemble, []string{"/plugin/**", "!/plugin/[disk=loop0]/**", "!/plugin/[disk]/read_ops"})
+
+		So(pc.ShouldProcess("/plugin/[disk=sda]"), ShouldBeTrue)
+		So(pc.ShouldProcess("/plugin/[disk=loop0]"), ShouldBeFalse)
+		So(pc.ShouldProcess("/plugin/[disk=loop0]/io_time"), ShouldBeFalse)
+
+		So(pc.AddMetric("/plugin/[disk=sda]/io_time", 10), ShouldBeNil)
+		So(pc.AddMetric("/plugin/[disk=sda]/read_ops", 20), ShouldBeNil)
+		So(pc.AddMetric("/plugin/[disk=loop0]/io_time", 30), ShouldBeNil)
+		So(pc.Namespace("/plugin/[disk]/io_time").Add(40, "loop0"), ShouldBeNil)
+
+		mts := pc.Metrics(true)
+		So(mts, ShouldHaveLength, 1)
+		So(mts[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/io_time")
+	})
+
+	Convey("Validate that negated filters have to be compatible with metric definition", t, func() {
+		statsController, _ := stats.NewEmptyController()
+		cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", &diskCollector{}), statsController)
+
+		So(cm.LoadTask("task-1", []byte("{}"), []string{"!/plugin/[disk]/write_ops"}), ShouldBeError)
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/metrictree"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
@@ -49,19 +51,31 @@ const (
 	unloadRetryInterval = 1 * time.Second
 
 	streamingCheckInterval = 1 * time.Second
+
+	deadlineMargin = 200 * time.Millisecond // time reserved for sending partial results before request deadline
 )
 
 type Collector interface {
-	RequestCollect(id string) <-chan types.CollectChunk
+	RequestCollect(ctx context.Context, id string) <-chan types.CollectChunk
 	LoadTask(id string, config []byte, selectors []string) error
 	UnloadTask(id string) error
+	ReconfigureTask(id string, config []byte, selectors []string) error
 	CustomInfo(id string) ([]byte, error)
+	CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error)
+	CheckPluginHealth(ctx context.Context) types.HealthReport
+	Shutdown(ctx context.Context)
+	MetricsCatalog() []types.MetricDefinition
 }
 
 type metricMetadata struct {
 	isDefault   bool
 	description string
 	unit        string
+	kind        plugin.MetricKind
+}
+
+func (m *metricMetadata) SetKind(kind plugin.MetricKind) {
+	m.kind = kind
 }
 
 type ContextManager struct {
@@ -76,6 +90,18 @@ type ContextManager struct {
 	metricsMetadata   map[string]metricMetadata // metadata associated with each metric (is default?, description, unit)
 	groupsDescription map[string]string         // description associated with each group (dynamic element)
 
+	flushThreshold int // number of metrics after which chunk is sent automatically (0 - disabled)
+
+	collectSharing     bool                     // results of collection are shared by tasks with identical configuration
+	minCollectInterval time.Duration            // results of collection younger than interval are served from cache
+	collectGroups      map[string]*collectGroup // config key -> tasks sharing results of collection
+	collectGroupsMutex sync.Mutex
+
+	runningCollects sync.Map // (synced map[*PluginContext]struct{}) contexts of user-defined Collect calls which haven't returned yet
+
+	CollectTimeout time.Duration // default maximum duration of collect request (0 - no limit)
+	SeriesLimits   SeriesLimits  // default limits of distinct series emitted by a task
+
 	statsController stats.Controller // reference to statistics controller
 }
 
@@ -92,6 +118,8 @@ func NewContextManager(ctx context.Context, collector types.Collector, statsCont
 		metricsMetadata:   map[string]metricMetadata{},
 		groupsDescription: map[string]string{},
 
+		collectGroups: map[string]*collectGroup{},
+
 		statsController: statsController,
 	}
 
@@ -107,76 +135,153 @@ func (cm *ContextManager) Context() context.Context {
 	return cm.ctx
 }
 
-func (cm *ContextManager) RequestCollect(id string) <-chan types.CollectChunk {
+// Request collection for a given task. Deadline of ctx (if set) limits duration of collection (see also CollectTimeout)
+func (cm *ContextManager) RequestCollect(ctx context.Context, id string) <-chan types.CollectChunk {
 	chunkCh := make(chan types.CollectChunk)
-	go cm.requestCollect(id, chunkCh)
+	go cm.requestCollect(ctx, id, chunkCh)
 	return chunkCh
 }
 
-func (cm *ContextManager) requestCollect(id string, chunkCh chan<- types.CollectChunk) {
+func (cm *ContextManager) requestCollect(ctx context.Context, id string, chunkCh chan<- types.CollectChunk) {
 	if !cm.AcquireTask(id) {
-		chunkCh <- types.CollectChunk{
+		sendChunk(ctx, chunkCh, types.CollectChunk{
 			Err: fmt.Errorf("can't process collect request, other request for the same id (%s) is in progress", id),
-		}
+		})
 		close(chunkCh)
 		return
 	}
 
 	contextIf, ok := cm.contextMap.Load(id)
 	if !ok {
-		chunkCh <- types.CollectChunk{
+		sendChunk(ctx, chunkCh, types.CollectChunk{
 			Err: fmt.Errorf("can't find a context for a given id: %s", id),
-		}
+		})
 		close(chunkCh)
 		return
 	}
 
 	pContext := contextIf.(*PluginContext)
 
+	if cm.collectSharing && cm.collector.Type() == types.PluginTypeCollector {
+		cm.sharedCollect(ctx, id, pContext, chunkCh)
+		cm.MarkTaskAsCompleted(id)
+		return
+	}
+
+	// session can't be cleared until previous user-defined Collect returns (it may still add metrics)
+	if !cm.beginCollect(ctx, pContext) {
+		cm.MarkTaskAsCompleted(id)
+		sendChunk(ctx, chunkCh, types.CollectChunk{
+			Err: fmt.Errorf("can't process collect request, previous collect for the same id (%s) is still running", id),
+		})
+		close(chunkCh)
+		return
+	}
+
 	pContext.AttachContext(cm.TaskContext(id))
 	pContext.ClearCollectorSession()
 	pContext.ResetWarnings()
 
 	switch cm.collector.Type() {
 	case types.PluginTypeCollector:
-		cm.collect(id, pContext, chunkCh)
+		cm.collect(ctx, id, pContext, chunkCh, cm.collectTimeout(ctx, pContext))
 	case types.PluginTypeStreamingCollector:
-		cm.streamingCollect(id, pContext, chunkCh)
+		cm.streamingCollect(ctx, id, pContext, chunkCh)
 	}
 
 	cm.MarkTaskAsCompleted(id)
 	pContext.ReleaseContext()
 }
 
-func (cm *ContextManager) collect(id string, context *PluginContext, chunkCh chan<- types.CollectChunk) {
+// Wait (no longer than collect request may last) until user-defined Collect started by previous request returns
+// and reserve context for the next one
+func (cm *ContextManager) beginCollect(ctx context.Context, pContext *PluginContext) bool {
+	var timeoutCh <-chan time.Time
+	if timeout := cm.collectTimeout(ctx, pContext); timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	for !pContext.beginCollect() {
+		select {
+		case <-pContext.collectCompleted():
+		case <-timeoutCh:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// Calculate maximum duration of collect request: task config overrides plugin default, request deadline limits both
+func (cm *ContextManager) collectTimeout(ctx context.Context, pContext *PluginContext) time.Duration {
+	timeout := cm.CollectTimeout
+	if taskTimeout := pContext.taskCollectTimeout(); taskTimeout > 0 {
+		timeout = taskTimeout
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		untilDeadline := time.Until(deadline)
+		if untilDeadline > 2*deadlineMargin {
+			untilDeadline -= deadlineMargin
+		}
+		if untilDeadline <= 0 {
+			untilDeadline = time.Nanosecond
+		}
+
+		if timeout == 0 || untilDeadline < timeout {
+			timeout = untilDeadline
+		}
+	}
+
+	return timeout
+}
+
+// Call user-defined Collect (context has to be reserved with beginCollect, it's released when Collect returns).
+// Chunks are sent to chunkCh until ctx (of the request) is done
+func (cm *ContextManager) collect(ctx context.Context, id string, context *PluginContext, chunkCh chan<- types.CollectChunk, timeout time.Duration) {
 	logF := cm.logger()
 	taskCtx := cm.TaskContext(id)
 
 	var mts []*types.Metric
 	var warnings []types.Warning
+	var events []*types.Event
 	var err error
 
+	startTime := time.Now()
+
 	go func() {
 		defer func() {
 			// catch panics (since it's running in it's own goroutine)
 			if r := recover(); r != nil {
 				logF.WithError(fmt.Errorf("%v", r)).Error("user-defined function has ended with panic")
 				logF.WithField("block", "recover").Trace(string(debug.Stack()))
-				err = fmt.Errorf("user-defined function has ended with panic: %v", r)
+				err = fmt.Errorf("user-defined function has ended with panic: %s", secrets.Redact(fmt.Sprintf("%v", r)))
 			}
 
-			cm.ReleaseTask(id)
+			context.waitForWorkers() // also when Collect has ended with panic (workers still use session)
+			context.endCollect()
+			cm.ReleaseTaskContext(id, taskCtx)
 		}()
 
-		startTime := time.Now()
 		err = cm.collector.Collect(context) // calling to user defined code
+		context.waitForWorkers()
 		endTime := time.Now()
 
-		if !context.Context.IsDone() {
+		if taskCtx.Err() == nil { // session of timed out collection has been already sent
+			cm.reportDroppedSeries(id, context)
+
 			mts = context.Metrics(false)
 			warnings = context.Warnings(false)
+			events = context.Events(false)
+			mtsCount := len(mts) + context.FlushedMetricsCount()
 
-			cm.statsController.UpdateExecutionStat(id, len(mts), err != nil, startTime, endTime)
+			if !context.forGroup { // shared collection is accounted to each task (with metrics requested by it)
+				cm.statsController.UpdateExecutionStat(id, mtsCount, err != nil, startTime, endTime)
+			}
 
 			if err != nil {
 				err = fmt.Errorf("user-defined Collect method ended with error: %v", err)
@@ -184,8 +289,9 @@ func (cm *ContextManager) collect(id string, context *PluginContext, chunkCh cha
 
 			logF.WithFields(logrus.Fields{
 				"elapsed":      endTime.Sub(startTime).String(),
-				"metrics-num":  len(mts),
+				"metrics-num":  mtsCount,
 				"warnings-num": len(warnings),
+				"events-num":   len(events),
 			}).Debug("Collect completed")
 		} else {
 			logF.WithFields(logrus.Fields{
@@ -194,18 +300,83 @@ func (cm *ContextManager) collect(id string, context *PluginContext, chunkCh cha
 		}
 	}()
 
-	<-taskCtx.Done()
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	// send metrics flushed by user code until collection is completed
+	for flushing := true; flushing; {
+		select {
+		case flushedMts := <-context.flushCh:
+			if !sendChunk(ctx, chunkCh, types.CollectChunk{Metrics: flushedMts}) {
+				logF.Warning("Collect request has been abandoned, collection will be canceled")
+				cm.ReleaseTask(id) // user code is notified via ctx.Done()
+				close(chunkCh)
+				return
+			}
+		case <-taskCtx.Done():
+			flushing = false
+		case <-timeoutCh:
+			if taskCtx.Err() != nil {
+				continue // collection has just completed
+			}
 
-	chunkCh <- types.CollectChunk{
+			cm.collectTimedOut(ctx, id, context, chunkCh, timeout, startTime)
+			close(chunkCh)
+			return
+		}
+	}
+
+	sendChunk(ctx, chunkCh, types.CollectChunk{
 		Metrics:  mts,
 		Warnings: warnings,
+		Events:   events,
 		Err:      err,
-	}
+	})
 
 	close(chunkCh)
 }
 
-func (cm *ContextManager) streamingCollect(id string, context *PluginContext, chunkCh chan<- types.CollectChunk) {
+// Cancel collection which exceeded timeout and send metrics gathered so far
+func (cm *ContextManager) collectTimedOut(ctx context.Context, id string, context *PluginContext, chunkCh chan<- types.CollectChunk, timeout time.Duration, startTime time.Time) {
+	logF := cm.logger().WithField("task-id", id)
+
+	cm.ReleaseTask(id) // user code is notified via ctx.Done()
+
+	cm.reportDroppedSeries(id, context)
+
+	mts := context.Metrics(true)
+	events := context.Events(true)
+	warnings := append([]types.Warning{}, context.Warnings(false)...)
+	mtsCount := len(mts) + context.FlushedMetricsCount()
+	endTime := time.Now()
+
+	if !context.forGroup {
+		cm.statsController.UpdateTimeoutStat(id, mtsCount, startTime, endTime)
+	}
+
+	logF.WithFields(logrus.Fields{
+		"timeout":     timeout.String(),
+		"metrics-num": mtsCount,
+	}).Warning("Collect exceeded timeout and has been canceled, partial results will be sent")
+
+	warnings = append(warnings, types.Warning{
+		Message:   fmt.Sprintf("collect exceeded timeout (%s), only metrics gathered before timeout are sent", timeout),
+		Timestamp: endTime,
+	})
+
+	sendChunk(ctx, chunkCh, types.CollectChunk{
+		Metrics:  mts,
+		Warnings: warnings,
+		Events:   events,
+		Err:      fmt.Errorf("%w (%s)", types.ErrCollectTimeout, timeout),
+	})
+}
+
+func (cm *ContextManager) streamingCollect(ctx context.Context, id string, context *PluginContext, chunkCh chan<- types.CollectChunk) {
 	logF := cm.logger()
 	var err error
 
@@ -219,9 +390,10 @@ func (cm *ContextManager) streamingCollect(id string, context *PluginContext, ch
 			if r := recover(); r != nil {
 				logF.WithError(fmt.Errorf("%v", r)).Error("user-defined function has ended with panic")
 				logF.WithField("block", "recover").Trace(string(debug.Stack()))
-				err = fmt.Errorf("user-defined function has ended with panic: %v", r)
+				err = fmt.Errorf("user-defined function has ended with panic: %s", secrets.Redact(fmt.Sprintf("%v", r)))
 			}
 
+			context.endCollect()
 			cm.ReleaseTask(id)
 		}()
 
@@ -231,33 +403,78 @@ func (cm *ContextManager) streamingCollect(id string, context *PluginContext, ch
 	for {
 		select {
 		case <-taskCtx.Done():
-			cm.handleChunk(id, err, context, chunkCh, startTime)
+			cm.handleChunk(ctx, id, err, context, chunkCh, startTime)
 			close(chunkCh)
 			return
+		case <-ctx.Done():
+			cm.ReleaseTask(id) // nobody receives chunks anymore, user code is notified via ctx.Done()
+		case flushedMts := <-context.flushCh:
+			if sendChunk(ctx, chunkCh, types.CollectChunk{Metrics: flushedMts}) {
+				cm.statsController.UpdateStreamingStat(id, len(flushedMts), startTime, time.Now())
+			}
 		case <-time.After(streamingCheckInterval):
-			cm.handleChunk(id, err, context, chunkCh, startTime)
+			cm.handleChunk(ctx, id, err, context, chunkCh, startTime)
 		}
 	}
 }
 
-func (cm *ContextManager) handleChunk(id string, err error, context *PluginContext, chunkCh chan<- types.CollectChunk, startTime time.Time) {
+func (cm *ContextManager) handleChunk(ctx context.Context, id string, err error, context *PluginContext, chunkCh chan<- types.CollectChunk, startTime time.Time) {
+	cm.reportDroppedSeries(id, context)
+
 	mts := context.Metrics(true)
 	warnings := context.Warnings(true)
+	events := context.Events(true)
 
-	if len(mts) > 0 || len(warnings) > 0 || err != nil {
+	if len(mts) > 0 || len(warnings) > 0 || len(events) > 0 || err != nil {
 		lastUpdate := time.Now()
 
-		chunkCh <- types.CollectChunk{
+		if sendChunk(ctx, chunkCh, types.CollectChunk{
 			Metrics:  mts,
 			Warnings: warnings,
+			Events:   events,
 			Err:      err,
+		}) {
+			cm.statsController.UpdateStreamingStat(id, len(mts), startTime, lastUpdate)
 		}
+	}
+}
+
+// Send chunk unless request has been abandoned (ctx is done), return true when chunk was sent
+func sendChunk(ctx context.Context, chunkCh chan<- types.CollectChunk, chunk types.CollectChunk) bool {
+	select {
+	case chunkCh <- chunk:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
 
-		cm.statsController.UpdateStreamingStat(id, len(mts), startTime, lastUpdate)
+// Inform about series dropped due to exceeded limits (via warning, log and statistics)
+func (cm *ContextManager) reportDroppedSeries(id string, context *PluginContext) {
+	if msg, ok := cm.droppedSeriesWarning(id, context); ok {
+		context.AddWarning(msg)
 	}
 }
 
-func (cm *ContextManager) LoadTask(id string, rawConfig []byte, mtsFilter []string) error {
+// Log and count series dropped due to exceeded limits, return message of warning (false if there were none)
+func (cm *ContextManager) droppedSeriesWarning(id string, context *PluginContext) (string, bool) {
+	ds, ok := context.DroppedSeries()
+	if !ok {
+		return "", false
+	}
+
+	cm.logger().WithFields(logrus.Fields{
+		"task-id":        id,
+		"dropped-series": ds.count,
+		"namespaces":     countsToString(ds.topNamespaces),
+		"tag-keys":       countsToString(ds.topTagKeys),
+	}).Warning("Series limit exceeded, new series have been dropped")
+
+	cm.statsController.UpdateSeriesLimitStat(id, ds.count, ds.topNamespaces, ds.topTagKeys)
+	return fmt.Sprintf("series limit exceeded: %s", ds), true
+}
+
+func (cm *ContextManager) LoadTask(id string, rawConfig []byte, mtsFilter []string) (err error) {
 	if !cm.AcquireTask(id) {
 		return fmt.Errorf("can't process load request, other request for the same id (%s) is in progress", id)
 	}
@@ -266,21 +483,31 @@ func (cm *ContextManager) LoadTask(id string, rawConfig []byte, mtsFilter []stri
 	if _, ok := cm.contextMap.Load(id); ok {
 		return errors.New("context with given id was already defined")
 	}
-	newCtx, err := NewPluginContext(cm, id, rawConfig)
+
+	config, redactedConfig, err := cm.PrepareConfig(id, rawConfig)
 	if err != nil {
 		return fmt.Errorf("can't load task: %v", err)
 	}
 
-	for _, mtFilter := range mtsFilter {
-		// If requested metrics are not provided in config, snap sends requested metric in a form of "/*"
-		if mtFilter == RequestAllMetricsFilter {
-			continue
-		}
-
-		err := newCtx.metricsFilters.AddRule(mtFilter)
+	// secrets of a task which couldn't be loaded shouldn't be kept (nor leaked via returned error)
+	defer func() {
 		if err != nil {
-			return fmt.Errorf("wrong filtering rule (%v): %v", mtFilter, err)
+			err = secrets.RedactError(err)
+			cm.ReleaseSecrets(id)
 		}
+	}()
+
+	newCtx, err := NewPluginContext(cm, id, config)
+	if err != nil {
+		return fmt.Errorf("can't load task: %v", err)
+	}
+
+	cm.AttachPersistentState(newCtx.Context, id)
+	cm.AttachSharedStore(newCtx.Context)
+
+	err = newCtx.addFilters(mtsFilter)
+	if err != nil {
+		return err
 	}
 
 	if loadable, ok := cm.collector.Unwrap().(plugin.LoadableCollector); ok {
@@ -291,7 +518,100 @@ func (cm *ContextManager) LoadTask(id string, rawConfig []byte, mtsFilter []stri
 	}
 
 	cm.contextMap.Store(id, newCtx)
-	cm.statsController.UpdateLoadStat(id, string(rawConfig), mtsFilter)
+	if cm.collectSharing {
+		cm.invalidateCollectGroup(newCtx.taskConfigKey())
+	}
+	cm.statsController.UpdateLoadStat(id, string(redactedConfig), mtsFilter)
+
+	return nil
+}
+
+// Apply new configuration (and filters) of a loaded task. When collector doesn't implement plugin.ReconfigurableCollector
+// (or task is streaming), task is unloaded and loaded again.
+func (cm *ContextManager) ReconfigureTask(id string, rawConfig []byte, mtsFilter []string) (err error) {
+	reconfigurable, ok := cm.collector.Unwrap().(plugin.ReconfigurableCollector)
+	if !ok || cm.collector.Type() == types.PluginTypeStreamingCollector {
+		return cm.reloadTask(id, rawConfig, mtsFilter)
+	}
+
+	if !cm.AcquireTask(id) {
+		return fmt.Errorf("can't process reconfigure request, other request for the same id (%s) is in progress", id)
+	}
+	defer cm.MarkTaskAsCompleted(id)
+
+	contextI, ok := cm.contextMap.Load(id)
+	if !ok {
+		return errors.New("context with given id is not defined")
+	}
+	pContext := contextI.(*PluginContext)
+
+	config, redactedConfig, completeFn, err := cm.PrepareNewConfig(id, rawConfig)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	applied := false
+	defer func() {
+		if err != nil {
+			err = secrets.RedactError(err)
+		}
+		completeFn(applied)
+	}()
+
+	newCtx, err := NewPluginContext(cm, id, config)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	err = newCtx.addFilters(mtsFilter)
+	if err != nil {
+		return err
+	}
+
+	err = reconfigurable.Reconfigure(pContext, commonProxy.NewConfigChange(pContext.Context, newCtx.Context))
+	if err != nil {
+		return fmt.Errorf("new configuration has been rejected by user-defined function: %s", err)
+	}
+
+	prevConfigKey := pContext.taskConfigKey()
+	pContext.applyConfig(newCtx)
+	applied = true
+
+	if cm.collectSharing {
+		cm.invalidateCollectGroup(prevConfigKey)
+		cm.invalidateCollectGroup(pContext.taskConfigKey())
+	}
+
+	cm.statsController.UpdateReconfigureStat(id, string(redactedConfig), mtsFilter)
+
+	return nil
+}
+
+// Apply new configuration by unloading and loading task again (new configuration is validated before unload)
+func (cm *ContextManager) reloadTask(id string, rawConfig []byte, mtsFilter []string) error {
+	config, _, completeFn, err := cm.PrepareNewConfig(id, rawConfig)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	newCtx, err := NewPluginContext(cm, id, config)
+	if err == nil {
+		err = newCtx.addFilters(mtsFilter)
+	}
+	completeFn(false)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", secrets.RedactError(err))
+	}
+
+	err = cm.UnloadTask(id)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	err = cm.LoadTask(id, rawConfig, mtsFilter)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task (task has been unloaded): %v", err)
+	}
 
 	return nil
 }
@@ -333,6 +653,10 @@ func (cm *ContextManager) UnloadTask(id string) error {
 	}
 
 	cm.contextMap.Delete(id)
+	if cm.collectSharing {
+		cm.invalidateCollectGroup(pluginCtx.taskConfigKey())
+	}
+	cm.ReleaseSecrets(id)
 	cm.statsController.UpdateUnloadStat(id)
 
 	return nil
@@ -361,10 +685,80 @@ func (cm *ContextManager) CustomInfo(id string) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// Run health checks defined by plugin (all tasks are checked when taskID is empty)
+func (cm *ContextManager) CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error) {
+	// Do not call cm.AcquireTask, health may be checked when other request is in progress
+
+	tasks := map[string]plugin.Context{}
+	cm.contextMap.Range(func(k, v interface{}) bool {
+		tasks[k.(string)] = v.(*PluginContext)
+		return true
+	})
+
+	return commonProxy.CheckHealth(ctx, cm.collector.Unwrap(), tasks, taskID)
+}
+
+// Run plugin-wide health check defined by plugin (used for liveness)
+func (cm *ContextManager) CheckPluginHealth(ctx context.Context) types.HealthReport {
+	return commonProxy.CheckPluginHealth(ctx, cm.collector.Unwrap())
+}
+
+// Call plugin-wide initialization hook (when implemented). Used once, before plugin starts serving requests.
+func (cm *ContextManager) Init(ctx context.Context) error {
+	initializable, ok := cm.collector.Unwrap().(plugin.InitializableCollector)
+	if !ok {
+		return nil
+	}
+
+	return commonProxy.Initialize(func() error {
+		return initializable.Init(ctx, cm.Shared)
+	})
+}
+
+// Unload all tasks and call plugin-wide shutdown hook (when implemented). Used when plugin is being stopped.
+func (cm *ContextManager) Shutdown(ctx context.Context) {
+	ids := []string{}
+	cm.contextMap.Range(func(k, _ interface{}) bool {
+		ids = append(ids, k.(string))
+		return true
+	})
+
+	var shutdownFn func(ctx context.Context) error
+	if shutdownable, ok := cm.collector.Unwrap().(plugin.ShutdownableCollector); ok {
+		shutdownFn = shutdownable.Shutdown
+	}
+
+	// tasks have been canceled, but user-defined Collect may still be running (Unload shouldn't be called in parallel)
+	if !cm.waitForCollects(ctx) {
+		cm.logger().Warning("Collect hasn't completed before shutdown timeout, tasks will be unloaded anyway")
+	}
+
+	commonProxy.Shutdown(ctx, cm.logger(), ids, cm.UnloadTask, shutdownFn)
+}
+
+// Wait until all user-defined Collect calls return (false when ctx is done before)
+func (cm *ContextManager) waitForCollects(ctx context.Context) bool {
+	var running []*PluginContext
+	cm.runningCollects.Range(func(k, _ interface{}) bool {
+		running = append(running, k.(*PluginContext))
+		return true
+	})
+
+	for _, pc := range running {
+		select {
+		case <-pc.collectCompleted():
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // plugin.CollectorDefinition related methods
 
-func (cm *ContextManager) DefineMetric(ns string, unit string, isDefault bool, description string) {
+func (cm *ContextManager) DefineMetric(ns string, unit string, isDefault bool, description string, modifiers ...plugin.MetricDefinitionModifier) {
 	logF := cm.logger()
 
 	err := cm.metricsDefinition.AddRule(ns)
@@ -372,11 +766,17 @@ func (cm *ContextManager) DefineMetric(ns string, unit string, isDefault bool, d
 		logF.WithFields(moduleFields).WithError(err).WithFields(logrus.Fields{"namespace": ns}).Fatal("Wrong metric definition")
 	}
 
-	cm.metricsMetadata[ns] = metricMetadata{
+	mtMeta := metricMetadata{
 		isDefault:   isDefault,
 		description: description,
 		unit:        unit,
 	}
+
+	for _, m := range modifiers {
+		m.UpdateMetricDefinition(&mtMeta)
+	}
+
+	cm.metricsMetadata[ns] = mtMeta
 }
 
 // Define description for dynamic element
@@ -384,6 +784,28 @@ func (cm *ContextManager) DefineGroup(name string, description string) {
 	cm.groupsDescription[name] = description
 }
 
+// Define number of metrics after which collected chunk is sent automatically
+func (cm *ContextManager) DefineFlushThreshold(metricsCount int) error {
+	if metricsCount < 0 {
+		return fmt.Errorf("invalid flush threshold")
+	}
+
+	cm.flushThreshold = metricsCount
+	return nil
+}
+
+// Enable sharing of collection results between tasks with identical configuration.
+// Results younger than interval are served from cache (0 - only collection in progress is shared)
+func (cm *ContextManager) DefineMinCollectInterval(interval time.Duration) error {
+	if interval < 0 {
+		return fmt.Errorf("invalid minimum collect interval")
+	}
+
+	cm.collectSharing = true
+	cm.minCollectInterval = interval
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 func (cm *ContextManager) RequestPluginDefinition() {
@@ -412,6 +834,43 @@ func (cm *ContextManager) ListDefaultMetrics() []string {
 	return result
 }
 
+// List metrics defined by plugin together with descriptions of their groups (sorted by namespace)
+func (cm *ContextManager) MetricsCatalog() []types.MetricDefinition {
+	catalog := make([]types.MetricDefinition, 0, len(cm.metricsMetadata))
+
+	for ns, meta := range cm.metricsMetadata {
+		mtDef := types.MetricDefinition{
+			Namespace:   ns,
+			Description: meta.description,
+			Unit:        meta.unit,
+			IsDefault:   meta.isDefault,
+			Kind:        meta.kind,
+		}
+
+		nsElems, _, _ := metrictree.SplitNamespace(ns) // namespace was validated when metric was defined
+		for i, el := range nsElems[1:] {
+			if !strings.HasPrefix(el, "[") || !strings.HasSuffix(el, "]") {
+				continue
+			}
+
+			groupName := el[1 : len(el)-1]
+			mtDef.Groups = append(mtDef.Groups, types.MetricGroup{
+				Name:        groupName,
+				Description: cm.groupsDescription[groupName],
+				Position:    i,
+			})
+		}
+
+		catalog = append(catalog, mtDef)
+	}
+
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Namespace < catalog[j].Namespace
+	})
+
+	return catalog
+}
+
 func (cm *ContextManager) logger() logrus.FieldLogger {
 	return log.WithCtx(cm.ctx).WithFields(moduleFields).WithField("service", "manager")
 }
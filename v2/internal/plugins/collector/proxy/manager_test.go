@@ -0,0 +1,244 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+// Collector which doesn't respect ctx.Done(): the first call returns only when releaseCh is closed
+type stubbornDiskCollector struct {
+	releaseCh chan struct{}
+
+	mutex    sync.Mutex
+	contexts []plugin.CollectContext // contexts passed to subsequent calls
+	returned bool                    // the first call has returned
+	unloaded []bool                  // value of returned observed by subsequent Unload calls
+}
+
+func (*stubbornDiskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	return nil
+}
+
+func (c *stubbornDiskCollector) Collect(ctx plugin.CollectContext) error {
+	c.mutex.Lock()
+	c.contexts = append(c.contexts, ctx)
+	n := len(c.contexts)
+	c.mutex.Unlock()
+
+	_ = ctx.AddMetric("/plugin/[disk=sda]/io_time", n)
+	if n == 1 {
+		<-c.releaseCh
+		_ = ctx.AddMetric("/plugin/[disk=sdb]/io_time", n)
+
+		c.mutex.Lock()
+		c.returned = true
+		c.mutex.Unlock()
+	}
+
+	return nil
+}
+
+func (c *stubbornDiskCollector) Unload(_ plugin.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.unloaded = append(c.unloaded, c.returned)
+	return nil
+}
+
+func (c *stubbornDiskCollector) calls() []plugin.CollectContext {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return append([]plugin.CollectContext{}, c.contexts...)
+}
+
+func TestCollectTimeout(t *testing.T) {
+	Convey("Validate that Collect ignoring timeout affects neither next collection nor unload", t, func() {
+		statsController, _ := stats.NewEmptyController()
+
+		collector := &stubbornDiskCollector{releaseCh: make(chan struct{})}
+		cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+		cm.CollectTimeout = 50 * time.Millisecond
+
+		Convey("Next collection of a task waits until previous one returns", func() {
+			// Arrange
+			So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+
+			mts, err := collectMetrics(cm, "task-1")
+			So(errors.Is(err, types.ErrCollectTimeout), ShouldBeTrue)
+			So(mts, ShouldHaveLength, 1)
+
+			// Act - previous Collect is still running
+			mts, err = collectMetrics(cm, "task-1")
+
+			// Assert
+			So(err, ShouldBeError)
+			So(err.Error(), ShouldContainSubstring, "previous collect for the same id (task-1) is still running")
+			So(mts, ShouldBeEmpty)
+			So(collector.calls(), ShouldHaveLength, 1)
+
+			// Act - previous Collect returns
+			close(collector.releaseCh)
+			mts, err = collectMetrics(cm, "task-1")
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/io_time")
+			So(mts[0].Value(), ShouldEqual, 2)
+		})
+
+		Convey("Next shared collection doesn't reuse context of previous one", func() {
+			// Arrange
+			So(cm.DefineMinCollectInterval(0), ShouldBeNil)
+			So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+			So(cm.LoadTask("task-2", []byte("{}"), nil), ShouldBeNil)
+
+			_, err := collectMetrics(cm, "task-1")
+			So(errors.Is(err, types.ErrCollectTimeout), ShouldBeTrue)
+
+			// Act
+			mts, err := collectMetrics(cm, "task-2")
+			close(collector.releaseCh)
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Value(), ShouldEqual, 2)
+
+			calls := collector.calls()
+			So(calls, ShouldHaveLength, 2)
+			So(calls[1] != calls[0], ShouldBeTrue)
+		})
+
+		Convey("Shutdown waits for running Collect before tasks are unloaded", func() {
+			// Arrange
+			So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+
+			_, err := collectMetrics(cm, "task-1")
+			So(errors.Is(err, types.ErrCollectTimeout), ShouldBeTrue)
+
+			cm.ReleaseAllTasks()
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				close(collector.releaseCh)
+			}()
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancelFn()
+
+			// Act
+			cm.Shutdown(ctx)
+
+			// Assert
+			collector.mutex.Lock()
+			defer collector.mutex.Unlock()
+			So(collector.unloaded, ShouldResemble, []bool{true})
+		})
+
+		Convey("Shutdown doesn't wait for running Collect longer than timeout", func() {
+			// Arrange
+			So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+
+			_, err := collectMetrics(cm, "task-1")
+			So(errors.Is(err, types.ErrCollectTimeout), ShouldBeTrue)
+			defer close(collector.releaseCh)
+
+			cm.ReleaseAllTasks()
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancelFn()
+
+			// Act
+			startTime := time.Now()
+			cm.Shutdown(ctx)
+
+			// Assert
+			So(time.Since(startTime), ShouldBeLessThan, time.Second)
+		})
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Collector which flushes metrics until its first call is canceled
+type flushingDiskCollector struct {
+	calls int32
+}
+
+func (*flushingDiskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	return nil
+}
+
+func (c *flushingDiskCollector) Collect(ctx plugin.CollectContext) error {
+	n := atomic.AddInt32(&c.calls, 1)
+
+	for i := 0; n == 1 && !ctx.IsDone(); i++ {
+		_ = ctx.AddMetric("/plugin/[disk=sda]/io_time", i)
+		_ = ctx.Flush()
+	}
+
+	return ctx.AddMetric("/plugin/[disk=sda]/io_time", n)
+}
+
+func TestAbandonedCollectRequest(t *testing.T) {
+	Convey("Validate that collection isn't blocked when nobody receives its results", t, func() {
+		// Arrange
+		statsController, _ := stats.NewEmptyController()
+
+		collector := &flushingDiskCollector{}
+		cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+		So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		chunkCh := cm.RequestCollect(ctx, "task-1")
+		<-chunkCh // receive first flushed chunk only
+
+		// Act
+		cancelFn()
+
+		// Assert
+		var mts []*types.Metric
+		var err error
+		for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+			if mts, err = collectMetrics(cm, "task-1"); err == nil {
+				break
+			}
+		}
+
+		So(err, ShouldBeNil)
+		So(mts, ShouldHaveLength, 1)
+		So(mts[0].Value(), ShouldEqual, 2)
+	})
+}
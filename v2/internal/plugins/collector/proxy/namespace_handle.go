@@ -0,0 +1,239 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/metrictree"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	// Number of filtering results kept by a single handle (cache is cleared when limit is exceeded)
+	maxCachedFilterResults = 100000
+
+	// Separates group values joined into cache key
+	groupValuesSeparator = "\x00"
+
+	// Group value used when template is validated against plugin definition (any value matches group)
+	templateGroupValue = "_"
+
+	// Characters which can't be used in group values (they would change the structure of namespace)
+	forbiddenGroupValueChars = "[]"
+)
+
+// Namespace template compiled once: validation against plugin definition, metadata and descriptions are
+// resolved when handle is created; only task filters depend on group values (results are cached).
+type namespaceHandle struct {
+	pc       *PluginContext
+	template string
+	err      error // error found when template was compiled
+
+	base      *resolvedNamespace // namespace elements (with placeholders for group values) and metadata
+	elements  []string           // template elements (without leading separator)
+	dynamic   []int              // positions of dynamic elements ([group]) in elements
+	separator string
+
+	filterMutex   sync.RWMutex
+	filterResults map[string]bool // group values -> metric matches task filters
+	filtersGen    uint64          // generation of task filters for which results are cached
+}
+
+func (pc *PluginContext) Namespace(template string) plugin.NamespaceHandle {
+	if h, ok := pc.nsHandles.Load(template); ok {
+		return h.(*namespaceHandle)
+	}
+
+	h, _ := pc.nsHandles.LoadOrStore(template, newNamespaceHandle(pc, template))
+	return h.(*namespaceHandle)
+}
+
+func newNamespaceHandle(pc *PluginContext, template string) *namespaceHandle {
+	h := &namespaceHandle{
+		pc:            pc,
+		template:      template,
+		filterResults: map[string]bool{},
+		filtersGen:    atomic.LoadUint64(&pc.filtersGen),
+	}
+
+	elements, separator, err := metrictree.SplitNamespace(template)
+	if err != nil {
+		h.err = fmt.Errorf("invalid namespace template %s: %v", template, err)
+		return h
+	}
+
+	h.elements = elements[1:]
+	h.separator = separator
+
+	for i, el := range h.elements {
+		if strings.HasPrefix(el, "[") && strings.HasSuffix(el, "]") && !strings.Contains(el, "=") {
+			if len(el) == 2 {
+				h.err = fmt.Errorf("invalid namespace template %s: group name can't be empty", template)
+				return h
+			}
+			h.dynamic = append(h.dynamic, i)
+		}
+	}
+
+	placeholders := make([]string, len(h.dynamic))
+	for i := range placeholders {
+		placeholders[i] = templateGroupValue
+	}
+
+	h.base, err = pc.resolveNamespace(h.fill(placeholders))
+	if err != nil {
+		h.err = fmt.Errorf("invalid namespace template %s: %v", template, err)
+	}
+
+	return h
+}
+
+func (h *namespaceHandle) Add(v interface{}, groupValues ...string) error {
+	return h.AddWithModifiers(v, groupValues)
+}
+
+func (h *namespaceHandle) AddWithModifiers(v interface{}, groupValues []string, modifiers ...plugin.MetricModifier) error {
+	if h.err != nil {
+		return h.err
+	}
+
+	err := h.validateGroupValues(groupValues)
+	if err != nil {
+		return err
+	}
+
+	pc := h.pc
+
+	err = pc.validateMetricValue(h.template, v)
+	if err != nil {
+		return err
+	}
+
+	if !h.matchFilters(groupValues) {
+		return nil // don't throw error when metric is just filtered
+	}
+
+	mtNamespace := make([]types.NamespaceElement, len(h.base.elements))
+	copy(mtNamespace, h.base.elements)
+	for i, pos := range h.dynamic {
+		mtNamespace[pos].Value_ = groupValues[i]
+	}
+
+	err = pc.appendMetric(h.template, mtNamespace, &h.base.meta, v, modifiers)
+	if err != nil {
+		return err
+	}
+
+	if pc.flushThresholdExceeded() {
+		return pc.Flush()
+	}
+
+	return nil
+}
+
+func (h *namespaceHandle) Err() error {
+	return h.err
+}
+
+func (h *namespaceHandle) validateGroupValues(groupValues []string) error {
+	if len(groupValues) != len(h.dynamic) {
+		return fmt.Errorf("namespace template %s requires %d group value(s), got %d", h.template, len(h.dynamic), len(groupValues))
+	}
+
+	for _, v := range groupValues {
+		if v == "" || strings.Contains(v, h.separator) || strings.ContainsAny(v, forbiddenGroupValueChars) {
+			return fmt.Errorf("invalid group value (%q) for namespace template %s", v, h.template)
+		}
+	}
+
+	return nil
+}
+
+// Check if metric with given group values matches task filters (result is cached until filters are replaced)
+func (h *namespaceHandle) matchFilters(groupValues []string) bool {
+	gen := atomic.LoadUint64(&h.pc.filtersGen)
+
+	if !h.pc.filters().HasRules() {
+		return true
+	}
+
+	var key string
+	switch len(groupValues) {
+	case 0:
+	case 1:
+		key = groupValues[0]
+	default:
+		key = strings.Join(groupValues, groupValuesSeparator)
+	}
+
+	h.filterMutex.RLock()
+	match, ok := h.filterResults[key]
+	ok = ok && h.filtersGen == gen
+	h.filterMutex.RUnlock()
+
+	if ok {
+		return match
+	}
+
+	match = h.pc.matchFilters(h.fill(groupValues))
+
+	h.filterMutex.Lock()
+	defer h.filterMutex.Unlock()
+
+	if h.filtersGen != gen || len(h.filterResults) >= maxCachedFilterResults {
+		h.filterResults = map[string]bool{}
+		h.filtersGen = gen
+	}
+	h.filterResults[key] = match
+
+	return match
+}
+
+// Build concrete namespace from template, ie. /plugin/[disk]/io_time -> /plugin/[disk=sda]/io_time
+// (or /plugin/sda/io_time when plugin doesn't define metrics, so groups are unknown)
+func (h *namespaceHandle) fill(groupValues []string) string {
+	withGroups := h.pc.ctxManager.metricsDefinition.HasRules()
+
+	sb := strings.Builder{}
+	d := 0
+
+	for i, el := range h.elements {
+		sb.WriteString(h.separator)
+
+		if d < len(h.dynamic) && h.dynamic[d] == i {
+			if withGroups {
+				sb.WriteString(el[:len(el)-1])
+				sb.WriteString("=")
+				sb.WriteString(groupValues[d])
+				sb.WriteString("]")
+			} else {
+				sb.WriteString(groupValues[d])
+			}
+			d++
+			continue
+		}
+
+		sb.WriteString(el)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,141 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type diskCollector struct{}
+
+func (*diskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	def.DefineMetric("/plugin/[disk]/read_ops", "", true, "Read operations")
+	def.DefineGroup("disk", "Disk name")
+	return nil
+}
+
+func (*diskCollector) Collect(_ plugin.CollectContext) error {
+	return nil
+}
+
+func newDiskPluginContext(filters []string) *PluginContext {
+	statsController, _ := stats.NewEmptyController()
+	cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", &diskCollector{}), statsController)
+
+	err := cm.LoadTask("task-1", []byte("{}"), filters)
+	So(err, ShouldBeNil)
+
+	pcI, _ := cm.contextMap.Load("task-1")
+	return pcI.(*PluginContext)
+}
+
+func TestNamespaceHandle(t *testing.T) {
+	Convey("Validate that metrics can be added with namespace handle", t, func() {
+		Convey("Metrics are the same as added with AddMetric", func() {
+			// Arrange
+			pc := newDiskPluginContext(nil)
+
+			// Act
+			h := pc.Namespace("/plugin/[disk]/io_time")
+			So(h.Err(), ShouldBeNil)
+			So(h.Add(10, "sda"), ShouldBeNil)
+			So(h.Add(20, "sdb"), ShouldBeNil)
+			So(h.AddWithModifiers(30, []string{"sda"}, plugin.MetricTag("dev", "hdd")), ShouldBeNil)
+			So(pc.AddMetric("/plugin/[disk=sda]/io_time", 40), ShouldBeNil)
+
+			// Assert
+			So(pc.Namespace("/plugin/[disk]/io_time"), ShouldEqual, h)
+
+			mts := pc.Metrics(true)
+			So(mts, ShouldHaveLength, 4)
+			So(mts[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/io_time")
+			So(mts[1].Namespace().String(), ShouldEqual, "/plugin/[disk=sdb]/io_time")
+			So(mts[2].Tags(), ShouldResemble, map[string]string{"dev": "hdd"})
+			So(mts[0].Namespace_, ShouldResemble, mts[3].Namespace_)
+			So(mts[0].Unit(), ShouldEqual, "ms")
+			So(mts[0].Description(), ShouldEqual, "Time spent doing I/O")
+			So(mts[0].Namespace_[1].Description_, ShouldEqual, "Disk name")
+		})
+
+		Convey("Filtered metrics are silently dropped", func() {
+			// Arrange
+			pc := newDiskPluginContext([]string{"/plugin/[disk=sda]/*"})
+			h := pc.Namespace("/plugin/[disk]/read_ops")
+
+			// Act
+			for i := 0; i < 3; i++ {
+				So(h.Add(i, "sda"), ShouldBeNil)
+				So(h.Add(i, "sdb"), ShouldBeNil)
+			}
+
+			// Assert
+			mts := pc.Metrics(true)
+			So(mts, ShouldHaveLength, 3)
+			for _, mt := range mts {
+				So(mt.Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/read_ops")
+			}
+		})
+
+		Convey("Handle kept by user code follows filters of reconfigured task", func() {
+			// Arrange
+			pc := newDiskPluginContext([]string{"/plugin/[disk=sda]/*"})
+			h := pc.Namespace("/plugin/[disk]/read_ops")
+
+			So(h.Add(1, "sda"), ShouldBeNil)
+			So(h.Add(1, "sdb"), ShouldBeNil)
+			So(pc.Metrics(true), ShouldHaveLength, 1)
+
+			newPc, err := NewPluginContext(pc.ctxManager, "task-1", []byte("{}"))
+			So(err, ShouldBeNil)
+			So(newPc.addFilters([]string{"/plugin/[disk=sdb]/*"}), ShouldBeNil)
+
+			// Act
+			pc.applyConfig(newPc)
+
+			So(h.Add(2, "sda"), ShouldBeNil)
+			So(h.Add(2, "sdb"), ShouldBeNil)
+
+			// Assert
+			mts := pc.Metrics(true)
+			So(mts, ShouldHaveLength, 1)
+			So(mts[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sdb]/read_ops")
+		})
+
+		Convey("Invalid usage is reported", func() {
+			pc := newDiskPluginContext(nil)
+
+			So(pc.Namespace("plugin").Err(), ShouldBeError)
+			So(pc.Namespace("plugin").Add(1), ShouldBeError)
+			So(pc.Namespace("/plugin/[]/io_time").Err(), ShouldBeError)
+			So(pc.Namespace("/plugin/[disk]/io_time").Add(1), ShouldBeError)
+			So(pc.Namespace("/plugin/[disk]/io_time").Add(1, "sda", "sdb"), ShouldBeError)
+			So(pc.Namespace("/plugin/[disk]/unknown").Add(1, "sda"), ShouldBeError)
+			So(pc.Namespace("/plugin/[disk]/io_time").Add(struct{}{}, "sda"), ShouldBeError)
+			So(pc.Metrics(true), ShouldBeEmpty)
+		})
+	})
+}
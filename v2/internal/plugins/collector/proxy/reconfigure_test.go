@@ -0,0 +1,189 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type reconfigurableDiskCollector struct {
+	diskCollector
+	loadCalls  int
+	lastChange plugin.ConfigChange
+}
+
+func (c *reconfigurableDiskCollector) Load(ctx plugin.Context) error {
+	c.loadCalls++
+	ctx.Store("connection", "established")
+	return nil
+}
+
+func (c *reconfigurableDiskCollector) Reconfigure(ctx plugin.Context, change plugin.ConfigChange) error {
+	c.lastChange = change
+	if v, _ := change.ConfigValue("server.port"); v == "0" {
+		return errors.New("invalid port")
+	}
+	return nil
+}
+
+type reloadableDiskCollector struct {
+	diskCollector
+	loadCalls int
+}
+
+func (c *reloadableDiskCollector) Load(ctx plugin.Context) error {
+	c.loadCalls++
+	return nil
+}
+
+func TestReconfigureTask(t *testing.T) {
+	Convey("Validate that configuration of loaded task can be changed", t, func() {
+		statsController, _ := stats.NewEmptyController()
+
+		Convey("Approved configuration is applied without reloading task", func() {
+			// Arrange
+			collector := &reconfigurableDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.LoadTask("task-1", []byte(`{"server": {"host": "a", "port": 80}, "debug": true}`), nil), ShouldBeNil)
+
+			// Act
+			err := cm.ReconfigureTask("task-1", []byte(`{"server": {"host": "a", "port": 8080}, "user": "admin"}`), []string{"/plugin/*/io_time"})
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(collector.loadCalls, ShouldEqual, 1)
+			So(collector.lastChange.Added(), ShouldResemble, []string{"user"})
+			So(collector.lastChange.Removed(), ShouldResemble, []string{"debug"})
+			So(collector.lastChange.Modified(), ShouldResemble, []string{"server.port"})
+
+			pcI, _ := cm.contextMap.Load("task-1")
+			pc := pcI.(*PluginContext)
+			port, _ := pc.ConfigValue("server.port")
+			So(port, ShouldEqual, "8080")
+			conn, _ := pc.Load("connection")
+			So(conn, ShouldEqual, "established")
+			So(pc.RequestedMetrics(), ShouldResemble, []string{"/plugin/*/io_time"})
+		})
+
+		Convey("Settings of task can be replaced while other task of the group is collected", func() {
+			// Arrange
+			collector := &reconfigurableDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.DefineMinCollectInterval(time.Millisecond), ShouldBeNil)
+			So(cm.LoadTask("task-1", []byte(`{"server": {"port": 80}}`), nil), ShouldBeNil)
+			So(cm.LoadTask("task-2", []byte(`{"server": {"port": 80}}`), []string{"/plugin/*/io_time"}), ShouldBeNil)
+
+			pcI, _ := cm.contextMap.Load("task-1")
+			pc := pcI.(*PluginContext)
+
+			newPcs := make([]*PluginContext, 2)
+			for i := range newPcs {
+				var err error
+				newPcs[i], err = NewPluginContext(cm, "task-1", []byte(fmt.Sprintf(`{"server": {"port": %d}}`, 80+i)))
+				So(err, ShouldBeNil)
+				So(newPcs[i].addFilters([]string{"/plugin/*/read_ops"}), ShouldBeNil)
+			}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			// Act
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 20; i++ {
+					pc.applyConfig(newPcs[i%2])
+				}
+			}()
+
+			errs := 0
+			for i := 0; i < 20; i++ {
+				if _, err := collectMetrics(cm, "task-2"); err != nil {
+					errs++
+				}
+			}
+			wg.Wait()
+
+			// Assert
+			So(errs, ShouldEqual, 0)
+			So(pc.RequestedMetrics(), ShouldResemble, []string{"/plugin/*/read_ops"})
+		})
+
+		Convey("Rejected configuration is not applied", func() {
+			// Arrange
+			collector := &reconfigurableDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.LoadTask("task-1", []byte(`{"server": {"port": 80}}`), nil), ShouldBeNil)
+
+			// Act
+			err := cm.ReconfigureTask("task-1", []byte(`{"server": {"port": 0}}`), nil)
+
+			// Assert
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "invalid port")
+
+			pcI, _ := cm.contextMap.Load("task-1")
+			port, _ := pcI.(*PluginContext).ConfigValue("server.port")
+			So(port, ShouldEqual, "80")
+		})
+
+		Convey("Invalid configuration is rejected before user code is called", func() {
+			// Arrange
+			collector := &reloadableDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.LoadTask("task-1", []byte(`{}`), nil), ShouldBeNil)
+
+			// Act
+			err := cm.ReconfigureTask("task-1", []byte(`{"__collectTimeout": "-1s"}`), nil)
+
+			// Assert
+			So(err, ShouldNotBeNil)
+			So(collector.loadCalls, ShouldEqual, 1)
+			_, ok := cm.contextMap.Load("task-1")
+			So(ok, ShouldBeTrue)
+		})
+
+		Convey("Task of collector without Reconfigure is loaded again", func() {
+			// Arrange
+			collector := &reloadableDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.LoadTask("task-1", []byte(`{"server": {"port": 80}}`), nil), ShouldBeNil)
+
+			// Act
+			err := cm.ReconfigureTask("task-1", []byte(`{"server": {"port": 8080}}`), nil)
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(collector.loadCalls, ShouldEqual, 2)
+
+			pcI, _ := cm.contextMap.Load("task-1")
+			port, _ := pcI.(*PluginContext).ConfigValue("server.port")
+			So(port, ShouldEqual, "8080")
+		})
+	})
+}
@@ -0,0 +1,275 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/metrictree"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+)
+
+// Results of collection shared by tasks with identical configuration
+type sharedResult struct {
+	doneCh    chan struct{} // closed when collection is completed
+	chunk     types.CollectChunk
+	completed time.Time
+}
+
+// Tasks with identical configuration (collection requested by one of them is shared with others)
+type collectGroup struct {
+	mutex    sync.Mutex
+	pc       *PluginContext // context used for shared collections (nil - has to be rebuilt)
+	inFlight *sharedResult  // collection in progress
+	last     *sharedResult  // the latest successful collection
+}
+
+func configKey(config []byte) string {
+	sum := sha256.Sum256(config)
+	return hex.EncodeToString(sum[:])
+}
+
+func (cm *ContextManager) collectGroup(key string) *collectGroup {
+	cm.collectGroupsMutex.Lock()
+	defer cm.collectGroupsMutex.Unlock()
+
+	group, ok := cm.collectGroups[key]
+	if !ok {
+		group = &collectGroup{}
+		cm.collectGroups[key] = group
+	}
+
+	return group
+}
+
+// Should be called when task is loaded or unloaded (filters of shared collection have to be recalculated)
+func (cm *ContextManager) invalidateCollectGroup(key string) {
+	cm.collectGroupsMutex.Lock()
+	defer cm.collectGroupsMutex.Unlock()
+
+	group, ok := cm.collectGroups[key]
+	if !ok {
+		return
+	}
+
+	if len(cm.groupMembers(key)) == 0 {
+		delete(cm.collectGroups, key)
+		return
+	}
+
+	group.mutex.Lock()
+	group.pc = nil
+	group.mutex.Unlock()
+}
+
+func (cm *ContextManager) groupMembers(key string) []*PluginContext {
+	var members []*PluginContext
+
+	cm.contextMap.Range(func(_, v interface{}) bool {
+		if pc := v.(*PluginContext); pc.taskConfigKey() == key {
+			members = append(members, pc)
+		}
+		return true
+	})
+
+	return members
+}
+
+// Filters of shared collection: sum of filters of all tasks in a group
+func (cm *ContextManager) groupFilters(key string) *metrictree.TreeValidator {
+	filters := metrictree.NewMetricFilter(cm.metricsDefinition)
+
+	for _, member := range cm.groupMembers(key) {
+		// member requested all metrics or excluded some of them (negated rules of one task can't be applied to others)
+		memberFilters := member.filters()
+		if !memberFilters.HasRules() || memberFilters.HasExclusions() {
+			return metrictree.NewMetricFilter(cm.metricsDefinition)
+		}
+
+		for _, rule := range memberFilters.ListRules() {
+			_ = filters.AddRule(rule) // rules were already validated (when task was loaded)
+		}
+	}
+
+	return filters
+}
+
+// Serve collect request from cache or results of collection requested by other task with the same configuration.
+// When there is no such results, collection is performed (and its results are shared).
+func (cm *ContextManager) sharedCollect(ctx context.Context, id string, pContext *PluginContext, chunkCh chan<- types.CollectChunk) {
+	startTime := time.Now()
+	group := cm.collectGroup(pContext.taskConfigKey())
+	leader := false
+
+	group.mutex.Lock()
+	result := group.last
+	switch {
+	case result != nil && time.Since(result.completed) < cm.minCollectInterval:
+		// results are fresh enough
+	case group.inFlight != nil:
+		result = group.inFlight
+	default:
+		result = &sharedResult{doneCh: make(chan struct{})}
+		group.inFlight = result
+		leader = true
+	}
+	group.mutex.Unlock()
+
+	if leader {
+		cm.collectForGroup(ctx, id, pContext, group, result)
+	} else {
+		select {
+		case <-result.doneCh:
+		case <-ctx.Done():
+			sendChunk(ctx, chunkCh, types.CollectChunk{
+				Err: fmt.Errorf("%w (waiting for shared collection)", types.ErrCollectTimeout),
+			})
+			close(chunkCh)
+			return
+		}
+	}
+
+	// filters, series limits and statistics are applied per task
+	chunk := result.chunk
+	chunk.Metrics = pContext.limitSeries(pContext.filterMetrics(chunk.Metrics))
+	if msg, ok := cm.droppedSeriesWarning(id, pContext); ok {
+		chunk.Warnings = append(append([]types.Warning{}, chunk.Warnings...), types.Warning{
+			Message:   msg,
+			Timestamp: time.Now(),
+		})
+	}
+
+	endTime := time.Now()
+	if errors.Is(chunk.Err, types.ErrCollectTimeout) {
+		cm.statsController.UpdateTimeoutStat(id, len(chunk.Metrics), startTime, endTime)
+	} else {
+		cm.statsController.UpdateExecutionStat(id, len(chunk.Metrics), chunk.Err == nil, startTime, endTime)
+	}
+
+	if !leader {
+		cm.statsController.UpdateCacheHitStat(id)
+	}
+
+	sendChunk(ctx, chunkCh, chunk)
+	close(chunkCh)
+}
+
+// Perform collection on behalf of all tasks in a group (results are merged into single chunk)
+func (cm *ContextManager) collectForGroup(ctx context.Context, id string, pContext *PluginContext, group *collectGroup, result *sharedResult) {
+	group.mutex.Lock()
+	if group.pc == nil || !group.pc.beginCollect() {
+		// previous shared collection may still be running (it ignored timeout), so its session can't be reused
+		group.pc = pContext.withFilters(cm.groupFilters(pContext.taskConfigKey()))
+		_ = group.pc.beginCollect() // new context is always available
+	}
+	gpc := group.pc
+	group.mutex.Unlock()
+
+	gpc.AttachContext(cm.TaskContext(id))
+	gpc.ClearCollectorSession()
+	gpc.ResetWarnings()
+
+	innerCh := make(chan types.CollectChunk)
+	// innerCh is always drained, so results are gathered for the group even when leader's request is abandoned
+	go cm.collect(context.Background(), id, gpc, innerCh, cm.collectTimeout(ctx, gpc))
+
+	for chunk := range innerCh {
+		result.chunk.Metrics = append(result.chunk.Metrics, chunk.Metrics...)
+		result.chunk.Warnings = append(result.chunk.Warnings, chunk.Warnings...)
+		result.chunk.Events = append(result.chunk.Events, chunk.Events...)
+		if chunk.Err != nil {
+			result.chunk.Err = chunk.Err
+		}
+	}
+
+	gpc.ReleaseContext()
+
+	result.completed = time.Now()
+
+	group.mutex.Lock()
+	group.inFlight = nil
+	if result.chunk.Err == nil {
+		group.last = result
+	}
+	group.mutex.Unlock()
+
+	close(result.doneCh)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Create context for shared collection: configuration, shared store and stored objects (copied when context is created)
+// are the same as in original context, filters are given and session (metrics, warnings, counters, handles) is independent.
+// Context doesn't belong to any task (no task id and persistent state), series limits are applied per task.
+func (pc *PluginContext) withFilters(filters *metrictree.TreeValidator) *PluginContext {
+	gpc := &PluginContext{
+		Context:        pc.Context.Fork(),
+		ctx:            pc.ctx,
+		forGroup:       true,
+		configKey:      pc.taskConfigKey(),
+		metricsFilters: filters,
+		counters:       newCounterState(),
+		series:         newSeriesLimiter(SeriesLimits{}),
+		flushCh:        make(chan []*types.Metric),
+		collectTimeout: pc.taskCollectTimeout(),
+		ctxManager:     pc.ctxManager,
+	}
+	gpc.goPool = newWorkerPool(gpc, defaultWorkersLimit, true)
+
+	return gpc
+}
+
+// Apply series limits of task to metrics of shared collection (dropped series are reported by droppedSeriesWarning)
+func (pc *PluginContext) limitSeries(mts []*types.Metric) []*types.Metric {
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	now := time.Now()
+	pc.series.nextCollection(now)
+
+	admitted := make([]*types.Metric, 0, len(mts))
+	for _, mt := range mts {
+		if pc.series.admit(mt, now) {
+			admitted = append(admitted, mt)
+		}
+	}
+
+	return admitted
+}
+
+// Select metrics requested by task
+func (pc *PluginContext) filterMetrics(mts []*types.Metric) []*types.Metric {
+	filters := pc.filters()
+	if !filters.HasRules() {
+		return mts
+	}
+
+	filtered := make([]*types.Metric, 0, len(mts))
+	for _, mt := range mts {
+		if filters.IsValidWithTags(mt.Namespace().String(), mt.Tags()) {
+			filtered = append(filtered, mt)
+		}
+	}
+
+	return filtered
+}
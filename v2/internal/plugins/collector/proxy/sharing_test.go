@@ -0,0 +1,276 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type sharedDiskCollector struct {
+	minInterval  time.Duration
+	delay        time.Duration
+	collectCalls int32
+}
+
+func (c *sharedDiskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	def.DefineMetric("/plugin/[disk]/read_ops", "", true, "Read operations")
+	return def.DefineMinCollectInterval(c.minInterval)
+}
+
+func (c *sharedDiskCollector) Collect(ctx plugin.CollectContext) error {
+	atomic.AddInt32(&c.collectCalls, 1)
+	time.Sleep(c.delay)
+
+	_ = ctx.AddMetric("/plugin/[disk=sda]/io_time", 10)
+	_ = ctx.AddMetric("/plugin/[disk=sda]/read_ops", 20)
+	return nil
+}
+
+// Collector which checks context it gets in shared collection (objects stored by Load, persistent state)
+type groupDiskCollector struct {
+	mutex       sync.Mutex
+	connections []interface{}
+	stateErrs   []error
+}
+
+func (c *groupDiskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	def.DefineMetric("/plugin/[disk]/read_ops", "", true, "Read operations")
+	return def.DefineMinCollectInterval(time.Minute)
+}
+
+func (c *groupDiskCollector) Load(ctx plugin.Context) error {
+	ctx.Store("connection", "established")
+	return nil
+}
+
+func (c *groupDiskCollector) Collect(ctx plugin.CollectContext) error {
+	conn, _ := ctx.Load("connection")
+	ctx.Store("session", "started")
+
+	c.mutex.Lock()
+	c.connections = append(c.connections, conn)
+	c.stateErrs = append(c.stateErrs, ctx.PersistentState().Store("offset", 1))
+	c.mutex.Unlock()
+
+	for _, disk := range []string{"sda", "sdb"} {
+		_ = ctx.AddMetric(fmt.Sprintf("/plugin/[disk=%s]/io_time", disk), 10)
+		_ = ctx.AddMetric(fmt.Sprintf("/plugin/[disk=%s]/read_ops", disk), 20)
+	}
+	return nil
+}
+
+// Keeps number of metrics reported in the last execution of each task
+type executionStatsController struct {
+	stats.EmptyController
+	mutex   sync.Mutex
+	metrics map[string]int
+}
+
+func (c *executionStatsController) UpdateExecutionStat(taskID string, metricsCount int, _ bool, _, _ time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.metrics[taskID] = metricsCount
+}
+
+func (c *executionStatsController) executions() map[string]int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	executions := map[string]int{}
+	for k, v := range c.metrics {
+		executions[k] = v
+	}
+	return executions
+}
+
+func collectChunks(cm *ContextManager, id string) ([]*types.Metric, []types.Warning) {
+	var mts []*types.Metric
+	var warnings []types.Warning
+
+	for chunk := range cm.RequestCollect(context.Background(), id) {
+		So(chunk.Err, ShouldBeNil)
+		mts = append(mts, chunk.Metrics...)
+		warnings = append(warnings, chunk.Warnings...)
+	}
+
+	return mts, warnings
+}
+
+func collectMetrics(cm *ContextManager, id string) ([]*types.Metric, error) {
+	var mts []*types.Metric
+	var err error
+
+	for chunk := range cm.RequestCollect(context.Background(), id) {
+		mts = append(mts, chunk.Metrics...)
+		if chunk.Err != nil {
+			err = chunk.Err
+		}
+	}
+
+	return mts, err
+}
+
+func TestSharedCollection(t *testing.T) {
+	Convey("Validate that tasks with identical configuration share results of collection", t, func() {
+		statsController, _ := stats.NewEmptyController()
+
+		Convey("Results younger than minimum interval are served from cache and filtered per task", func() {
+			// Arrange
+			collector := &sharedDiskCollector{minInterval: 100 * time.Millisecond}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+
+			So(cm.LoadTask("task-1", []byte(`{"server": "a"}`), []string{"/plugin/*/io_time"}), ShouldBeNil)
+			So(cm.LoadTask("task-2", []byte(`{"server": "a"}`), []string{"/plugin/*/read_ops"}), ShouldBeNil)
+			So(cm.LoadTask("task-3", []byte(`{"server": "b"}`), nil), ShouldBeNil)
+
+			// Act
+			mts1, err1 := collectMetrics(cm, "task-1")
+			mts2, err2 := collectMetrics(cm, "task-2")
+
+			// Assert
+			So(err1, ShouldBeNil)
+			So(err2, ShouldBeNil)
+			So(atomic.LoadInt32(&collector.collectCalls), ShouldEqual, 1)
+
+			So(len(mts1), ShouldEqual, 1)
+			So(mts1[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/io_time")
+			So(len(mts2), ShouldEqual, 1)
+			So(mts2[0].Namespace().String(), ShouldEqual, "/plugin/[disk=sda]/read_ops")
+
+			// Act - task with different configuration is collected separately
+			mts3, err3 := collectMetrics(cm, "task-3")
+
+			// Assert
+			So(err3, ShouldBeNil)
+			So(len(mts3), ShouldEqual, 2)
+			So(atomic.LoadInt32(&collector.collectCalls), ShouldEqual, 2)
+
+			// Act - cached results have expired
+			time.Sleep(150 * time.Millisecond)
+			_, _ = collectMetrics(cm, "task-2")
+
+			// Assert
+			So(atomic.LoadInt32(&collector.collectCalls), ShouldEqual, 3)
+		})
+
+		Convey("Concurrent requests share collection in progress", func() {
+			// Arrange
+			collector := &sharedDiskCollector{delay: 100 * time.Millisecond}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+
+			So(cm.LoadTask("task-1", []byte(`{}`), nil), ShouldBeNil)
+			So(cm.LoadTask("task-2", []byte(`{}`), nil), ShouldBeNil)
+
+			// Act
+			wg := sync.WaitGroup{}
+			counts := make([]int, 2)
+			for i, id := range []string{"task-1", "task-2"} {
+				wg.Add(1)
+				go func(i int, id string) {
+					defer wg.Done()
+					mts, _ := collectMetrics(cm, id)
+					counts[i] = len(mts)
+				}(i, id)
+			}
+			wg.Wait()
+
+			// Assert
+			So(atomic.LoadInt32(&collector.collectCalls), ShouldEqual, 1)
+			So(counts, ShouldResemble, []int{2, 2})
+
+			// Act - minimum interval is not defined, so results are not cached
+			_, _ = collectMetrics(cm, "task-1")
+
+			// Assert
+			So(atomic.LoadInt32(&collector.collectCalls), ShouldEqual, 2)
+		})
+
+		Convey("Collect is called with context which doesn't belong to any task", func() {
+			// Arrange
+			collector := &groupDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+
+			So(cm.LoadTask("task-1", []byte(`{}`), nil), ShouldBeNil)
+			So(cm.LoadTask("task-2", []byte(`{}`), nil), ShouldBeNil)
+
+			// Act
+			mts1, _ := collectChunks(cm, "task-1")
+			mts2, _ := collectChunks(cm, "task-2")
+
+			// Assert
+			So(len(mts1), ShouldEqual, 4)
+			So(len(mts2), ShouldEqual, 4)
+
+			So(collector.connections, ShouldResemble, []interface{}{"established"})
+			So(collector.stateErrs, ShouldHaveLength, 1)
+			So(collector.stateErrs[0], ShouldNotBeNil)
+
+			for _, id := range []string{"task-1", "task-2"} {
+				pcI, _ := cm.contextMap.Load(id)
+				_, ok := pcI.(*PluginContext).Load("session")
+				So(ok, ShouldBeFalse)
+			}
+		})
+
+		Convey("Series limits and statistics are applied per task to filtered metrics", func() {
+			// Arrange
+			execStats := &executionStatsController{metrics: map[string]int{}}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", &groupDiskCollector{}), execStats)
+			cm.SeriesLimits = SeriesLimits{PerCollect: 2}
+
+			So(cm.LoadTask("task-1", []byte(`{}`), []string{"/plugin/*/io_time"}), ShouldBeNil)
+			So(cm.LoadTask("task-2", []byte(`{}`), nil), ShouldBeNil)
+
+			// Act
+			mts1, warnings1 := collectChunks(cm, "task-1")
+			mts2, warnings2 := collectChunks(cm, "task-2")
+
+			// Assert
+			So(len(mts1), ShouldEqual, 2)
+			for _, mt := range mts1 {
+				So(mt.Namespace().String(), ShouldEndWith, "/io_time")
+			}
+			So(warnings1, ShouldBeEmpty)
+
+			So(len(mts2), ShouldEqual, 2)
+			So(warnings2, ShouldHaveLength, 1)
+			So(warnings2[0].Message, ShouldStartWith, "series limit exceeded: 2 new series dropped")
+
+			So(execStats.executions(), ShouldResemble, map[string]int{"task-1": 2, "task-2": 2})
+		})
+
+		Convey("Negative minimum interval is rejected", func() {
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", &diskCollector{}), statsController)
+			So(cm.DefineMinCollectInterval(-time.Second), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,133 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const defaultWorkersLimit = 10 // maximum number of functions running at once (ctx.Go() and pools without explicit limit)
+
+var errWorkerNotStarted = errors.New("function not started, task has been canceled")
+
+// Runs user functions in background goroutines, tracked by context (collection waits for them)
+type workerPool struct {
+	pc           *PluginContext
+	slots        chan struct{}
+	reportErrors bool // errors returned by functions are added as warnings (otherwise only returned by Wait)
+
+	wg       sync.WaitGroup
+	errMutex sync.Mutex
+	err      error
+}
+
+func newWorkerPool(pc *PluginContext, n int, reportErrors bool) *workerPool {
+	if n <= 0 {
+		n = defaultWorkersLimit
+	}
+
+	return &workerPool{
+		pc:           pc,
+		slots:        make(chan struct{}, n),
+		reportErrors: reportErrors,
+	}
+}
+
+func (p *workerPool) Go(fn func() error) {
+	if p.pc.IsDone() {
+		p.setErr(errWorkerNotStarted)
+		return
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+	case <-p.pc.Done():
+		p.setErr(errWorkerNotStarted)
+		return
+	}
+
+	p.wg.Add(1)
+	p.pc.workers.Add(1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logF := p.pc.ctxManager.logger().WithField("task-id", p.pc.taskID)
+				logF.WithError(fmt.Errorf("%v", r)).Error("background function has ended with panic")
+				logF.WithField("block", "recover").Trace(string(debug.Stack()))
+
+				err := fmt.Errorf("background function has ended with panic: %s", secrets.Redact(fmt.Sprintf("%v", r)))
+				p.pc.AddWarning(err.Error())
+				p.setErr(err)
+			}
+
+			<-p.slots
+			p.wg.Done()
+			p.pc.workers.Done()
+		}()
+
+		err := fn() // calling to user defined code
+		if err != nil {
+			if p.reportErrors {
+				p.pc.AddWarning(fmt.Sprintf("background function has ended with error: %v", err))
+			}
+			p.setErr(err)
+		}
+	}()
+}
+
+func (p *workerPool) Wait() error {
+	p.wg.Wait()
+
+	p.errMutex.Lock()
+	defer p.errMutex.Unlock()
+
+	err := p.err
+	p.err = nil
+	return err
+}
+
+func (p *workerPool) setErr(err error) {
+	p.errMutex.Lock()
+	defer p.errMutex.Unlock()
+
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (pc *PluginContext) Go(fn func() error) {
+	pc.goPool.Go(fn)
+}
+
+func (pc *PluginContext) WorkerPool(n int) plugin.WorkerPool {
+	return newWorkerPool(pc, n, false)
+}
+
+// Wait until all functions started in background (during collection) are completed
+func (pc *PluginContext) waitForWorkers() {
+	pc.workers.Wait()
+	_ = pc.goPool.Wait() // errors have been already reported as warnings
+}
@@ -0,0 +1,189 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type parallelDiskCollector struct {
+	maxRunning int32
+}
+
+func (*parallelDiskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	return nil
+}
+
+func (c *parallelDiskCollector) Collect(ctx plugin.CollectContext) error {
+	var running int32
+
+	for i := 0; i < 30; i++ {
+		disk := fmt.Sprintf("sd%d", i)
+		ctx.Go(func() error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+			for {
+				max := atomic.LoadInt32(&c.maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&c.maxRunning, max, n) {
+					break
+				}
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			return ctx.AddMetric(fmt.Sprintf("/plugin/[disk=%s]/io_time", disk), 10)
+		})
+	}
+
+	ctx.Go(func() error {
+		return errors.New("endpoint unreachable")
+	})
+	ctx.Go(func() error {
+		panic("nil map")
+	})
+
+	return nil // background functions are still running
+}
+
+type panickingDiskCollector struct {
+	workerDone int32
+}
+
+func (*panickingDiskCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	def.DefineMetric("/plugin/[disk]/io_time", "ms", true, "Time spent doing I/O")
+	return nil
+}
+
+func (c *panickingDiskCollector) Collect(ctx plugin.CollectContext) error {
+	ctx.Go(func() error {
+		time.Sleep(50 * time.Millisecond)
+		atomic.StoreInt32(&c.workerDone, 1)
+		return nil
+	})
+
+	panic("invalid state")
+}
+
+func TestWorkers(t *testing.T) {
+	Convey("Validate that functions can be run in background during collection", t, func() {
+		statsController, _ := stats.NewEmptyController()
+
+		Convey("Collection waits for functions started with Go(), errors and panics are reported as warnings", func() {
+			// Arrange
+			collector := &parallelDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+
+			// Act
+			var mts []*types.Metric
+			var warnings []types.Warning
+			for chunk := range cm.RequestCollect(context.Background(), "task-1") {
+				So(chunk.Err, ShouldBeNil)
+				mts = append(mts, chunk.Metrics...)
+				warnings = append(warnings, chunk.Warnings...)
+			}
+
+			// Assert
+			So(len(mts), ShouldEqual, 30)
+			So(atomic.LoadInt32(&collector.maxRunning), ShouldBeLessThanOrEqualTo, defaultWorkersLimit)
+
+			So(len(warnings), ShouldEqual, 2)
+			messages := []string{warnings[0].Message, warnings[1].Message}
+			So(messages, ShouldContain, "background function has ended with error: endpoint unreachable")
+			So(messages, ShouldContain, "background function has ended with panic: nil map")
+		})
+
+		Convey("Collection ended with panic waits for functions started with Go()", func() {
+			// Arrange
+			collector := &panickingDiskCollector{}
+			cm := NewContextManager(context.Background(), types.NewCollector("disk", "1.0.0", collector), statsController)
+			So(cm.LoadTask("task-1", []byte("{}"), nil), ShouldBeNil)
+
+			// Act
+			var err error
+			workerDone := int32(0)
+			for chunk := range cm.RequestCollect(context.Background(), "task-1") {
+				err = chunk.Err
+				workerDone = atomic.LoadInt32(&collector.workerDone)
+			}
+
+			// Assert
+			So(err, ShouldBeError)
+			So(err.Error(), ShouldContainSubstring, "user-defined function has ended with panic: invalid state")
+			So(workerDone, ShouldEqual, 1)
+		})
+
+		Convey("Worker pool limits concurrency and returns the first error", func() {
+			// Arrange
+			pc := newDiskPluginContext(nil)
+			pc.AttachContext(context.Background())
+			pc.ClearCollectorSession()
+
+			pool := pc.WorkerPool(2)
+			var running, maxRunning int32
+
+			// Act
+			for i := 0; i < 10; i++ {
+				pool.Go(func() error {
+					n := atomic.AddInt32(&running, 1)
+					if n > atomic.LoadInt32(&maxRunning) {
+						atomic.StoreInt32(&maxRunning, n)
+					}
+					time.Sleep(5 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					return nil
+				})
+			}
+			pool.Go(func() error { return errors.New("timeout") })
+
+			// Assert
+			So(pool.Wait(), ShouldBeError, "timeout")
+			So(atomic.LoadInt32(&maxRunning), ShouldBeLessThanOrEqualTo, 2)
+			So(pool.Wait(), ShouldBeNil)
+			So(pc.Warnings(false), ShouldBeEmpty)
+		})
+
+		Convey("Functions are not started when task has been canceled", func() {
+			// Arrange
+			pc := newDiskPluginContext(nil)
+			ctx, cancelFn := context.WithCancel(context.Background())
+			pc.AttachContext(ctx)
+			pool := pc.WorkerPool(1)
+
+			// Act
+			cancelFn()
+			started := false
+			pool.Go(func() error { started = true; return nil })
+
+			// Assert
+			So(pool.Wait(), ShouldEqual, errWorkerNotStarted)
+			So(started, ShouldBeFalse)
+		})
+	})
+}
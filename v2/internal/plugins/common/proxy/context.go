@@ -26,7 +26,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/simpleconfig"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/statestore"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
 
 const (
@@ -39,13 +41,21 @@ var (
 )
 
 type Context struct {
+	configMutex        sync.RWMutex // guards configuration (which may be swapped by Reconfigure)
 	rawConfig          []byte
 	flattenedConfig    map[string]string
+	parsedConfig       map[string]interface{}
 	storedObjectsMutex sync.RWMutex
 	storedObjects      map[string]interface{}
 	warningsMutex      sync.RWMutex
 	sessionWarnings    []types.Warning
 
+	persistentStateFn   func() plugin.PersistentState // loads persistent state (on first use)
+	persistentStateOnce sync.Once
+	persistentState     plugin.PersistentState
+
+	shared *SharedStore // objects shared by all tasks of plugin
+
 	ctx      context.Context
 	cancelFn context.CancelFunc
 	ctxMu    sync.RWMutex
@@ -62,20 +72,57 @@ func NewContext(rawConfig []byte) (*Context, error) {
 		return nil, fmt.Errorf("can't create context due to invalid json: %v", err)
 	}
 
+	parsedConfig, err := simpleconfig.ParseJSON(rawConfig)
+	if err != nil {
+		return nil, fmt.Errorf("can't create context due to invalid json: %v", err)
+	}
+
 	return &Context{
 		rawConfig:       rawConfig,
 		flattenedConfig: flattenedConfig,
+		parsedConfig:    parsedConfig,
 		storedObjects:   map[string]interface{}{},
+		shared:          NewSharedStore(),
 		ctx:             context.Background(),
 	}, nil
 }
 
+// Create context independent from this one (ie. used by collection performed on behalf of several tasks):
+// configuration and shared store are the same, stored objects are copied, persistent state isn't available.
+func (c *Context) Fork() *Context {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	c.storedObjectsMutex.RLock()
+	defer c.storedObjectsMutex.RUnlock()
+
+	storedObjects := make(map[string]interface{}, len(c.storedObjects))
+	for k, v := range c.storedObjects {
+		storedObjects[k] = v
+	}
+
+	return &Context{
+		rawConfig:       c.rawConfig,
+		flattenedConfig: c.flattenedConfig,
+		parsedConfig:    c.parsedConfig,
+		storedObjects:   storedObjects,
+		shared:          c.shared,
+		ctx:             context.Background(),
+	}
+}
+
 func (c *Context) ConfigValue(key string) (string, bool) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
 	v, ok := c.flattenedConfig[key]
 	return v, ok
 }
 
 func (c *Context) ConfigKeys() []string {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
 	var keysList []string
 	for k := range c.flattenedConfig {
 		keysList = append(keysList, k)
@@ -84,9 +131,64 @@ func (c *Context) ConfigKeys() []string {
 }
 
 func (c *Context) RawConfig() []byte {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
 	return c.rawConfig
 }
 
+func (c *Context) UnmarshalConfig(dest interface{}) error {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	return simpleconfig.Unmarshal(c.parsedConfig, dest)
+}
+
+func (c *Context) ConfigInt(key string) (int, error) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	var v int
+	err := simpleconfig.UnmarshalKey(c.parsedConfig, key, &v)
+	return v, err
+}
+
+func (c *Context) ConfigFloat(key string) (float64, error) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	var v float64
+	err := simpleconfig.UnmarshalKey(c.parsedConfig, key, &v)
+	return v, err
+}
+
+func (c *Context) ConfigBool(key string) (bool, error) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	var v bool
+	err := simpleconfig.UnmarshalKey(c.parsedConfig, key, &v)
+	return v, err
+}
+
+func (c *Context) ConfigDuration(key string) (time.Duration, error) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	var v time.Duration
+	err := simpleconfig.UnmarshalKey(c.parsedConfig, key, &v)
+	return v, err
+}
+
+func (c *Context) ConfigStringList(key string) ([]string, error) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+
+	var v []string
+	err := simpleconfig.UnmarshalKey(c.parsedConfig, key, &v)
+	return v, err
+}
+
 func (c *Context) Store(key string, obj interface{}) {
 	c.storedObjectsMutex.Lock()
 	defer c.storedObjectsMutex.Unlock()
@@ -111,6 +213,11 @@ func (c *Context) LoadTo(key string, dest interface{}) error {
 		return fmt.Errorf("couldn't find object with a given key (%s)", key)
 	}
 
+	return loadTo(obj, dest)
+}
+
+// Assign stored object to variable pointed by dest (types have to match)
+func loadTo(obj interface{}, dest interface{}) error {
 	vDest := reflect.ValueOf(dest)
 	if vDest.Kind() != reflect.Ptr || vDest.IsNil() {
 		return fmt.Errorf("passed variable should be a non-nil pointer")
@@ -124,6 +231,23 @@ func (c *Context) LoadTo(key string, dest interface{}) error {
 	return nil
 }
 
+func (c *Context) PersistentState() plugin.PersistentState {
+	c.persistentStateOnce.Do(func() {
+		if c.persistentStateFn == nil {
+			c.persistentState = statestore.UnavailableState()
+			return
+		}
+
+		c.persistentState = c.persistentStateFn()
+	})
+
+	return c.persistentState
+}
+
+func (c *Context) Shared() plugin.SharedStore {
+	return c.shared
+}
+
 func (c *Context) AddWarning(msg string) {
 	c.ctxMu.RLock()
 	defer c.ctxMu.RUnlock()
@@ -20,9 +20,15 @@ package proxy
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/statestore"
 )
 
 type basicConfig struct {
@@ -135,6 +141,38 @@ func TestContextAPI_Config(t *testing.T) {
 
 		})
 
+		Convey("Validate that typed accessors can be used while configuration is swapped", func() {
+			// Arrange
+			swapCtx, _ := NewContext([]byte(`{"interval": "5s", "rights": ["admin"]}`))
+			otherCtx, _ := NewContext([]byte(`{"interval": "10s", "rights": ["reader"]}`))
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+
+			// Act
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					swapCtx.SwapConfig(otherCtx)
+				}
+			}()
+
+			for i := 0; i < 100; i++ {
+				_, _ = swapCtx.ConfigDuration("interval")
+				_, _ = swapCtx.ConfigStringList("rights")
+			}
+			wg.Wait()
+
+			// Assert
+			interval, err := swapCtx.ConfigDuration("interval")
+			So(err, ShouldBeNil)
+			So(interval, ShouldEqual, 10*time.Second)
+
+			rights, err := swapCtx.ConfigStringList("rights")
+			So(err, ShouldBeNil)
+			So(rights, ShouldResemble, []string{"reader"})
+		})
+
 	})
 }
 
@@ -278,3 +316,45 @@ func TestContextAPI_Storage(t *testing.T) {
 		})
 	})
 }
+
+func TestContextAPI_PersistentState(t *testing.T) {
+	Convey("Validate Context API for handling persistent state", t, func() {
+		// Arrange
+		stateDir, _ := ioutil.TempDir("", "state")
+		defer os.RemoveAll(stateDir)
+
+		cm := NewContextManager()
+		ctx, _ := NewContext([]byte("{}"))
+
+		Convey("Validate that persistent state is not available when store isn't configured", func() {
+			// Act
+			cm.AttachPersistentState(ctx, "task-1")
+			err := ctx.PersistentState().Store("offset", 10)
+
+			// Assert
+			So(err, ShouldEqual, statestore.ErrNotAvailable)
+		})
+
+		Convey("Validate that persistent state is available for the next context of the same task", func() {
+			// Arrange
+			cm.StateStore = statestore.NewStore(stateDir, "test-plugin")
+			cm.AttachPersistentState(ctx, "task-1")
+
+			// Act
+			So(ctx.PersistentState().Store("offset", 10), ShouldBeNil)
+
+			newCtx, _ := NewContext([]byte("{}"))
+			cm.AttachPersistentState(newCtx, "task-1")
+
+			otherCtx, _ := NewContext([]byte("{}"))
+			cm.AttachPersistentState(otherCtx, "task-2")
+
+			// Assert
+			offset := 0
+			So(newCtx.PersistentState().LoadTo("offset", &offset), ShouldBeNil)
+			So(offset, ShouldEqual, 10)
+
+			So(otherCtx.PersistentState().Has("offset"), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const healthCheckTimeout = 10 * time.Second // maximum duration of health checks (shorter when request deadline is closer)
+
+// Run health checks implemented by plugin (see plugin.HealthCheckable) for plugin and tasks (task id -> context).
+// When taskID is not empty, only a given task is checked (next to plugin-wide check).
+// Checks which don't complete before ctx is done (or healthCheckTimeout elapses) are reported as unhealthy.
+func CheckHealth(ctx context.Context, pluginObj interface{}, tasks map[string]plugin.Context, taskID string) (types.HealthReport, error) {
+	report := types.HealthReport{}
+
+	if taskID != "" {
+		taskCtx, ok := tasks[taskID]
+		if !ok {
+			return report, fmt.Errorf("can't find a context for a given id: %s", taskID)
+		}
+		tasks = map[string]plugin.Context{taskID: taskCtx}
+	}
+
+	ids := make([]string, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	checkable, ok := pluginObj.(plugin.HealthCheckable)
+	if !ok {
+		for _, id := range ids {
+			report.AddTaskHealth(id, plugin.HealthStatusHealthy, "")
+		}
+		return report, nil
+	}
+
+	checkFns := []func() (plugin.HealthStatus, string){checkable.HealthCheck}
+	for _, id := range ids {
+		taskCtx := tasks[id]
+		checkFns = append(checkFns, func() (plugin.HealthStatus, string) {
+			return checkable.TaskHealthCheck(taskCtx)
+		})
+	}
+
+	results := runHealthChecks(ctx, checkFns)
+
+	report.SetPluginHealth(results[0].status, results[0].details)
+	for i, id := range ids {
+		report.AddTaskHealth(id, results[i+1].status, results[i+1].details)
+	}
+
+	return report, nil
+}
+
+// Run plugin-wide health check only (tasks are not checked, report contains plugin status)
+func CheckPluginHealth(ctx context.Context, pluginObj interface{}) types.HealthReport {
+	report := types.HealthReport{}
+
+	checkable, ok := pluginObj.(plugin.HealthCheckable)
+	if !ok {
+		return report
+	}
+
+	results := runHealthChecks(ctx, []func() (plugin.HealthStatus, string){checkable.HealthCheck})
+	report.SetPluginHealth(results[0].status, results[0].details)
+
+	return report
+}
+
+type healthResult struct {
+	status  plugin.HealthStatus
+	details string
+}
+
+// Run user-defined checks concurrently and return their results (in order of checkFns)
+func runHealthChecks(ctx context.Context, checkFns []func() (plugin.HealthStatus, string)) []healthResult {
+	ctx, cancelFn := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancelFn()
+
+	type indexedResult struct {
+		index int
+		healthResult
+	}
+
+	resultCh := make(chan indexedResult, len(checkFns)) // buffered, so checks completed after timeout don't block
+	for i, checkFn := range checkFns {
+		go func(i int, checkFn func() (plugin.HealthStatus, string)) {
+			status, details := safeHealthCheck(checkFn)
+			resultCh <- indexedResult{index: i, healthResult: healthResult{status: status, details: details}}
+		}(i, checkFn)
+	}
+
+	results := make([]healthResult, len(checkFns))
+	for i := range results {
+		results[i] = healthResult{
+			status:  plugin.HealthStatusUnhealthy,
+			details: "health check hasn't completed on time",
+		}
+	}
+
+	for range checkFns {
+		select {
+		case r := <-resultCh:
+			results[r.index] = r.healthResult
+		case <-ctx.Done():
+			return results
+		}
+	}
+
+	return results
+}
+
+// Call user-defined check (panic is reported as unhealthy status)
+func safeHealthCheck(checkFn func() (plugin.HealthStatus, string)) (status plugin.HealthStatus, details string) {
+	defer func() {
+		if r := recover(); r != nil {
+			status = plugin.HealthStatusUnhealthy
+			details = fmt.Sprintf("health check has ended with panic: %s", secrets.Redact(fmt.Sprintf("%v", r)))
+		}
+	}()
+
+	status, details = checkFn()
+	return status, secrets.Redact(details)
+}
@@ -0,0 +1,134 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type healthCheckablePlugin struct{}
+
+func (*healthCheckablePlugin) HealthCheck() (plugin.HealthStatus, string) {
+	return plugin.HealthStatusHealthy, ""
+}
+
+func (*healthCheckablePlugin) TaskHealthCheck(ctx plugin.Context) (plugin.HealthStatus, string) {
+	endpoint, _ := ctx.ConfigValue("endpoint")
+	switch endpoint {
+	case "unreachable":
+		return plugin.HealthStatusUnhealthy, "can't connect to " + endpoint
+	case "slow":
+		return plugin.HealthStatusDegraded, "high latency"
+	case "broken":
+		panic("invalid state")
+	case "hanging":
+		<-ctx.Done() // task context is never canceled in tests
+	}
+
+	return plugin.HealthStatusHealthy, ""
+}
+
+func newHealthTestContext(config string) plugin.Context {
+	ctx, err := NewContext([]byte(config))
+	So(err, ShouldBeNil)
+	return ctx
+}
+
+func TestCheckHealth(t *testing.T) {
+	Convey("Validate that health checks defined by plugin are run for plugin and tasks", t, func() {
+		tasks := map[string]plugin.Context{
+			"task-1": newHealthTestContext(`{"endpoint": "ok"}`),
+			"task-2": newHealthTestContext(`{"endpoint": "slow"}`),
+		}
+
+		Convey("Overall status is the worst of plugin and tasks statuses", func() {
+			report, err := CheckHealth(context.Background(), &healthCheckablePlugin{}, tasks, "")
+
+			So(err, ShouldBeNil)
+			So(report.Status, ShouldEqual, plugin.HealthStatusDegraded)
+			So(report.PluginStatus, ShouldEqual, plugin.HealthStatusHealthy)
+			So(len(report.Tasks), ShouldEqual, 2)
+			So(report.Tasks[0].TaskID, ShouldEqual, "task-1")
+			So(report.Tasks[1].Status, ShouldEqual, plugin.HealthStatusDegraded)
+			So(report.Tasks[1].Details, ShouldEqual, "high latency")
+
+			tasks["task-3"] = newHealthTestContext(`{"endpoint": "unreachable"}`)
+			report, _ = CheckHealth(context.Background(), &healthCheckablePlugin{}, tasks, "")
+			So(report.Status, ShouldEqual, plugin.HealthStatusUnhealthy)
+		})
+
+		Convey("Only a given task is checked", func() {
+			report, err := CheckHealth(context.Background(), &healthCheckablePlugin{}, tasks, "task-1")
+			So(err, ShouldBeNil)
+			So(report.Status, ShouldEqual, plugin.HealthStatusHealthy)
+			So(len(report.Tasks), ShouldEqual, 1)
+
+			_, err = CheckHealth(context.Background(), &healthCheckablePlugin{}, tasks, "task-4")
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Panic in health check is reported as unhealthy status", func() {
+			tasks["task-3"] = newHealthTestContext(`{"endpoint": "broken"}`)
+			report, err := CheckHealth(context.Background(), &healthCheckablePlugin{}, tasks, "task-3")
+
+			So(err, ShouldBeNil)
+			So(report.Status, ShouldEqual, plugin.HealthStatusUnhealthy)
+			So(report.Tasks[0].Details, ShouldEqual, "health check has ended with panic: invalid state")
+		})
+
+		Convey("Health check which doesn't complete on time is reported as unhealthy", func() {
+			tasks["task-3"] = newHealthTestContext(`{"endpoint": "hanging"}`)
+			ctx, cancelFn := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancelFn()
+
+			startTime := time.Now()
+			report, err := CheckHealth(ctx, &healthCheckablePlugin{}, tasks, "")
+
+			So(err, ShouldBeNil)
+			So(time.Since(startTime), ShouldBeLessThan, time.Second)
+			So(report.Status, ShouldEqual, plugin.HealthStatusUnhealthy)
+			So(report.PluginStatus, ShouldEqual, plugin.HealthStatusHealthy)
+			So(report.Tasks[1].Status, ShouldEqual, plugin.HealthStatusDegraded)
+			So(report.Tasks[2].Status, ShouldEqual, plugin.HealthStatusUnhealthy)
+			So(report.Tasks[2].Details, ShouldEqual, "health check hasn't completed on time")
+		})
+
+		Convey("Only plugin-wide check is run for liveness", func() {
+			tasks["task-3"] = newHealthTestContext(`{"endpoint": "hanging"}`)
+
+			report := CheckPluginHealth(context.Background(), &healthCheckablePlugin{})
+
+			So(report.Status, ShouldEqual, plugin.HealthStatusHealthy)
+			So(report.Tasks, ShouldBeEmpty)
+		})
+
+		Convey("Plugin which doesn't implement health checks is healthy", func() {
+			report, err := CheckHealth(context.Background(), struct{}{}, tasks, "")
+			So(err, ShouldBeNil)
+			So(report.Status, ShouldEqual, plugin.HealthStatusHealthy)
+			So(len(report.Tasks), ShouldEqual, 2)
+		})
+	})
+}
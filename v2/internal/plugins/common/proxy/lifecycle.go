@@ -0,0 +1,111 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+)
+
+// Call plugin-wide initialization hook (before plugin starts serving requests)
+func Initialize(initFn func() error) error {
+	err := safeCall(initFn)
+	if err != nil {
+		return fmt.Errorf("plugin initialization has failed: %w", err)
+	}
+
+	return nil
+}
+
+// Stop plugin in orderly manner: unload all tasks (in parallel) and call plugin-wide shutdown hook (may be nil).
+// Outcome is logged for each task. When ctx is done, tasks which haven't been unloaded yet are abandoned.
+func Shutdown(ctx context.Context, logF logrus.FieldLogger, ids []string, unloadFn func(id string) error, shutdownFn func(ctx context.Context) error) {
+	sort.Strings(ids)
+
+	type unloadResult struct {
+		id  string
+		err error
+	}
+
+	resultCh := make(chan unloadResult, len(ids))
+	for _, id := range ids {
+		go func(id string) {
+			resultCh <- unloadResult{id: id, err: safeCall(func() error { return unloadFn(id) })}
+		}(id)
+	}
+
+	pending := map[string]bool{}
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	for len(pending) > 0 {
+		select {
+		case res := <-resultCh:
+			delete(pending, res.id)
+			if res.err != nil {
+				logF.WithError(res.err).WithField("task-id", res.id).Warning("Task couldn't have been unloaded during shutdown")
+				continue
+			}
+			logF.WithField("task-id", res.id).Debug("Task has been unloaded during shutdown")
+		case <-ctx.Done():
+			for _, id := range ids {
+				if pending[id] {
+					logF.WithField("task-id", id).Warning("Task hasn't been unloaded before shutdown timeout")
+				}
+			}
+			return
+		}
+	}
+
+	if shutdownFn == nil {
+		return
+	}
+
+	startTime := time.Now()
+	doneCh := make(chan error, 1)
+	go func() {
+		doneCh <- safeCall(func() error { return shutdownFn(ctx) })
+	}()
+
+	select {
+	case err := <-doneCh:
+		if err != nil {
+			logF.WithError(err).Warning("Plugin shutdown hook has ended with error")
+			return
+		}
+		logF.WithField("elapsed", time.Since(startTime).String()).Debug("Plugin shutdown hook has completed")
+	case <-ctx.Done():
+		logF.Warning("Plugin shutdown hook hasn't completed before shutdown timeout")
+	}
+}
+
+// Call user-defined function (panic is reported as error)
+func safeCall(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("user-defined function has ended with panic: %s", secrets.Redact(fmt.Sprintf("%v", r)))
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,144 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type shutdownRecorder struct {
+	mutex    sync.Mutex
+	unloaded []string
+	hookCall int
+}
+
+func (r *shutdownRecorder) unload(id string) error {
+	switch id {
+	case "task-failing":
+		return errors.New("connection already closed")
+	case "task-panicking":
+		panic("invalid state")
+	case "task-hanging":
+		time.Sleep(1 * time.Second)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.unloaded = append(r.unloaded, id)
+	return nil
+}
+
+func (r *shutdownRecorder) shutdown(ctx context.Context) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.hookCall++
+	return nil
+}
+
+func TestShutdown(t *testing.T) {
+	Convey("Validate that all tasks are unloaded before plugin shutdown hook is called", t, func() {
+		rec := &shutdownRecorder{}
+
+		Shutdown(context.Background(), logrus.New(), []string{"task-2", "task-failing", "task-1", "task-panicking"}, rec.unload, rec.shutdown)
+
+		So(rec.unloaded, ShouldHaveLength, 2)
+		So(rec.unloaded, ShouldContain, "task-1")
+		So(rec.unloaded, ShouldContain, "task-2")
+		So(rec.hookCall, ShouldEqual, 1)
+	})
+
+	Convey("Validate that shutdown completes without hook", t, func() {
+		rec := &shutdownRecorder{}
+
+		Shutdown(context.Background(), logrus.New(), []string{"task-1"}, rec.unload, nil)
+
+		So(rec.unloaded, ShouldResemble, []string{"task-1"})
+	})
+
+	Convey("Validate that shutdown is limited by grace period", t, func() {
+		rec := &shutdownRecorder{}
+
+		ctx, cancelFn := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancelFn()
+
+		startTime := time.Now()
+		Shutdown(ctx, logrus.New(), []string{"task-1", "task-hanging"}, rec.unload, rec.shutdown)
+
+		So(time.Since(startTime), ShouldBeLessThan, 500*time.Millisecond)
+		So(rec.hookCall, ShouldEqual, 0)
+	})
+}
+
+func TestInitialize(t *testing.T) {
+	Convey("Validate that errors and panics of initialization hook are reported", t, func() {
+		So(Initialize(func() error { return nil }), ShouldBeNil)
+
+		err := Initialize(func() error { return errors.New("can't connect to database") })
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldContainSubstring, "can't connect to database")
+
+		err = Initialize(func() error { panic("invalid state") })
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldContainSubstring, "panic")
+	})
+}
+
+func TestSharedStore(t *testing.T) {
+	Convey("Validate that objects put into shared store are available in all tasks", t, func() {
+		cm := NewContextManager()
+		cm.Shared.Store("pool", []string{"conn-1", "conn-2"})
+
+		ctx1, err := NewContext([]byte(`{}`))
+		So(err, ShouldBeNil)
+		ctx2, err := NewContext([]byte(`{}`))
+		So(err, ShouldBeNil)
+
+		cm.AttachSharedStore(ctx1)
+		cm.AttachSharedStore(ctx2)
+
+		var pool []string
+		So(ctx1.Shared().LoadTo("pool", &pool), ShouldBeNil)
+		So(pool, ShouldResemble, []string{"conn-1", "conn-2"})
+
+		ctx1.Shared().Store("cache", 10)
+		v, ok := ctx2.Shared().Load("cache")
+		So(ok, ShouldBeTrue)
+		So(v, ShouldEqual, 10)
+
+		var wrongType int
+		So(ctx2.Shared().LoadTo("pool", &wrongType), ShouldBeError)
+
+		ctx2.Shared().Delete("cache")
+		_, ok = ctx1.Shared().Load("cache")
+		So(ok, ShouldBeFalse)
+
+		Convey("Validate that tasks' private objects are not shared", func() {
+			ctx1.Store("private", 1)
+			_, ok := ctx2.Load("private")
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -21,6 +21,9 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/configschema"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/statestore"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 	"gopkg.in/yaml.v3"
 )
@@ -37,7 +40,13 @@ type ContextManager struct {
 	TasksLimit     int
 	InstancesLimit int
 
-	ExampleConfig yaml.Node // example config
+	ExampleConfig yaml.Node            // example config
+	ConfigSchema  *configschema.Schema // typed configuration fields declared by plugin
+
+	StateStore *statestore.Store // persistent state of tasks (nil - not available)
+	Shared     *SharedStore      // objects shared by all tasks of plugin
+
+	taskSecrets sync.Map // task id -> *secrets.Registration (secret values redacted while task is loaded)
 }
 
 func NewContextManager() *ContextManager {
@@ -45,6 +54,8 @@ func NewContextManager() *ContextManager {
 		activeTasks:    map[string]contextHolder{},
 		TasksLimit:     plugin.NoLimit,
 		InstancesLimit: plugin.NoLimit,
+		ConfigSchema:   configschema.NewSchema(),
+		Shared:         NewSharedStore(),
 	}
 }
 
@@ -85,6 +96,43 @@ func (cm *ContextManager) ReleaseTask(id string) {
 	}
 }
 
+// Cancel all tasks in progress (ie. when plugin is being stopped, streaming collectors send last chunk and complete)
+func (cm *ContextManager) ReleaseAllTasks() {
+	cm.activeTasksMutex.Lock()
+	defer cm.activeTasksMutex.Unlock()
+
+	for _, aTask := range cm.activeTasks {
+		aTask.cancelFn()
+	}
+}
+
+// Cancel task only when it's still associated with a given context (request could have been already completed, ie. due to timeout)
+func (cm *ContextManager) ReleaseTaskContext(id string, ctx context.Context) {
+	cm.activeTasksMutex.Lock()
+	defer cm.activeTasksMutex.Unlock()
+
+	if aTask, ok := cm.activeTasks[id]; ok && aTask.ctx == ctx {
+		aTask.cancelFn()
+	}
+}
+
+// Make persistent state of a task available via context (state is read from disk on first use)
+func (cm *ContextManager) AttachPersistentState(ctx *Context, id string) {
+	store := cm.StateStore
+	if store == nil {
+		return
+	}
+
+	ctx.persistentStateFn = func() plugin.PersistentState {
+		return store.TaskState(id)
+	}
+}
+
+// Make objects shared by all tasks of plugin available via context
+func (cm *ContextManager) AttachSharedStore(ctx *Context) {
+	ctx.shared = cm.Shared
+}
+
 func (cm *ContextManager) TaskContext(id string) context.Context {
 	cm.activeTasksMutex.Lock()
 	defer cm.activeTasksMutex.Unlock()
@@ -118,3 +166,106 @@ func (cm *ContextManager) DefineExampleConfig(cfg string) error {
 
 	return nil
 }
+
+func (cm *ContextManager) DefineConfigField(path string, typ plugin.ConfigFieldType, modifiers ...plugin.ConfigFieldModifier) error {
+	return cm.ConfigSchema.DefineField(path, typ, modifiers...)
+}
+
+// Validate task configuration against fields declared by plugin and fill missing values with defaults
+func (cm *ContextManager) ApplyConfigSchema(rawConfig []byte) ([]byte, error) {
+	return cm.ConfigSchema.Apply(rawConfig)
+}
+
+// Prepare task configuration: resolve references (${env:VAR}, ${file:/path}) and apply config schema.
+// Returns configuration passed to plugin and its copy with masked secrets (presented in statistics).
+// Secret values are redacted from logs and errors until ReleaseSecrets is called for a task.
+func (cm *ContextManager) PrepareConfig(id string, rawConfig []byte) ([]byte, []byte, error) {
+	config, redacted, reg, err := cm.prepareConfig(rawConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cm.taskSecrets.Store(id, reg)
+
+	return config, redacted, nil
+}
+
+// Prepare new configuration of already loaded task (see PrepareConfig). Secrets of both configurations are redacted
+// until returned function is called: with true - new configuration has been applied, false - it has been rejected.
+func (cm *ContextManager) PrepareNewConfig(id string, rawConfig []byte) ([]byte, []byte, func(applied bool), error) {
+	config, redacted, reg, err := cm.prepareConfig(rawConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	completeFn := func(applied bool) {
+		if !applied {
+			reg.Release()
+			return
+		}
+
+		cm.ReleaseSecrets(id)
+		cm.taskSecrets.Store(id, reg)
+	}
+
+	return config, redacted, completeFn, nil
+}
+
+func (cm *ContextManager) prepareConfig(rawConfig []byte) ([]byte, []byte, *secrets.Registration, error) {
+	resolved, refPaths, err := secrets.ResolveReferences(rawConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	redacted, values, err := secrets.Scan(resolved, cm.secretPathFn(refPaths))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reg := secrets.Register(values...)
+
+	config, err := cm.ApplyConfigSchema(resolved)
+	if err != nil {
+		err = secrets.RedactError(err)
+		reg.Release()
+		return nil, nil, nil, err
+	}
+
+	return config, redacted, reg, nil
+}
+
+// Stop redacting secret values of a task (called when task is unloaded or couldn't be loaded)
+func (cm *ContextManager) ReleaseSecrets(id string) {
+	if reg, ok := cm.taskSecrets.Load(id); ok {
+		reg.(*secrets.Registration).Release()
+		cm.taskSecrets.Delete(id)
+	}
+}
+
+// Key is secret when it's declared so (ConfigSecret) or matches naming pattern (used to redact plugin options)
+func (cm *ContextManager) IsSecretPath(path string) bool {
+	return cm.secretPathFn(nil)(path)
+}
+
+// Key is secret when it's declared so (ConfigSecret), matches naming pattern or its value was read from reference
+func (cm *ContextManager) secretPathFn(refPaths []string) func(string) bool {
+	return func(path string) bool {
+		if secrets.IsSecretKey(path) {
+			return true
+		}
+
+		for _, p := range refPaths {
+			if p == path {
+				return true
+			}
+		}
+
+		for _, f := range cm.ConfigSchema.Fields() {
+			if f.Secret && f.Path == path {
+				return true
+			}
+		}
+
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package proxy
+
+import (
+	"sort"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+// New configuration of a task (see plugin.ConfigChange)
+type configChange struct {
+	*Context // context created from new configuration
+
+	added    []string
+	removed  []string
+	modified []string
+}
+
+// Calculate difference between flattened configurations of current and new context
+func NewConfigChange(current *Context, newCtx *Context) plugin.ConfigChange {
+	current.configMutex.RLock()
+	defer current.configMutex.RUnlock()
+
+	change := &configChange{
+		Context:  newCtx,
+		added:    []string{},
+		removed:  []string{},
+		modified: []string{},
+	}
+
+	for k, v := range newCtx.flattenedConfig {
+		oldV, ok := current.flattenedConfig[k]
+		switch {
+		case !ok:
+			change.added = append(change.added, k)
+		case oldV != v:
+			change.modified = append(change.modified, k)
+		}
+	}
+
+	for k := range current.flattenedConfig {
+		if _, ok := newCtx.flattenedConfig[k]; !ok {
+			change.removed = append(change.removed, k)
+		}
+	}
+
+	sort.Strings(change.added)
+	sort.Strings(change.removed)
+	sort.Strings(change.modified)
+
+	return change
+}
+
+func (cc *configChange) Added() []string {
+	return cc.added
+}
+
+func (cc *configChange) Removed() []string {
+	return cc.removed
+}
+
+func (cc *configChange) Modified() []string {
+	return cc.modified
+}
+
+// Replace configuration with the one held by other context (stored objects, state and warnings are kept)
+func (c *Context) SwapConfig(other *Context) {
+	c.configMutex.Lock()
+	defer c.configMutex.Unlock()
+
+	c.rawConfig = other.rawConfig
+	c.flattenedConfig = other.flattenedConfig
+	c.parsedConfig = other.parsedConfig
+}
@@ -0,0 +1,68 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SharedStore keeps objects shared by all tasks of plugin (implements plugin.SharedStore)
+type SharedStore struct {
+	mutex   sync.RWMutex
+	objects map[string]interface{}
+}
+
+func NewSharedStore() *SharedStore {
+	return &SharedStore{
+		objects: map[string]interface{}{},
+	}
+}
+
+func (s *SharedStore) Store(key string, value interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.objects[key] = value
+}
+
+func (s *SharedStore) Load(key string) (interface{}, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	obj, ok := s.objects[key]
+	return obj, ok
+}
+
+func (s *SharedStore) LoadTo(key string, dest interface{}) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return fmt.Errorf("couldn't find shared object with a given key (%s)", key)
+	}
+
+	return loadTo(obj, dest)
+}
+
+func (s *SharedStore) Delete(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.objects, key)
+}
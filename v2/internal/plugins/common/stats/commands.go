@@ -78,3 +78,80 @@ type streamTaskStat struct {
 func (ts *streamTaskStat) ApplyStat() {
 	ts.sm.applyStreamStat(ts.taskID, ts.metricsCount, ts.startTime, ts.lastUpdate)
 }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type timeoutTaskStat struct {
+	sm           *StatisticsController
+	taskID       string
+	metricsCount int
+	startTime    time.Time
+	processTime  time.Time
+}
+
+func (ts *timeoutTaskStat) ApplyStat() {
+	ts.sm.applyTimeoutStat(ts.taskID, ts.metricsCount, ts.startTime, ts.processTime)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type bufferTaskStat struct {
+	sm             *StatisticsController
+	taskID         string
+	queuedBatches  int
+	size           int64
+	droppedBatches int
+}
+
+func (ts *bufferTaskStat) ApplyStat() {
+	ts.sm.applyBufferStat(ts.taskID, ts.queuedBatches, ts.size, ts.droppedBatches)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type seriesLimitTaskStat struct {
+	sm            *StatisticsController
+	taskID        string
+	droppedSeries int
+	topNamespaces map[string]int
+	topTagKeys    map[string]int
+}
+
+func (ts *seriesLimitTaskStat) ApplyStat() {
+	ts.sm.applySeriesLimitStat(ts.taskID, ts.droppedSeries, ts.topNamespaces, ts.topTagKeys)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type cacheHitTaskStat struct {
+	sm     *StatisticsController
+	taskID string
+}
+
+func (ts *cacheHitTaskStat) ApplyStat() {
+	ts.sm.applyCacheHitStat(ts.taskID)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type reconfigureTaskStat struct {
+	sm      *StatisticsController
+	taskID  string
+	config  string
+	filters []string
+}
+
+func (ts *reconfigureTaskStat) ApplyStat() {
+	ts.sm.applyReconfigureStat(ts.taskID, ts.config, ts.filters)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type redactPluginInfoStat struct {
+	sm       *StatisticsController
+	isSecret func(path string) bool
+}
+
+func (ts *redactPluginInfoStat) ApplyStat() {
+	ts.sm.applyRedactPluginInfo(ts.isSecret)
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 
@@ -47,6 +48,12 @@ type Controller interface {
 	UpdateUnloadStat(taskID string)
 	UpdateExecutionStat(taskID string, metricsCount int, success bool, startTime, endTime time.Time)
 	UpdateStreamingStat(taskID string, metricsCount int, startTime, lastUpdate time.Time)
+	UpdateTimeoutStat(taskID string, metricsCount int, startTime, endTime time.Time)
+	UpdateBufferStat(taskID string, queuedBatches int, size int64, droppedBatches int)
+	UpdateSeriesLimitStat(taskID string, droppedSeries int, topNamespaces, topTagKeys map[string]int)
+	UpdateCacheHitStat(taskID string)
+	UpdateReconfigureStat(taskID string, config string, filters []string)
+	RedactPluginInfo(isSecret func(path string) bool)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -70,11 +77,16 @@ type StatisticsController struct {
 	closeCh           chan struct{}
 	stats             *Statistics
 
+	args []string        // command-line arguments (kept to redact them again when secret fields are known)
+	opt  *plugin.Options // options of plugin (as above)
+
 	ctx context.Context
 }
 
 func NewStatsController(ctx context.Context, pluginName string, pluginVersion string, pluginType types.PluginType, opt *plugin.Options) (Controller, error) {
-	optJson, err := json.Marshal(opt)
+	args := os.Args[1:]
+
+	optJson, err := redactOptions(opt, secrets.IsSecretKey)
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +94,8 @@ func NewStatsController(ctx context.Context, pluginName string, pluginVersion st
 	sc := &StatisticsController{
 		ctx:        ctx,
 		pluginType: pluginType,
+		args:       args,
+		opt:        opt,
 
 		startedSync:       sync.Once{},
 		incomingStatsCh:   make(chan StatCommand, statsChannelSize),
@@ -97,7 +111,7 @@ func NewStatsController(ctx context.Context, pluginName string, pluginVersion st
 					Time: time.Now(),
 				},
 
-				CmdLineOptions: strings.Join(os.Args[1:], " "),
+				CmdLineOptions: redactCmdLine(args, secrets.IsSecretKey),
 				Options:        optJson,
 			},
 			TasksSummary: tasksSummary{},
@@ -110,6 +124,35 @@ func NewStatsController(ctx context.Context, pluginName string, pluginVersion st
 	return sc, nil
 }
 
+// Mask secret values in configuration passed as command-line argument (ie. -plugin-config='{"password": "..."}')
+func redactCmdLine(args []string, isSecret func(path string) bool) string {
+	redactedArgs := make([]string, 0, len(args))
+	for _, arg := range args {
+		if i := strings.Index(arg, "{"); i >= 0 {
+			arg = arg[:i] + redactConfig(arg[i:], isSecret)
+		}
+		redactedArgs = append(redactedArgs, arg)
+	}
+
+	return strings.Join(redactedArgs, " ")
+}
+
+func redactOptions(opt *plugin.Options, isSecret func(path string) bool) ([]byte, error) {
+	redactedOpt := *opt
+	redactedOpt.PluginConfig = redactConfig(opt.PluginConfig, isSecret)
+
+	return json.Marshal(redactedOpt)
+}
+
+func redactConfig(config string, isSecret func(path string) bool) string {
+	redacted, _, err := secrets.Scan([]byte(config), isSecret)
+	if err != nil {
+		return config // not a configuration (JSON)
+	}
+
+	return string(redacted)
+}
+
 func (sc *StatisticsController) run() {
 	sc.startedSync.Do(func() {
 		go func() {
@@ -182,8 +225,73 @@ func (sc *StatisticsController) UpdateStreamingStat(taskID string, metricsCount
 	}
 }
 
+func (sc *StatisticsController) UpdateTimeoutStat(taskID string, metricsCount int, startTime, endTime time.Time) {
+	sc.incomingStatsCh <- &timeoutTaskStat{
+		sm:           sc,
+		taskID:       taskID,
+		metricsCount: metricsCount,
+		startTime:    startTime,
+		processTime:  endTime,
+	}
+}
+
+func (sc *StatisticsController) UpdateBufferStat(taskID string, queuedBatches int, size int64, droppedBatches int) {
+	sc.incomingStatsCh <- &bufferTaskStat{
+		sm:             sc,
+		taskID:         taskID,
+		queuedBatches:  queuedBatches,
+		size:           size,
+		droppedBatches: droppedBatches,
+	}
+}
+
+func (sc *StatisticsController) UpdateSeriesLimitStat(taskID string, droppedSeries int, topNamespaces, topTagKeys map[string]int) {
+	sc.incomingStatsCh <- &seriesLimitTaskStat{
+		sm:            sc,
+		taskID:        taskID,
+		droppedSeries: droppedSeries,
+		topNamespaces: topNamespaces,
+		topTagKeys:    topTagKeys,
+	}
+}
+
+func (sc *StatisticsController) UpdateCacheHitStat(taskID string) {
+	sc.incomingStatsCh <- &cacheHitTaskStat{
+		sm:     sc,
+		taskID: taskID,
+	}
+}
+
+func (sc *StatisticsController) UpdateReconfigureStat(taskID string, config string, filters []string) {
+	sc.incomingStatsCh <- &reconfigureTaskStat{
+		sm:      sc,
+		taskID:  taskID,
+		config:  config,
+		filters: filters,
+	}
+}
+
+// Redact plugin options again, when secret fields are known (ie. declared in configuration schema)
+func (sc *StatisticsController) RedactPluginInfo(isSecret func(path string) bool) {
+	sc.incomingStatsCh <- &redactPluginInfoStat{
+		sm:       sc,
+		isSecret: isSecret,
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
+func (sc *StatisticsController) applyRedactPluginInfo(isSecret func(path string) bool) {
+	optJson, err := redactOptions(sc.opt, isSecret)
+	if err != nil {
+		sc.logger().WithError(err).Error("can't redact plugin options")
+		return
+	}
+
+	sc.stats.PluginInfo.CmdLineOptions = redactCmdLine(sc.args, isSecret)
+	sc.stats.PluginInfo.Options = optJson
+}
+
 func (sc *StatisticsController) applyLoadStat(taskID string, config string, filters []string) {
 	logF := sc.logger()
 	logF.WithFields(logrus.Fields{
@@ -292,6 +400,114 @@ func (sc *StatisticsController) applyStreamStat(taskID string, metricsCount int,
 	sc.stats.TasksDetails[taskID] = td
 }
 
+func (sc *StatisticsController) applyTimeoutStat(taskID string, metricsCount int, startTime, completeTime time.Time) {
+	sc.applyCollectStat(taskID, metricsCount, false, startTime, completeTime)
+
+	logF := sc.logger()
+	logF.WithFields(moduleFields).WithFields(logrus.Fields{
+		"task-id":        taskID,
+		"statistic-type": "Timeout",
+	}).Trace("Applying statistic")
+
+	// Update global stats
+	sc.stats.TasksSummary.Counters.TotalTimeouts += 1
+
+	// Update task-specific state
+	td := sc.stats.TasksDetails[taskID]
+	td.Counters.Timeouts += 1
+
+	sc.stats.TasksDetails[taskID] = td
+}
+
+func (sc *StatisticsController) applyBufferStat(taskID string, queuedBatches int, size int64, droppedBatches int) {
+	logF := sc.logger()
+	logF.WithFields(moduleFields).WithFields(logrus.Fields{
+		"task-id":        taskID,
+		"statistic-type": "Buffer",
+	}).Trace("Applying statistic")
+
+	td, ok := sc.stats.TasksDetails[taskID]
+	if !ok {
+		return // task has been already unloaded
+	}
+
+	td.Buffer = &bufferInfo{
+		QueuedBatches:  queuedBatches,
+		Size:           size,
+		DroppedBatches: droppedBatches,
+	}
+
+	sc.stats.TasksDetails[taskID] = td
+}
+
+func (sc *StatisticsController) applySeriesLimitStat(taskID string, droppedSeries int, topNamespaces, topTagKeys map[string]int) {
+	logF := sc.logger()
+	logF.WithFields(moduleFields).WithFields(logrus.Fields{
+		"task-id":        taskID,
+		"statistic-type": "SeriesLimit",
+	}).Trace("Applying statistic")
+
+	td, ok := sc.stats.TasksDetails[taskID]
+	if !ok {
+		return // task has been already unloaded
+	}
+
+	sc.stats.TasksSummary.Counters.TotalDroppedSeries += droppedSeries
+
+	td.Counters.DroppedSeries += droppedSeries
+	td.SeriesLimit = &seriesLimitInfo{
+		LastDroppedSeries: droppedSeries,
+		TopNamespaces:     topNamespaces,
+		TopTagKeys:        topTagKeys,
+	}
+
+	sc.stats.TasksDetails[taskID] = td
+}
+
+func (sc *StatisticsController) applyCacheHitStat(taskID string) {
+	logF := sc.logger()
+	logF.WithFields(moduleFields).WithFields(logrus.Fields{
+		"task-id":        taskID,
+		"statistic-type": "CacheHit",
+	}).Trace("Applying statistic")
+
+	td, ok := sc.stats.TasksDetails[taskID]
+	if !ok {
+		return // task has been already unloaded
+	}
+
+	sc.stats.TasksSummary.Counters.TotalCacheHits++
+	td.Counters.CacheHits++
+
+	sc.stats.TasksDetails[taskID] = td
+}
+
+func (sc *StatisticsController) applyReconfigureStat(taskID string, config string, filters []string) {
+	logF := sc.logger()
+	logF.WithFields(moduleFields).WithFields(logrus.Fields{
+		"task-id":        taskID,
+		"statistic-type": "Reconfigure",
+	}).Trace("Applying statistic")
+
+	td, ok := sc.stats.TasksDetails[taskID]
+	if !ok {
+		return // task has been already unloaded
+	}
+
+	if filters == nil { // generate [] instead of null when marshaling
+		filters = []string{}
+	}
+
+	td.Configuration = json.RawMessage(config)
+	td.Filters = filters
+	td.Counters.Reconfigurations++
+	td.Reconfigured = &eventTimes{
+		Time: time.Now(),
+	}
+
+	sc.stats.TasksDetails[taskID] = td
+}
+
 func (sc *StatisticsController) logger() logrus.FieldLogger {
 	return log.WithCtx(sc.ctx).WithFields(moduleFields).WithField("service", "stats")
 }
@@ -329,3 +545,21 @@ func (d *EmptyController) UpdateExecutionStat(taskID string, metricsCount int, s
 
 func (d *EmptyController) UpdateStreamingStat(taskID string, metricsCount int, startTime, lastUpdate time.Time) {
 }
+
+func (d *EmptyController) UpdateTimeoutStat(taskID string, metricsCount int, startTime, endTime time.Time) {
+}
+
+func (d *EmptyController) UpdateBufferStat(taskID string, queuedBatches int, size int64, droppedBatches int) {
+}
+
+func (d *EmptyController) UpdateSeriesLimitStat(taskID string, droppedSeries int, topNamespaces, topTagKeys map[string]int) {
+}
+
+func (d *EmptyController) UpdateCacheHitStat(taskID string) {
+}
+
+func (d *EmptyController) UpdateReconfigureStat(taskID string, config string, filters []string) {
+}
+
+func (d *EmptyController) RedactPluginInfo(isSecret func(path string) bool) {
+}
@@ -19,6 +19,7 @@
 package stats
 
 import (
+	"os"
 	"testing"
 	"time"
 
@@ -169,7 +170,67 @@ func TestStatistics(t *testing.T) {
 			So(td, ShouldNotContainKey, "task-3")
 		}
 
+		// Load task4 with collection exceeding timeout
+		{
+			// Act
+			sc.UpdateLoadStat("task-4", "cfg_1", []string{})
+			sc.UpdateExecutionStat("task-4", 5, true, startTime.Add(60*time.Second), startTime.Add(61*time.Second))
+			sc.UpdateTimeoutStat("task-4", 2, startTime.Add(65*time.Second), startTime.Add(75*time.Second))
+			sc.UpdateBufferStat("task-4", 3, 1024, 1)
+			sc.UpdateBufferStat("task-5", 1, 10, 0) // task not loaded
+
+			// Assert
+			time.Sleep(waitForCalculation)
+
+			ts := sc.stats.TasksSummary
+			So(ts.Counters.TotalExecutionRequests, ShouldEqual, 11)
+			So(ts.Counters.TotalTimeouts, ShouldEqual, 1)
+
+			td := sc.stats.TasksDetails
+			So(td["task-4"].Counters.CollectRequests, ShouldEqual, 2)
+			So(td["task-4"].Counters.Timeouts, ShouldEqual, 1)
+			So(td["task-4"].Counters.TotalMetrics, ShouldEqual, 7)
+			So(td["task-4"].LastMeasurement.ProcessedMetrics, ShouldEqual, 2)
+			So(td["task-4"].ProcessingTimes.Maximum, ShouldEqual, 10*time.Second)
+			So(*td["task-4"].Buffer, ShouldResemble, bufferInfo{QueuedBatches: 3, Size: 1024, DroppedBatches: 1})
+			So(td, ShouldNotContainKey, "task-5")
+		}
+
 		// Finalize
 		sc.Close()
 	})
 }
+
+func TestPluginInfoRedaction(t *testing.T) {
+	Convey("Validate that secrets are redacted from plugin options", t, func() {
+		// Arrange
+		config := `{"user": "admin", "password": "pass1", "dsn": "postgres://admin:pass2@db"}`
+
+		args := os.Args
+		os.Args = []string{"plugin", "-plugin-config=" + config}
+		defer func() { os.Args = args }()
+
+		sci, _ := NewStatsController(stdCtx.Background(), pluginName, pluginVersion, types.PluginTypeCollector, &plugin.Options{PluginConfig: config})
+		sc := sci.(*StatisticsController)
+		defer sc.Close()
+
+		time.Sleep(waitForCalculation)
+		So(sc.stats.PluginInfo.CmdLineOptions, ShouldNotContainSubstring, "pass1")
+		So(sc.stats.PluginInfo.CmdLineOptions, ShouldContainSubstring, "pass2")
+
+		// Act - dsn is declared as secret field
+		sc.RedactPluginInfo(func(path string) bool {
+			return path == "password" || path == "dsn"
+		})
+
+		// Assert
+		time.Sleep(waitForCalculation)
+
+		pi := sc.stats.PluginInfo
+		So(pi.CmdLineOptions, ShouldContainSubstring, "admin")
+		So(pi.CmdLineOptions, ShouldNotContainSubstring, "pass1")
+		So(pi.CmdLineOptions, ShouldNotContainSubstring, "pass2")
+		So(string(pi.Options), ShouldNotContainSubstring, "pass1")
+		So(string(pi.Options), ShouldNotContainSubstring, "pass2")
+	})
+}
@@ -47,10 +47,13 @@ type taskDetails struct {
 	Configuration json.RawMessage `json:"Configuration"`
 	Filters       []string        `json:"Requested metrics (filters),omitempty"`
 
-	Counters        tasksCounters   `json:"Counters"`
-	Loaded          eventTimes      `json:"Loaded"`
-	ProcessingTimes processingTimes `json:"Processing times"`
-	LastMeasurement measurementInfo `json:"Last execution"`
+	Counters        tasksCounters    `json:"Counters"`
+	Loaded          eventTimes       `json:"Loaded"`
+	Reconfigured    *eventTimes      `json:"Reconfigured,omitempty"`
+	ProcessingTimes processingTimes  `json:"Processing times"`
+	LastMeasurement measurementInfo  `json:"Last execution"`
+	Buffer          *bufferInfo      `json:"Disk buffer,omitempty"`
+	SeriesLimit     *seriesLimitInfo `json:"Series limit,omitempty"`
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -59,12 +62,32 @@ type summaryCounters struct {
 	CurrentlyActiveTasks   int `json:"Currently active tasks"`
 	TotalActiveTasks       int `json:"Total active tasks"`
 	TotalExecutionRequests int `json:"Total execution requests"`
+	TotalTimeouts          int `json:"Total timeouts"`
+	TotalDroppedSeries     int `json:"Total dropped series"`
+	TotalCacheHits         int `json:"Total cache hits"`
 }
 
 type tasksCounters struct {
 	CollectRequests        int `json:"Collect requests"`
 	TotalMetrics           int `json:"Total metrics"`
 	AvgMetricsPerExecution int `json:"Average metrics / Execution"`
+	Timeouts               int `json:"Timeouts"`
+	DroppedSeries          int `json:"Dropped series"`
+	CacheHits              int `json:"Cache hits"`
+	Reconfigurations       int `json:"Reconfigurations"`
+}
+
+type bufferInfo struct {
+	QueuedBatches  int   `json:"Queued batches"`
+	Size           int64 `json:"Size (bytes)"`
+	DroppedBatches int   `json:"Dropped batches"`
+}
+
+// Series dropped due to exceeded limits, reported at the end of the last collection in which it happened
+type seriesLimitInfo struct {
+	LastDroppedSeries int            `json:"Dropped series (last exceeded)"`
+	TopNamespaces     map[string]int `json:"Top namespaces"`
+	TopTagKeys        map[string]int `json:"Top tag keys"`
 }
 
 type measurementInfo struct {
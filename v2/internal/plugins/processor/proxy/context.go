@@ -0,0 +1,185 @@
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/proxy"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/metrictree"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type PluginContext struct {
+	*proxy.Context
+
+	taskID          string
+	sessionMtsMutex sync.RWMutex
+	sessionMts      []*types.Metric
+}
+
+func NewPluginContext(ctxManager *ContextManager, taskID string, rawConfig []byte) (*PluginContext, error) {
+	if ctxManager == nil {
+		return nil, errors.New("can't create context without valid context manager")
+	}
+
+	baseContext, err := proxy.NewContext(rawConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PluginContext{
+		Context: baseContext,
+		taskID:  taskID,
+	}, nil
+}
+
+func (pc *PluginContext) ListAllMetrics() []plugin.Metric {
+	pc.sessionMtsMutex.RLock()
+	defer pc.sessionMtsMutex.RUnlock()
+
+	mts := make([]plugin.Metric, 0, len(pc.sessionMts))
+
+	for _, mt := range pc.sessionMts {
+		mts = append(mts, mt)
+	}
+
+	return mts
+}
+
+func (pc *PluginContext) Count() int {
+	pc.sessionMtsMutex.RLock()
+	defer pc.sessionMtsMutex.RUnlock()
+
+	return len(pc.sessionMts)
+}
+
+func (pc *PluginContext) AddMetric(ns string, v interface{}, modifiers ...plugin.MetricModifier) error {
+	if !types.IsValidValueType(v) {
+		return fmt.Errorf("invalid value type (%T) for metric: %s", v, ns)
+	}
+	if err := types.ValidateValue(v); err != nil {
+		return fmt.Errorf("invalid value for metric %s: %v", ns, err)
+	}
+
+	parsedNs, err := metrictree.ParseNamespace(ns, false)
+	if err != nil {
+		return fmt.Errorf("invalid format of namespace: %v", err)
+	}
+	if !parsedNs.IsUsableForAddition(true, false) {
+		return fmt.Errorf("invalid namespace (some elements can't be used when adding metric): %s", ns)
+	}
+
+	nsElems, _, err := metrictree.SplitNamespace(ns)
+	if err != nil {
+		return err
+	}
+
+	var mtNamespace []types.NamespaceElement
+	for _, nsElem := range nsElems[1:] {
+		mtNamespace = append(mtNamespace, pc.toNamespaceElement(nsElem))
+	}
+
+	mt := &types.Metric{
+		Namespace_: mtNamespace,
+		Value_:     v,
+		Timestamp_: time.Now(),
+	}
+
+	for _, m := range modifiers {
+		m.UpdateMetric(mt)
+	}
+
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	pc.sessionMts = append(pc.sessionMts, mt)
+
+	return nil
+}
+
+func (pc *PluginContext) ModifyMetric(mt plugin.Metric, modifiers ...plugin.MetricModifier) error {
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	i, err := pc.indexOf(mt)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range modifiers {
+		m.UpdateMetric(pc.sessionMts[i])
+	}
+
+	return nil
+}
+
+func (pc *PluginContext) DropMetric(mt plugin.Metric) error {
+	pc.sessionMtsMutex.Lock()
+	defer pc.sessionMtsMutex.Unlock()
+
+	i, err := pc.indexOf(mt)
+	if err != nil {
+		return err
+	}
+
+	pc.sessionMts = append(pc.sessionMts[:i], pc.sessionMts[i+1:]...)
+
+	return nil
+}
+
+func (pc *PluginContext) TaskID() string {
+	return pc.taskID
+}
+
+// find position of metric (obtained via ListAllMetrics) in processed set
+// function assumes that caller holds sessionMtsMutex
+func (pc *PluginContext) indexOf(mt plugin.Metric) (int, error) {
+	typedMt, ok := mt.(*types.Metric)
+	if !ok {
+		return -1, fmt.Errorf("metric wasn't obtained from processed set: %v", mt)
+	}
+
+	for i, sessionMt := range pc.sessionMts {
+		if sessionMt == typedMt {
+			return i, nil
+		}
+	}
+
+	return -1, fmt.Errorf("metric doesn't belong to processed set: %s", typedMt.Namespace().String())
+}
+
+// convert element of namespace like. /plugin/[grp=id]/m1 into namespace element
+// function assumes valid format
+func (pc *PluginContext) toNamespaceElement(s string) types.NamespaceElement {
+	if strings.HasPrefix(s, "[") {
+		eqIndex := strings.Index(s, "=")
+		if eqIndex != -1 {
+			return types.NamespaceElement{
+				Name_:  s[1:eqIndex],
+				Value_: s[eqIndex+1 : len(s)-1],
+			}
+		}
+	}
+
+	return types.NamespaceElement{Value_: s}
+}
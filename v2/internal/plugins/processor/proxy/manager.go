@@ -0,0 +1,254 @@
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	commonProxy "github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/proxy"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+var log *logrus.Entry
+
+func init() {
+	log = logrus.WithFields(logrus.Fields{"layer": "lib", "module": "processor-proxy"})
+}
+
+type Processor interface {
+	RequestProcess(id string, mts []*types.Metric) ([]*types.Metric, types.ProcessingStatus)
+	LoadTask(id string, config []byte) error
+	UnloadTask(id string) error
+	CustomInfo(id string) ([]byte, error)
+	CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error)
+	CheckPluginHealth(ctx context.Context) types.HealthReport
+	Shutdown(ctx context.Context)
+}
+
+type ContextManager struct {
+	*commonProxy.ContextManager
+
+	processor  plugin.Processor
+	contextMap sync.Map
+
+	statsController stats.Controller // reference to statistics controller
+}
+
+func NewContextManager(processor plugin.Processor, statsController stats.Controller) *ContextManager {
+	cm := &ContextManager{
+		ContextManager: commonProxy.NewContextManager(),
+		processor:      processor,
+		contextMap:     sync.Map{},
+
+		statsController: statsController,
+	}
+
+	cm.RequestPluginDefinition()
+
+	return cm
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// proxy.Processor related methods
+
+func (cm *ContextManager) RequestProcess(id string, mts []*types.Metric) ([]*types.Metric, types.ProcessingStatus) {
+	if !cm.AcquireTask(id) {
+		return nil, types.ProcessingStatus{
+			Error: fmt.Errorf("can't process process request, other request for the same id (%s) is in progress", id),
+		}
+	}
+	defer cm.MarkTaskAsCompleted(id)
+
+	contextIf, ok := cm.contextMap.Load(id)
+	if !ok {
+		return nil, types.ProcessingStatus{
+			Error: fmt.Errorf("can't find a context for a given id: %s", id),
+		}
+	}
+	context := contextIf.(*PluginContext)
+
+	context.sessionMts = mts // metrics to process are set within context
+	context.ResetWarnings()
+	defer func() { context.sessionMts = nil }()
+
+	startTime := time.Now()
+	err := cm.processor.Process(context) // calling to user defined code
+	warnings := context.Warnings(false)
+	endTime := time.Now()
+
+	processedMts := context.sessionMts
+
+	cm.statsController.UpdateExecutionStat(id, len(processedMts), err != nil, startTime, endTime)
+
+	if err != nil {
+		return nil, types.ProcessingStatus{
+			Error:    fmt.Errorf("user-defined Process method ended with error: %v", err),
+			Warnings: warnings,
+		}
+	}
+
+	log.WithFields(logrus.Fields{
+		"elapsed":         endTime.Sub(startTime).String(),
+		"metrics-num":     len(mts),
+		"out-metrics-num": len(processedMts),
+		"warnings-num":    len(warnings),
+	}).Debug("Process completed")
+
+	return processedMts, types.ProcessingStatus{
+		Warnings: warnings,
+	}
+}
+
+func (cm *ContextManager) LoadTask(id string, config []byte) (err error) {
+	if !cm.AcquireTask(id) {
+		return fmt.Errorf("can't process load request, other request for the same id (%s) is in progress", id)
+	}
+	defer cm.MarkTaskAsCompleted(id)
+
+	if _, ok := cm.contextMap.Load(id); ok {
+		return errors.New("context with given id was already defined")
+	}
+
+	validConfig, redactedConfig, err := cm.PrepareConfig(id, config)
+	if err != nil {
+		return fmt.Errorf("can't load task: %v", err)
+	}
+
+	// secrets of a task which couldn't be loaded shouldn't be kept (nor leaked via returned error)
+	defer func() {
+		if err != nil {
+			err = secrets.RedactError(err)
+			cm.ReleaseSecrets(id)
+		}
+	}()
+
+	newCtx, err := NewPluginContext(cm, id, validConfig)
+	if err != nil {
+		return fmt.Errorf("can't load task: %v", err)
+	}
+
+	cm.AttachPersistentState(newCtx.Context, id)
+	cm.AttachSharedStore(newCtx.Context)
+
+	if loadable, ok := cm.processor.(plugin.LoadableProcessor); ok {
+		err := loadable.Load(newCtx)
+		if err != nil {
+			return fmt.Errorf("can't load task due to errors returned from user-defined function: %s", err)
+		}
+	}
+
+	cm.contextMap.Store(id, newCtx)
+	cm.statsController.UpdateLoadStat(id, string(redactedConfig), nil)
+
+	return nil
+}
+
+func (cm *ContextManager) UnloadTask(id string) error {
+	if !cm.AcquireTask(id) {
+		return fmt.Errorf("can't process unload request, other request for the same id (%s) is in progress", id)
+	}
+	defer cm.MarkTaskAsCompleted(id)
+
+	contextI, ok := cm.contextMap.Load(id)
+	if !ok {
+		return errors.New("context with given id is not defined")
+	}
+
+	context := contextI.(*PluginContext)
+	if unloadable, ok := cm.processor.(plugin.UnloadableProcessor); ok {
+		err := unloadable.Unload(context)
+		if err != nil {
+			return fmt.Errorf("error occured when trying to unload a processor task (%s): %v", id, err)
+		}
+	}
+
+	cm.contextMap.Delete(id)
+	cm.ReleaseSecrets(id)
+	cm.statsController.UpdateUnloadStat(id)
+
+	return nil
+}
+
+func (cm *ContextManager) CustomInfo(id string) ([]byte, error) {
+	// Do not call cm.AcquireTask as above methods. CustomInfo is read-only
+
+	contextI, ok := cm.contextMap.Load(id)
+	if !ok {
+		return nil, errors.New("context with given id is not defined")
+	}
+	context := contextI.(*PluginContext)
+
+	if processorWithCustomInfo, ok := cm.processor.(plugin.CustomizableInfoProcessor); ok {
+		infoObj := processorWithCustomInfo.CustomInfo(context)
+
+		infoJSON, err := json.Marshal(infoObj)
+		if err != nil {
+			return nil, fmt.Errorf("can't unmarshal custom info to JSON: %v", err)
+		}
+
+		return infoJSON, nil
+	}
+
+	return []byte{}, nil
+}
+
+// Run health checks defined by plugin (all tasks are checked when taskID is empty)
+func (cm *ContextManager) CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error) {
+	// Do not call cm.AcquireTask, health may be checked when other request is in progress
+
+	tasks := map[string]plugin.Context{}
+	cm.contextMap.Range(func(k, v interface{}) bool {
+		tasks[k.(string)] = v.(*PluginContext)
+		return true
+	})
+
+	return commonProxy.CheckHealth(ctx, cm.processor, tasks, taskID)
+}
+
+// Run plugin-wide health check defined by plugin (used for liveness)
+func (cm *ContextManager) CheckPluginHealth(ctx context.Context) types.HealthReport {
+	return commonProxy.CheckPluginHealth(ctx, cm.processor)
+}
+
+// Unload all tasks and call plugin-wide shutdown hook (when implemented). Used when plugin is being stopped.
+func (cm *ContextManager) Shutdown(ctx context.Context) {
+	ids := []string{}
+	cm.contextMap.Range(func(k, _ interface{}) bool {
+		ids = append(ids, k.(string))
+		return true
+	})
+
+	commonProxy.Shutdown(ctx, log, ids, cm.UnloadTask, nil)
+}
+
+func (cm *ContextManager) RequestPluginDefinition() {
+	if definable, ok := cm.processor.(plugin.DefinableProcessor); ok {
+		err := definable.PluginDefinition(cm)
+		if err != nil {
+			log.WithError(err).Errorf("Error occurred during plugin definition")
+		}
+	}
+}
@@ -0,0 +1,328 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package proxy
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/diskqueue"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	defaultBufferRetryInterval    = 1 * time.Second
+	defaultBufferMaxRetryInterval = 1 * time.Minute
+
+	bufferCheckInterval  = 10 * time.Second      // how often empty buffer is checked (in case notification was missed)
+	taskAcquireInterval  = 10 * time.Millisecond // how often busy task is checked when waiting for it
+	bufferAcquireTimeout = 10 * time.Second      // how long requests wait for task held by drainer of buffer
+	taskBufferDirPrefix  = "task-"
+)
+
+// Parameters of disk buffer defined by plugin
+type diskBufferConfig struct {
+	dir              string
+	queueOpt         diskqueue.Options
+	retryInterval    time.Duration
+	maxRetryInterval time.Duration
+	maxRetries       int
+}
+
+func newDiskBufferConfig(dir string) *diskBufferConfig {
+	return &diskBufferConfig{
+		dir: dir,
+		queueOpt: diskqueue.Options{
+			MaxSize:     diskqueue.DefaultMaxSize,
+			SegmentSize: diskqueue.DefaultSegmentSize,
+		},
+		retryInterval:    defaultBufferRetryInterval,
+		maxRetryInterval: defaultBufferMaxRetryInterval,
+	}
+}
+
+func (c *diskBufferConfig) SetMaxSize(bytes int64) {
+	c.queueOpt.MaxSize = bytes
+}
+
+func (c *diskBufferConfig) SetMaxAge(age time.Duration) {
+	c.queueOpt.MaxAge = age
+}
+
+func (c *diskBufferConfig) SetSegmentSize(bytes int64) {
+	c.queueOpt.SegmentSize = bytes
+}
+
+func (c *diskBufferConfig) SetRetryInterval(interval, maxInterval time.Duration) {
+	c.retryInterval = interval
+	c.maxRetryInterval = maxInterval
+}
+
+func (c *diskBufferConfig) SetMaxRetries(retries int) {
+	c.maxRetries = retries
+}
+
+func (c *diskBufferConfig) validate() error {
+	if c.dir == "" {
+		return errors.New("buffer directory can't be empty")
+	}
+	if c.queueOpt.MaxSize <= 0 || c.queueOpt.SegmentSize <= 0 {
+		return errors.New("buffer and segment sizes should be positive")
+	}
+	if c.queueOpt.MaxAge < 0 {
+		return errors.New("maximum age of buffered metrics can't be negative")
+	}
+	if c.retryInterval <= 0 || c.maxRetryInterval < c.retryInterval {
+		return errors.New("invalid retry interval")
+	}
+	if c.maxRetries < 0 {
+		return errors.New("number of retries can't be negative")
+	}
+
+	return nil
+}
+
+// Disk buffer associated with a task and its background drainer
+type taskBuffer struct {
+	queue    *diskqueue.Queue
+	ctx      context.Context
+	cancelFn context.CancelFunc
+	doneCh   chan struct{}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (cm *ContextManager) DefineDiskBuffer(dir string, modifiers ...plugin.DiskBufferModifier) error {
+	cfg := newDiskBufferConfig(dir)
+	for _, m := range modifiers {
+		m.UpdateDiskBuffer(cfg)
+	}
+
+	err := cfg.validate()
+	if err != nil {
+		return fmt.Errorf("invalid disk buffer definition: %v", err)
+	}
+
+	cm.diskBuffer = cfg
+	return nil
+}
+
+// Open buffer of a task (metrics left by previous instance of task will be published as well)
+func (cm *ContextManager) openTaskBuffer(id string, pContext *PluginContext) error {
+	dir := filepath.Join(cm.diskBuffer.dir, taskBufferDirPrefix+hex.EncodeToString([]byte(id)))
+
+	queue, err := diskqueue.Open(dir, cm.diskBuffer.queueOpt)
+	if err != nil {
+		return fmt.Errorf("can't open disk buffer: %v", err)
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	pContext.AttachContext(ctx)
+
+	pContext.buffer = &taskBuffer{
+		queue:    queue,
+		ctx:      ctx,
+		cancelFn: cancelFn,
+		doneCh:   make(chan struct{}),
+	}
+
+	return nil
+}
+
+// Reopen buffer of a task which remains loaded (ie. when user-defined Unload has failed)
+func (cm *ContextManager) restoreTaskBuffer(id string, pContext *PluginContext) error {
+	err := cm.openTaskBuffer(id, pContext)
+	if err != nil {
+		return err
+	}
+
+	go cm.drainTaskBuffer(id, pContext)
+	return nil
+}
+
+// Stop draining buffer (not published metrics are kept on disk)
+func (cm *ContextManager) stopTaskBuffer(pContext *PluginContext) error {
+	buf := pContext.buffer
+	if buf == nil {
+		return nil
+	}
+
+	buf.cancelFn()
+	<-buf.doneCh
+
+	pContext.buffer = nil
+	return buf.queue.Close()
+}
+
+func (cm *ContextManager) bufferMetrics(id string, pContext *PluginContext, mts []*types.Metric, events []*types.Event) types.ProcessingStatus {
+	queue := pContext.buffer.queue
+
+	if len(mts) > 0 || len(events) > 0 {
+		data, err := types.EncodeBatch(types.Batch{Metrics: mts, Events: events})
+		if err == nil {
+			err = queue.Append(data)
+		}
+		if err != nil {
+			return types.ProcessingStatus{
+				Error: fmt.Errorf("can't write metrics to disk buffer: %v", err),
+			}
+		}
+	}
+
+	cm.updateBufferStat(id, queue)
+
+	log.WithFields(logrus.Fields{
+		"task-id":     id,
+		"metrics-num": len(mts),
+		"events-num":  len(events),
+	}).Debug("Metrics written to disk buffer")
+
+	return types.ProcessingStatus{}
+}
+
+// Publish buffered metrics in background (until task is unloaded)
+func (cm *ContextManager) drainTaskBuffer(id string, pContext *PluginContext) {
+	buf := pContext.buffer
+	defer close(buf.doneCh)
+
+	ctx := buf.ctx
+	logF := log.WithField("task-id", id)
+	retries := 0
+	delay := cm.diskBuffer.retryInterval
+
+	for {
+		cm.updateBufferStat(id, buf.queue)
+
+		data, pos, err := buf.queue.Peek()
+		if err == diskqueue.ErrEmpty {
+			select {
+			case <-buf.queue.Notify():
+			case <-time.After(bufferCheckInterval):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		if err != nil {
+			logF.WithError(err).Error("Can't read metrics from disk buffer")
+			if !sleepCtx(ctx, delay) {
+				return
+			}
+			continue
+		}
+
+		batch, err := types.DecodeBatch(data)
+		if err != nil {
+			logF.WithError(err).Error("Invalid entry has been removed from disk buffer")
+			_ = buf.queue.Discard(pos)
+			continue
+		}
+
+		// user-defined Publish can't be called concurrently with other requests for the same task
+		if !cm.waitForTask(ctx, id) {
+			return
+		}
+		err = cm.publishBuffered(id, pContext, batch)
+		cm.MarkTaskAsCompleted(id)
+
+		if err == nil {
+			_ = buf.queue.Ack(pos)
+			retries = 0
+			delay = cm.diskBuffer.retryInterval
+			continue
+		}
+
+		retries++
+		if cm.diskBuffer.maxRetries > 0 && retries >= cm.diskBuffer.maxRetries {
+			logF.WithError(err).WithField("retries", retries).Warning("Buffered metrics have been dropped after failed attempts to publish them")
+			_ = buf.queue.Discard(pos)
+			retries = 0
+			delay = cm.diskBuffer.retryInterval
+			continue
+		}
+
+		logF.WithError(err).WithField("retry-in", delay.String()).Info("Publishing buffered metrics failed")
+		if !sleepCtx(ctx, delay) {
+			return
+		}
+
+		delay *= 2
+		if delay > cm.diskBuffer.maxRetryInterval {
+			delay = cm.diskBuffer.maxRetryInterval
+		}
+	}
+}
+
+func (cm *ContextManager) updateBufferStat(id string, queue *diskqueue.Queue) {
+	cm.statsController.UpdateBufferStat(id, queue.Len(), queue.Size(), queue.Dropped())
+}
+
+// Wait for a given time, returns false if context was canceled in the meantime
+// Publish batch read from buffer (panic in user-defined code is treated as failed attempt)
+func (cm *ContextManager) publishBuffered(id string, pContext *PluginContext, batch types.Batch) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logF := log.WithField("task-id", id)
+			logF.WithError(fmt.Errorf("%v", r)).Error("user-defined function has ended with panic")
+			logF.WithField("block", "recover").Trace(string(debug.Stack()))
+			err = fmt.Errorf("user-defined function has ended with panic: %s", secrets.Redact(fmt.Sprintf("%v", r)))
+		}
+	}()
+
+	_, err = cm.publish(id, pContext, batch.Metrics, batch.Events)
+	return err
+}
+
+// Acquire task. When disk buffer is defined wait a while, since task may be held by drainer of buffer
+func (cm *ContextManager) acquireTask(id string) bool {
+	if cm.diskBuffer == nil {
+		return cm.AcquireTask(id)
+	}
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), bufferAcquireTimeout)
+	defer cancelFn()
+
+	return cm.waitForTask(ctx, id)
+}
+
+// Wait until task is acquired (false when ctx is done before)
+func (cm *ContextManager) waitForTask(ctx context.Context, id string) bool {
+	for !cm.AcquireTask(id) {
+		if !sleepCtx(ctx, taskAcquireInterval) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
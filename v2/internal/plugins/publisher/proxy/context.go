@@ -27,8 +27,10 @@ import (
 type PluginContext struct {
 	*proxy.Context
 
-	taskID     string
-	sessionMts []*types.Metric
+	taskID        string
+	sessionMts    []*types.Metric
+	sessionEvents []*types.Event
+	buffer        *taskBuffer // disk buffer (nil when not defined by plugin)
 }
 
 func NewPluginContext(ctxManager *ContextManager, taskID string, rawConfig []byte) (*PluginContext, error) {
@@ -61,6 +63,16 @@ func (pc *PluginContext) Count() int {
 	return len(pc.sessionMts)
 }
 
+func (pc *PluginContext) ListAllEvents() []plugin.Event {
+	events := make([]plugin.Event, 0, len(pc.sessionEvents))
+
+	for _, ev := range pc.sessionEvents {
+		events = append(events, ev)
+	}
+
+	return events
+}
+
 func (pc *PluginContext) TaskID() string {
 	return pc.taskID
 }
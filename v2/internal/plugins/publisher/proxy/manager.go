@@ -17,6 +17,7 @@
 package proxy
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,6 +27,7 @@ import (
 	"github.com/sirupsen/logrus"
 	commonProxy "github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/proxy"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
@@ -37,10 +39,14 @@ func init() {
 }
 
 type Publisher interface {
-	RequestPublish(id string, mts []*types.Metric) types.ProcessingStatus
+	RequestPublish(id string, mts []*types.Metric, events []*types.Event) types.ProcessingStatus
 	LoadTask(id string, config []byte) error
 	UnloadTask(id string) error
+	ReconfigureTask(id string, config []byte) error
 	CustomInfo(id string) ([]byte, error)
+	CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error)
+	CheckPluginHealth(ctx context.Context) types.HealthReport
+	Shutdown(ctx context.Context)
 }
 
 type ContextManager struct {
@@ -49,6 +55,8 @@ type ContextManager struct {
 	publisher  plugin.Publisher
 	contextMap sync.Map
 
+	diskBuffer *diskBufferConfig // durable buffer for metrics (nil - metrics are published synchronously)
+
 	statsController stats.Controller // reference to statistics controller
 }
 
@@ -69,8 +77,8 @@ func NewContextManager(publisher plugin.Publisher, statsController stats.Control
 ///////////////////////////////////////////////////////////////////////////////
 // proxy.Publisher related methods
 
-func (cm *ContextManager) RequestPublish(id string, mts []*types.Metric) types.ProcessingStatus {
-	if !cm.AcquireTask(id) {
+func (cm *ContextManager) RequestPublish(id string, mts []*types.Metric, events []*types.Event) types.ProcessingStatus {
+	if !cm.acquireTask(id) {
 		return types.ProcessingStatus{
 			Error: fmt.Errorf("can't process publish request, other request for the same id (%s) is in progress", id),
 		}
@@ -85,7 +93,20 @@ func (cm *ContextManager) RequestPublish(id string, mts []*types.Metric) types.P
 	}
 	context := contextIf.(*PluginContext)
 
-	context.sessionMts = mts // metrics to publish are set within context
+	if context.buffer != nil {
+		return cm.bufferMetrics(id, context, mts, events)
+	}
+
+	warnings, err := cm.publish(id, context, mts, events)
+	return types.ProcessingStatus{
+		Error:    err,
+		Warnings: warnings,
+	}
+}
+
+func (cm *ContextManager) publish(id string, context *PluginContext, mts []*types.Metric, events []*types.Event) ([]types.Warning, error) {
+	context.sessionMts = mts // metrics and events to publish are set within context
+	context.sessionEvents = events
 	context.ResetWarnings()
 
 	startTime := time.Now()
@@ -96,25 +117,21 @@ func (cm *ContextManager) RequestPublish(id string, mts []*types.Metric) types.P
 	cm.statsController.UpdateExecutionStat(id, len(context.sessionMts), err != nil, startTime, endTime)
 
 	if err != nil {
-		return types.ProcessingStatus{
-			Error:    fmt.Errorf("user-defined Publish method ended with error: %v", err),
-			Warnings: warnings,
-		}
+		return warnings, fmt.Errorf("user-defined Publish method ended with error: %v", err)
 	}
 
 	log.WithFields(logrus.Fields{
 		"elapsed":      endTime.Sub(startTime).String(),
 		"metrics-num":  len(mts),
+		"events-num":   len(events),
 		"warnings-num": len(warnings),
 	}).Debug("Publish completed")
 
-	return types.ProcessingStatus{
-		Warnings: warnings,
-	}
+	return warnings, nil
 }
 
-func (cm *ContextManager) LoadTask(id string, config []byte) error {
-	if !cm.AcquireTask(id) {
+func (cm *ContextManager) LoadTask(id string, config []byte) (err error) {
+	if !cm.acquireTask(id) {
 		return fmt.Errorf("can't process load request, other request for the same id (%s) is in progress", id)
 	}
 	defer cm.MarkTaskAsCompleted(id)
@@ -123,26 +140,133 @@ func (cm *ContextManager) LoadTask(id string, config []byte) error {
 		return errors.New("context with given id was already defined")
 	}
 
-	newCtx, err := NewPluginContext(cm, id, config)
+	validConfig, redactedConfig, err := cm.PrepareConfig(id, config)
 	if err != nil {
 		return fmt.Errorf("can't load task: %v", err)
 	}
 
+	// secrets of a task which couldn't be loaded shouldn't be kept (nor leaked via returned error)
+	defer func() {
+		if err != nil {
+			err = secrets.RedactError(err)
+			cm.ReleaseSecrets(id)
+		}
+	}()
+
+	newCtx, err := NewPluginContext(cm, id, validConfig)
+	if err != nil {
+		return fmt.Errorf("can't load task: %v", err)
+	}
+
+	cm.AttachPersistentState(newCtx.Context, id)
+	cm.AttachSharedStore(newCtx.Context)
+
+	if cm.diskBuffer != nil {
+		err := cm.openTaskBuffer(id, newCtx)
+		if err != nil {
+			return fmt.Errorf("can't load task: %v", err)
+		}
+	}
+
 	if loadable, ok := cm.publisher.(plugin.LoadablePublisher); ok {
 		err := loadable.Load(newCtx)
 		if err != nil {
+			if newCtx.buffer != nil {
+				newCtx.buffer.cancelFn()
+				_ = newCtx.buffer.queue.Close()
+			}
 			return fmt.Errorf("can't load task due to errors returned from user-defined function: %s", err)
 		}
 	}
 
 	cm.contextMap.Store(id, newCtx)
-	cm.statsController.UpdateLoadStat(id, string(config), nil)
+	cm.statsController.UpdateLoadStat(id, string(redactedConfig), nil)
+
+	if newCtx.buffer != nil {
+		go cm.drainTaskBuffer(id, newCtx)
+	}
+
+	return nil
+}
+
+// Apply new configuration of a loaded task. When publisher doesn't implement plugin.ReconfigurablePublisher,
+// task is unloaded and loaded again.
+func (cm *ContextManager) ReconfigureTask(id string, config []byte) (err error) {
+	reconfigurable, ok := cm.publisher.(plugin.ReconfigurablePublisher)
+	if !ok {
+		return cm.reloadTask(id, config)
+	}
+
+	if !cm.acquireTask(id) {
+		return fmt.Errorf("can't process reconfigure request, other request for the same id (%s) is in progress", id)
+	}
+	defer cm.MarkTaskAsCompleted(id)
+
+	contextI, ok := cm.contextMap.Load(id)
+	if !ok {
+		return errors.New("context with given id is not defined")
+	}
+	pContext := contextI.(*PluginContext)
+
+	validConfig, redactedConfig, completeFn, err := cm.PrepareNewConfig(id, config)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	applied := false
+	defer func() {
+		if err != nil {
+			err = secrets.RedactError(err)
+		}
+		completeFn(applied)
+	}()
+
+	newCtx, err := commonProxy.NewContext(validConfig)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	err = reconfigurable.Reconfigure(pContext, commonProxy.NewConfigChange(pContext.Context, newCtx))
+	if err != nil {
+		return fmt.Errorf("new configuration has been rejected by user-defined function: %s", err)
+	}
+
+	pContext.SwapConfig(newCtx)
+	applied = true
+
+	cm.statsController.UpdateReconfigureStat(id, string(redactedConfig), nil)
+
+	return nil
+}
+
+// Apply new configuration by unloading and loading task again (new configuration is validated before unload)
+func (cm *ContextManager) reloadTask(id string, config []byte) error {
+	validConfig, _, completeFn, err := cm.PrepareNewConfig(id, config)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	_, err = commonProxy.NewContext(validConfig)
+	completeFn(false)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", secrets.RedactError(err))
+	}
+
+	err = cm.UnloadTask(id)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task: %v", err)
+	}
+
+	err = cm.LoadTask(id, config)
+	if err != nil {
+		return fmt.Errorf("can't reconfigure task (task has been unloaded): %v", err)
+	}
 
 	return nil
 }
 
 func (cm *ContextManager) UnloadTask(id string) error {
-	if !cm.AcquireTask(id) {
+	if !cm.acquireTask(id) {
 		return fmt.Errorf("can't process unload request, other request for the same id (%s) is in progress", id)
 	}
 	defer cm.MarkTaskAsCompleted(id)
@@ -153,14 +277,28 @@ func (cm *ContextManager) UnloadTask(id string) error {
 	}
 
 	context := contextI.(*PluginContext)
+
+	hasBuffer := context.buffer != nil
+	err := cm.stopTaskBuffer(context)
+	if err != nil {
+		log.WithError(err).WithField("task-id", id).Warning("Disk buffer wasn't closed properly")
+	}
+
 	if unloadable, ok := cm.publisher.(plugin.UnloadablePublisher); ok {
 		err := unloadable.Unload(context)
 		if err != nil {
+			if hasBuffer { // task stays loaded, so metrics should still be buffered
+				errRestore := cm.restoreTaskBuffer(id, context)
+				if errRestore != nil {
+					log.WithError(errRestore).WithField("task-id", id).Error("Disk buffer couldn't be reopened")
+				}
+			}
 			return fmt.Errorf("error occured when trying to unload a publisher task (%s): %v", id, err)
 		}
 	}
 
 	cm.contextMap.Delete(id)
+	cm.ReleaseSecrets(id)
 	cm.statsController.UpdateUnloadStat(id)
 
 	return nil
@@ -189,6 +327,52 @@ func (cm *ContextManager) CustomInfo(id string) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// Run health checks defined by plugin (all tasks are checked when taskID is empty)
+func (cm *ContextManager) CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error) {
+	// Do not call cm.AcquireTask, health may be checked when other request is in progress
+
+	tasks := map[string]plugin.Context{}
+	cm.contextMap.Range(func(k, v interface{}) bool {
+		tasks[k.(string)] = v.(*PluginContext)
+		return true
+	})
+
+	return commonProxy.CheckHealth(ctx, cm.publisher, tasks, taskID)
+}
+
+// Run plugin-wide health check defined by plugin (used for liveness)
+func (cm *ContextManager) CheckPluginHealth(ctx context.Context) types.HealthReport {
+	return commonProxy.CheckPluginHealth(ctx, cm.publisher)
+}
+
+// Call plugin-wide initialization hook (when implemented). Used once, before plugin starts serving requests.
+func (cm *ContextManager) Init(ctx context.Context) error {
+	initializable, ok := cm.publisher.(plugin.InitializablePublisher)
+	if !ok {
+		return nil
+	}
+
+	return commonProxy.Initialize(func() error {
+		return initializable.Init(ctx, cm.Shared)
+	})
+}
+
+// Unload all tasks and call plugin-wide shutdown hook (when implemented). Used when plugin is being stopped.
+func (cm *ContextManager) Shutdown(ctx context.Context) {
+	ids := []string{}
+	cm.contextMap.Range(func(k, _ interface{}) bool {
+		ids = append(ids, k.(string))
+		return true
+	})
+
+	var shutdownFn func(ctx context.Context) error
+	if shutdownable, ok := cm.publisher.(plugin.ShutdownablePublisher); ok {
+		shutdownFn = shutdownable.Shutdown
+	}
+
+	commonProxy.Shutdown(ctx, log, ids, cm.UnloadTask, shutdownFn)
+}
+
 func (cm *ContextManager) RequestPluginDefinition() {
 	if definable, ok := cm.publisher.(plugin.DefinablePublisher); ok {
 		err := definable.PluginDefinition(cm)
@@ -18,12 +18,15 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -49,7 +52,14 @@ func (cs *collectService) Collect(request *pluginrpc.CollectRequest, stream plug
 	logF.Debug("GRPC Collect() received")
 	defer logF.Debug("GRPC Collect() completed")
 
-	chunksCh := cs.proxy.RequestCollect(taskID)
+	ctx, cancel := context.WithCancel(stream.Context())
+	chunksCh := cs.proxy.RequestCollect(ctx, taskID)
+	defer func() {
+		// collection is blocked until all chunks are received, so drain channel when stream can't be used anymore
+		for range chunksCh {
+		}
+	}()
+	defer cancel() // called before draining, so remaining chunks are dropped
 
 	for chunk := range chunksCh {
 		// try to send metrics first, even if there were errors during Collect or StreamingCollect
@@ -63,6 +73,14 @@ func (cs *collectService) Collect(request *pluginrpc.CollectRequest, stream plug
 			return fmt.Errorf("can't send all warnings to snap: %v", err)
 		}
 
+		err = cs.sendEvents(stream, chunk.Events)
+		if err != nil {
+			return fmt.Errorf("can't send all events to snap: %v", err)
+		}
+
+		if errors.Is(chunk.Err, types.ErrCollectTimeout) {
+			return status.Errorf(codes.DeadlineExceeded, "plugin didn't complete collecting metrics on time: %s", chunk.Err)
+		}
 		if chunk.Err != nil {
 			return fmt.Errorf("plugin errored while collecting metrics: %s", chunk.Err)
 		}
@@ -94,6 +112,19 @@ func (cs *collectService) Unload(ctx context.Context, request *pluginrpc.UnloadC
 	return &pluginrpc.UnloadCollectorResponse{}, cs.proxy.UnloadTask(taskID)
 }
 
+func (cs *collectService) Reconfigure(ctx context.Context, request *pluginrpc.ReconfigureCollectorRequest) (*pluginrpc.ReconfigureCollectorResponse, error) {
+	taskID := request.GetTaskId()
+	logF := cs.logger().WithField("task-id", taskID)
+
+	logF.Debug("GRPC Reconfigure() received")
+	defer logF.Debug("GRPC Reconfigure() completed")
+
+	jsonConfig := request.GetJsonConfig()
+	metrics := request.GetMetricSelectors()
+
+	return &pluginrpc.ReconfigureCollectorResponse{}, cs.proxy.ReconfigureTask(taskID, jsonConfig, metrics)
+}
+
 func (cs *collectService) Info(ctx context.Context, request *pluginrpc.InfoRequest) (*pluginrpc.InfoResponse, error) {
 	taskID := request.GetTaskId()
 	logF := cs.logger().WithField("task-id", taskID)
@@ -109,6 +140,45 @@ func (cs *collectService) Info(ctx context.Context, request *pluginrpc.InfoReque
 	return &pluginrpc.InfoResponse{Info: cInfo}, nil
 }
 
+func (cs *collectService) Describe(ctx context.Context, request *pluginrpc.DescribeRequest) (*pluginrpc.DescribeResponse, error) {
+	logF := cs.logger()
+
+	logF.Debug("GRPC Describe() received")
+	defer logF.Debug("GRPC Describe() completed")
+
+	catalog := cs.proxy.MetricsCatalog()
+
+	protoMetrics := make([]*pluginrpc.MetricDefinition, 0, len(catalog))
+	for _, mtDef := range catalog {
+		protoMetrics = append(protoMetrics, toGRPCMetricDefinition(mtDef))
+	}
+
+	return &pluginrpc.DescribeResponse{Metrics: protoMetrics}, nil
+}
+
+func (cs *collectService) sendEvents(stream pluginrpc.Collector_CollectServer, events []*types.Event) error {
+	logF := cs.logger()
+	protoEvents := make([]*pluginrpc.Event, 0, len(events))
+
+	for _, ev := range events {
+		protoEvents = append(protoEvents, toGRPCEvent(ev))
+	}
+
+	if len(events) != 0 {
+		err := stream.Send(&pluginrpc.CollectResponse{
+			Events: protoEvents,
+		})
+		if err != nil {
+			logF.WithError(err).Error("can't send events chunk over GRPC")
+			return err
+		}
+
+		logF.WithField("len", len(protoEvents)).Debug("events chunk has been sent to snap")
+	}
+
+	return nil
+}
+
 func (cs *collectService) sendWarnings(stream pluginrpc.Collector_CollectServer, warnings []types.Warning) error {
 	logF := cs.logger()
 	protoWarnings := make([]*pluginrpc.Warning, 0, len(warnings))
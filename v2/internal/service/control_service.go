@@ -42,13 +42,15 @@ type controlService struct {
 	pingCh chan struct{}   // notification about received ping
 	ctx    context.Context // check for a notification from top level code (service crash etc.)
 	errCh  chan error
+	health HealthChecker
 }
 
-func newControlService(ctx context.Context, errCh chan error, pingTimeout time.Duration, maxMissingPingCounter uint) *controlService {
+func newControlService(ctx context.Context, errCh chan error, health HealthChecker, pingTimeout time.Duration, maxMissingPingCounter uint) *controlService {
 	cs := &controlService{
 		pingCh: make(chan struct{}),
 		ctx:    ctx,
 		errCh:  errCh,
+		health: health,
 	}
 
 	go cs.monitor(pingTimeout, maxMissingPingCounter)
@@ -78,6 +80,18 @@ func (cs *controlService) Kill(ctx context.Context, _ *pluginrpc.KillRequest) (*
 	return &pluginrpc.KillResponse{}, nil
 }
 
+func (cs *controlService) Health(ctx context.Context, request *pluginrpc.HealthRequest) (*pluginrpc.HealthResponse, error) {
+	logF := cs.logger().WithFields(controlSrvFields).WithField("task-id", request.GetTaskId())
+	logF.Debug("GRPC Health() received")
+
+	report, err := cs.health.CheckHealth(ctx, request.GetTaskId())
+	if err != nil {
+		return nil, err
+	}
+
+	return toGRPCHealth(report), nil
+}
+
 func (cs *controlService) monitor(timeout time.Duration, maxPingMissed uint) {
 	pingMissed := uint(0)
 
@@ -46,7 +46,7 @@ func TestControlServiceMonitor_MissingPing(t *testing.T) {
 	doneTestCh := make(chan bool)
 
 	ctx, cancelFn := context.WithCancel(context.Background())
-	cs := newControlService(ctx, closeCh, 200*time.Millisecond, 3)
+	cs := newControlService(ctx, closeCh, nil, 200*time.Millisecond, 3)
 
 	go func() {
 		// ok
@@ -92,7 +92,7 @@ func TestControlServiceMonitor_MaxMissedPings(t *testing.T) {
 	doneTestCh := make(chan bool)
 
 	ctx, cancelFn := context.WithCancel(context.Background())
-	cs := newControlService(ctx, closeCh, 200*time.Millisecond, 3)
+	cs := newControlService(ctx, closeCh, nil, 200*time.Millisecond, 3)
 
 	go func() {
 		// ok
@@ -136,7 +136,7 @@ func TestControlServiceMonitor_ClosingInfiniteMonitor(t *testing.T) {
 	doneTestCh := make(chan bool)
 
 	ctx, cancelFn := context.WithCancel(context.Background())
-	cs := newControlService(ctx, closeCh, 0, 0)
+	cs := newControlService(ctx, closeCh, nil, 0, 0)
 
 	go func() {
 		time.Sleep(100 * time.Millisecond)
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
 )
 
@@ -38,6 +39,7 @@ func toGRPCMetric(mt *types.Metric) (*pluginrpc.Metric, error) {
 		Unit:        mt.Unit_,
 		Timestamp:   toGRPCTime(mt.Timestamp_),
 		Description: mt.Description_,
+		Kind:        pluginrpc.MetricKind(mt.Kind_), // values of both enums are equal
 	}
 
 	return protoMt, nil
@@ -61,6 +63,7 @@ func fromGRPCMetric(mt *pluginrpc.Metric) (types.Metric, error) {
 		Unit_:        mt.Unit,
 		Timestamp_:   fromGRPCTime(mt.Timestamp),
 		Description_: mt.Description,
+		Kind_:        plugin.MetricKind(mt.Kind),
 	}
 
 	return retMt, err
@@ -110,7 +113,7 @@ func fromGRPCTime(t *pluginrpc.Time) time.Time {
 func toGRPCValue(v interface{}) (*pluginrpc.MetricValue, error) {
 	grpcValue := &pluginrpc.MetricValue{}
 
-	// when adding new type(s) apply changes also in PluginContext.isValidValueType() function
+	// when adding new type(s) apply changes also in types.IsValidValueType() function
 	switch t := v.(type) {
 	case string:
 		grpcValue.DataVariant = &pluginrpc.MetricValue_VString{VString: t}
@@ -138,6 +141,14 @@ func toGRPCValue(v interface{}) (*pluginrpc.MetricValue, error) {
 		grpcValue.DataVariant = &pluginrpc.MetricValue_VInt16{VInt16: int32(t)}
 	case uint16:
 		grpcValue.DataVariant = &pluginrpc.MetricValue_VUint16{VUint16: uint32(t)}
+	case plugin.Histogram:
+		grpcValue.DataVariant = &pluginrpc.MetricValue_VHistogram{VHistogram: toGRPCHistogram(&t)}
+	case *plugin.Histogram:
+		grpcValue.DataVariant = &pluginrpc.MetricValue_VHistogram{VHistogram: toGRPCHistogram(t)}
+	case plugin.Summary:
+		grpcValue.DataVariant = &pluginrpc.MetricValue_VSummary{VSummary: toGRPCSummary(&t)}
+	case *plugin.Summary:
+		grpcValue.DataVariant = &pluginrpc.MetricValue_VSummary{VSummary: toGRPCSummary(t)}
 	case nil:
 		grpcValue.DataVariant = nil
 	default:
@@ -171,14 +182,140 @@ func fromGRPCValue(v *pluginrpc.MetricValue) (interface{}, error) {
 		return int16(v.GetVInt16()), nil
 	case *pluginrpc.MetricValue_VUint16:
 		return uint16(v.GetVUint16()), nil
+	case *pluginrpc.MetricValue_VHistogram:
+		return fromGRPCHistogram(v.GetVHistogram()), nil
+	case *pluginrpc.MetricValue_VSummary:
+		return fromGRPCSummary(v.GetVSummary()), nil
 	}
 
 	return nil, fmt.Errorf("unknown type of metric value: %T", v.DataVariant)
 }
 
+func toGRPCHistogram(h *plugin.Histogram) *pluginrpc.Histogram {
+	buckets := make([]*pluginrpc.HistogramBucket, 0, len(h.Buckets))
+	for _, b := range h.Buckets {
+		buckets = append(buckets, &pluginrpc.HistogramBucket{
+			UpperBound: b.UpperBound,
+			Count:      b.Count,
+		})
+	}
+
+	return &pluginrpc.Histogram{
+		Buckets: buckets,
+		Sum:     h.Sum,
+		Count:   h.Count,
+	}
+}
+
+func fromGRPCHistogram(h *pluginrpc.Histogram) plugin.Histogram {
+	buckets := make([]plugin.HistogramBucket, 0, len(h.GetBuckets()))
+	for _, b := range h.GetBuckets() {
+		buckets = append(buckets, plugin.HistogramBucket{
+			UpperBound: b.GetUpperBound(),
+			Count:      b.GetCount(),
+		})
+	}
+
+	return plugin.Histogram{
+		Buckets: buckets,
+		Sum:     h.GetSum(),
+		Count:   h.GetCount(),
+	}
+}
+
+func toGRPCSummary(s *plugin.Summary) *pluginrpc.Summary {
+	quantiles := make([]*pluginrpc.SummaryQuantile, 0, len(s.Quantiles))
+	for _, q := range s.Quantiles {
+		quantiles = append(quantiles, &pluginrpc.SummaryQuantile{
+			Quantile: q.Quantile,
+			Value:    q.Value,
+		})
+	}
+
+	return &pluginrpc.Summary{
+		Quantiles: quantiles,
+		Sum:       s.Sum,
+		Count:     s.Count,
+	}
+}
+
+func fromGRPCSummary(s *pluginrpc.Summary) plugin.Summary {
+	quantiles := make([]plugin.SummaryQuantile, 0, len(s.GetQuantiles()))
+	for _, q := range s.GetQuantiles() {
+		quantiles = append(quantiles, plugin.SummaryQuantile{
+			Quantile: q.GetQuantile(),
+			Value:    q.GetValue(),
+		})
+	}
+
+	return plugin.Summary{
+		Quantiles: quantiles,
+		Sum:       s.GetSum(),
+		Count:     s.GetCount(),
+	}
+}
+
 func toGRPCWarning(warning types.Warning) *pluginrpc.Warning {
 	return &pluginrpc.Warning{
 		Message:   warning.Message,
 		Timestamp: toGRPCTime(warning.Timestamp),
 	}
 }
+
+func toGRPCEvent(ev *types.Event) *pluginrpc.Event {
+	return &pluginrpc.Event{
+		Title:     ev.Title_,
+		Text:      ev.Text_,
+		Severity:  pluginrpc.EventSeverity(ev.Severity_), // values of both enums are equal
+		Tags:      ev.Tags_,
+		Timestamp: toGRPCTime(ev.Timestamp_),
+	}
+}
+
+func fromGRPCEvent(ev *pluginrpc.Event) *types.Event {
+	return &types.Event{
+		Title_:     ev.Title,
+		Text_:      ev.Text,
+		Severity_:  plugin.EventSeverity(ev.Severity),
+		Tags_:      ev.Tags,
+		Timestamp_: fromGRPCTime(ev.Timestamp),
+	}
+}
+
+func toGRPCHealth(report types.HealthReport) *pluginrpc.HealthResponse {
+	protoTasks := make([]*pluginrpc.TaskHealth, 0, len(report.Tasks))
+	for _, th := range report.Tasks {
+		protoTasks = append(protoTasks, &pluginrpc.TaskHealth{
+			TaskId:  th.TaskID,
+			Status:  pluginrpc.HealthStatus(th.Status), // values of both enums are equal
+			Details: th.Details,
+		})
+	}
+
+	return &pluginrpc.HealthResponse{
+		Status:       pluginrpc.HealthStatus(report.Status),
+		PluginStatus: pluginrpc.HealthStatus(report.PluginStatus),
+		Details:      report.Details,
+		Tasks:        protoTasks,
+	}
+}
+
+func toGRPCMetricDefinition(mtDef types.MetricDefinition) *pluginrpc.MetricDefinition {
+	protoGroups := make([]*pluginrpc.MetricGroup, 0, len(mtDef.Groups))
+	for _, g := range mtDef.Groups {
+		protoGroups = append(protoGroups, &pluginrpc.MetricGroup{
+			Name:        g.Name,
+			Description: g.Description,
+			Position:    int32(g.Position),
+		})
+	}
+
+	return &pluginrpc.MetricDefinition{
+		Namespace:   mtDef.Namespace,
+		Groups:      protoGroups,
+		Description: mtDef.Description,
+		Unit:        mtDef.Unit,
+		IsDefault:   mtDef.IsDefault,
+		Kind:        pluginrpc.MetricKind(mtDef.Kind), // values of both enums are equal
+	}
+}
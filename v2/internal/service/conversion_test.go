@@ -0,0 +1,96 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package service
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
+)
+
+func TestDistributionValueConversion(t *testing.T) {
+	Convey("Validate that histogram and summary are converted to GRPC structures and back", t, func() {
+		histogram := plugin.Histogram{
+			Buckets: []plugin.HistogramBucket{
+				{UpperBound: 0.1, Count: 3},
+				{UpperBound: 0.5, Count: 8},
+				{UpperBound: math.Inf(1), Count: 10},
+			},
+			Sum:   2.75,
+			Count: 10,
+		}
+
+		summary := plugin.Summary{
+			Quantiles: []plugin.SummaryQuantile{
+				{Quantile: 0.5, Value: 0.12},
+				{Quantile: 0.99, Value: 0.87},
+			},
+			Sum:   3.5,
+			Count: 20,
+		}
+
+		Convey("Values", func() {
+			grpcHistogram, err := toGRPCValue(histogram)
+			So(err, ShouldBeNil)
+			So(grpcHistogram.GetVHistogram().Buckets, ShouldHaveLength, 3)
+
+			v, err := fromGRPCValue(grpcHistogram)
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, histogram)
+
+			grpcSummary, err := toGRPCValue(summary)
+			So(err, ShouldBeNil)
+			So(grpcSummary.GetVSummary().Quantiles, ShouldHaveLength, 2)
+
+			v, err = fromGRPCValue(grpcSummary)
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, summary)
+		})
+
+		Convey("Pointers", func() {
+			grpcHistogram, err := toGRPCValue(&histogram)
+			So(err, ShouldBeNil)
+
+			v, err := fromGRPCValue(grpcHistogram)
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, histogram)
+
+			grpcSummary, err := toGRPCValue(&summary)
+			So(err, ShouldBeNil)
+
+			v, err = fromGRPCValue(grpcSummary)
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, summary)
+		})
+
+		Convey("Missing (nil) values", func() {
+			v, err := fromGRPCValue(&pluginrpc.MetricValue{DataVariant: &pluginrpc.MetricValue_VHistogram{}})
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, plugin.Histogram{Buckets: []plugin.HistogramBucket{}})
+
+			v, err = fromGRPCValue(&pluginrpc.MetricValue{DataVariant: &pluginrpc.MetricValue_VSummary{}})
+			So(err, ShouldBeNil)
+			So(v, ShouldResemble, plugin.Summary{Quantiles: []plugin.SummaryQuantile{}})
+		})
+	})
+}
@@ -0,0 +1,101 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const healthWatchInterval = 5 * time.Second // how often health is checked for Watch requests
+
+// Implementation of standard gRPC health service (https://github.com/grpc/grpc/blob/master/doc/health-checking.md).
+// Empty service name refers to plugin (and all tasks), otherwise service name is interpreted as task id.
+type healthService struct {
+	ctx    context.Context
+	health HealthChecker
+}
+
+func newHealthService(ctx context.Context, health HealthChecker) *healthService {
+	return &healthService{
+		ctx:    ctx,
+		health: health,
+	}
+}
+
+func (hs *healthService) Check(ctx context.Context, request *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	hs.logger().WithField("service", request.GetService()).Debug("GRPC health Check() received")
+
+	servingStatus, err := hs.servingStatus(ctx, request.GetService())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+func (hs *healthService) Watch(request *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	hs.logger().WithField("service", request.GetService()).Debug("GRPC health Watch() received")
+
+	lastStatus := grpc_health_v1.HealthCheckResponse_UNKNOWN
+
+	for {
+		servingStatus, err := hs.servingStatus(stream.Context(), request.GetService())
+		if err != nil {
+			servingStatus = grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+		}
+
+		if servingStatus != lastStatus {
+			err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: servingStatus})
+			if err != nil {
+				return err
+			}
+			lastStatus = servingStatus
+		}
+
+		select {
+		case <-time.After(healthWatchInterval):
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case <-hs.ctx.Done():
+			return status.Error(codes.Unavailable, "plugin is shutting down")
+		}
+	}
+}
+
+func (hs *healthService) servingStatus(ctx context.Context, taskID string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	report, err := hs.health.CheckHealth(ctx, taskID)
+	if err != nil {
+		return grpc_health_v1.HealthCheckResponse_UNKNOWN, err
+	}
+
+	if report.Status == plugin.HealthStatusUnhealthy {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil
+	}
+
+	return grpc_health_v1.HealthCheckResponse_SERVING, nil
+}
+
+func (hs *healthService) logger() logrus.FieldLogger {
+	return log.WithCtx(hs.ctx).WithFields(moduleFields).WithField("service", "Health")
+}
@@ -0,0 +1,185 @@
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
+)
+
+const (
+	maxProcessChunkSize = 100
+)
+
+type processingService struct {
+	proxy ProcessorProxy
+	ctx   context.Context
+}
+
+func newProcessingService(ctx context.Context, proxy ProcessorProxy) pluginrpc.ProcessorServer {
+	return &processingService{
+		proxy: proxy,
+		ctx:   ctx,
+	}
+}
+
+func (ps *processingService) Process(stream pluginrpc.Processor_ProcessServer) error {
+	logF := ps.logger()
+	logF.Debug("GRPC Process() received")
+	defer logF.Debug("GRPC Process() completed")
+
+	id := ""
+	mts := []*types.Metric{}
+
+	for {
+		processPartialReq, err := stream.Recv()
+		if err != nil {
+			if err == io.EOF { // OK, expected end of stream
+				break
+			}
+
+			return fmt.Errorf("failure when reading from process stream: %s", err.Error())
+		}
+
+		logF.WithField("length", len(processPartialReq.MetricSet)).Debug("Metrics chunk received from snap")
+
+		id = processPartialReq.TaskId
+
+		for _, protoMt := range processPartialReq.MetricSet {
+			mt, err := fromGRPCMetric(protoMt)
+			if err != nil {
+				logF.WithError(err).Error("can't read metric from GRPC stream")
+				continue
+			}
+			mts = append(mts, &mt)
+		}
+	}
+
+	if len(mts) == 0 {
+		logF.Info("nothing to process, request will be ignored")
+		return nil
+	}
+
+	logF.WithField("length", len(mts)).Debug("metric will be processed")
+
+	processedMts, status := ps.proxy.RequestProcess(id, mts)
+
+	// try to send metrics first, even if there were errors during Process
+	err := ps.sendMetrics(stream, processedMts)
+	if err != nil {
+		return fmt.Errorf("can't send all metrics to snap: %v", err)
+	}
+
+	err = ps.sendWarnings(stream, status.Warnings)
+	if err != nil {
+		return fmt.Errorf("can't send all warnings to snap: %v", err)
+	}
+
+	return status.Error
+}
+
+func (ps *processingService) Load(ctx context.Context, request *pluginrpc.LoadProcessorRequest) (*pluginrpc.LoadProcessorResponse, error) {
+	ps.logger().Debug("GRPC Load() received")
+
+	taskID := request.GetTaskId()
+	jsonConfig := request.GetJsonConfig()
+
+	return &pluginrpc.LoadProcessorResponse{}, ps.proxy.LoadTask(taskID, jsonConfig)
+}
+
+func (ps *processingService) Unload(ctx context.Context, request *pluginrpc.UnloadProcessorRequest) (*pluginrpc.UnloadProcessorResponse, error) {
+	ps.logger().Debug("GRPC Unload() received")
+
+	taskID := request.GetTaskId()
+
+	return &pluginrpc.UnloadProcessorResponse{}, ps.proxy.UnloadTask(taskID)
+}
+
+func (ps *processingService) Info(ctx context.Context, request *pluginrpc.InfoRequest) (*pluginrpc.InfoResponse, error) {
+	ps.logger().Debug("GRPC Info() received")
+
+	taskID := request.GetTaskId()
+
+	cInfo, err := ps.proxy.CustomInfo(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pluginrpc.InfoResponse{Info: cInfo}, nil
+}
+
+func (ps *processingService) sendWarnings(stream pluginrpc.Processor_ProcessServer, warnings []types.Warning) error {
+	logF := ps.logger()
+	protoWarnings := make([]*pluginrpc.Warning, 0, len(warnings))
+
+	for _, warn := range warnings {
+		protoWarnings = append(protoWarnings, toGRPCWarning(warn))
+	}
+
+	if len(warnings) != 0 {
+		err := stream.Send(&pluginrpc.ProcessResponse{
+			Warnings: protoWarnings,
+		})
+		if err != nil {
+			logF.WithError(err).Error("can't send warnings chunk over GRPC")
+			return err
+		}
+
+		logF.WithField("len", len(protoWarnings)).Debug("warnings chunk has been sent to snap")
+	}
+
+	return nil
+}
+
+func (ps *processingService) sendMetrics(stream pluginrpc.Processor_ProcessServer, pluginMts []*types.Metric) error {
+	logF := ps.logger()
+
+	protoMts := make([]*pluginrpc.Metric, 0, maxProcessChunkSize)
+	for i, pluginMt := range pluginMts {
+		protoMt, err := toGRPCMetric(pluginMt)
+		if err != nil {
+			logF.WithError(err).WithField("metric", pluginMt.Namespace).Errorf("can't send metric over GRPC")
+		} else {
+			protoMts = append(protoMts, protoMt)
+		}
+
+		if len(protoMts) == maxProcessChunkSize || i == len(pluginMts)-1 {
+			err = stream.Send(&pluginrpc.ProcessResponse{
+				MetricSet: protoMts,
+			})
+			if err != nil {
+				logF.WithError(err).Error("can't send metrics chunk over GRPC")
+				return err
+			}
+
+			logF.WithField("len", len(protoMts)).Debug("metrics chunk has been sent to snap")
+			protoMts = make([]*pluginrpc.Metric, 0, maxProcessChunkSize)
+		}
+	}
+
+	return nil
+}
+
+func (ps *processingService) logger() logrus.FieldLogger {
+	return log.WithCtx(ps.ctx).WithFields(moduleFields).WithField("service", "Process")
+}
@@ -16,17 +16,47 @@
 
 package service
 
-import "github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+import (
+	"context"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+)
+
+type HealthChecker interface {
+	CheckHealth(ctx context.Context, taskID string) (types.HealthReport, error)
+	CheckPluginHealth(ctx context.Context) types.HealthReport // plugin-wide check only
+}
+
+// Shutdowner stops plugin-side processing when plugin is being stopped
+type Shutdowner interface {
+	ReleaseAllTasks()
+	Shutdown(ctx context.Context)
+}
 
 type CollectorProxy interface {
-	RequestCollect(id string) <-chan types.CollectChunk
+	RequestCollect(ctx context.Context, id string) <-chan types.CollectChunk
 	LoadTask(id string, rawConfig []byte, mtsSelectors []string) error
 	UnloadTask(id string) error
+	ReconfigureTask(id string, rawConfig []byte, mtsSelectors []string) error
 	CustomInfo(id string) ([]byte, error)
+	MetricsCatalog() []types.MetricDefinition
+	HealthChecker
+	Shutdowner
 }
 type PublisherProxy interface {
-	RequestPublish(id string, mts []*types.Metric) types.ProcessingStatus
+	RequestPublish(id string, mts []*types.Metric, events []*types.Event) types.ProcessingStatus
+	LoadTask(id string, config []byte) error
+	UnloadTask(id string) error
+	ReconfigureTask(id string, config []byte) error
+	CustomInfo(id string) ([]byte, error)
+	HealthChecker
+	Shutdowner
+}
+type ProcessorProxy interface {
+	RequestProcess(id string, mts []*types.Metric) ([]*types.Metric, types.ProcessingStatus)
 	LoadTask(id string, config []byte) error
 	UnloadTask(id string) error
 	CustomInfo(id string) ([]byte, error)
+	HealthChecker
+	Shutdowner
 }
@@ -46,6 +46,7 @@ func (ps *publishingService) Publish(stream pluginrpc.Publisher_PublishServer) e
 
 	id := ""
 	mts := []*types.Metric{}
+	events := []*types.Event{}
 	response := &pluginrpc.PublishResponse{}
 
 	for {
@@ -70,12 +71,19 @@ func (ps *publishingService) Publish(stream pluginrpc.Publisher_PublishServer) e
 			}
 			mts = append(mts, &mt)
 		}
+
+		for _, protoEv := range publishPartialReq.Events {
+			events = append(events, fromGRPCEvent(protoEv))
+		}
 	}
 
-	if len(mts) != 0 {
-		logF.WithField("length", len(mts)).Debug("metric will be published")
+	if len(mts) != 0 || len(events) != 0 {
+		logF.WithFields(logrus.Fields{
+			"length":        len(mts),
+			"events-length": len(events),
+		}).Debug("metric will be published")
 
-		status := ps.proxy.RequestPublish(id, mts)
+		status := ps.proxy.RequestPublish(id, mts, events)
 
 		protoWarnings := make([]*pluginrpc.Warning, 0, len(status.Warnings))
 		for _, w := range status.Warnings {
@@ -112,6 +120,15 @@ func (ps *publishingService) Unload(ctx context.Context, request *pluginrpc.Unlo
 	return &pluginrpc.UnloadPublisherResponse{}, ps.proxy.UnloadTask(taskID)
 }
 
+func (ps *publishingService) Reconfigure(ctx context.Context, request *pluginrpc.ReconfigurePublisherRequest) (*pluginrpc.ReconfigurePublisherResponse, error) {
+	ps.logger().Debug("GRPC Reconfigure() received")
+
+	taskID := request.GetTaskId()
+	jsonConfig := request.GetJsonConfig()
+
+	return &pluginrpc.ReconfigurePublisherResponse{}, ps.proxy.ReconfigureTask(taskID, jsonConfig)
+}
+
 func (ps *publishingService) Info(ctx context.Context, request *pluginrpc.InfoRequest) (*pluginrpc.InfoResponse, error) {
 	ps.logger().Debug("GRPC Info() received")
 
@@ -26,6 +26,9 @@ package service
 import (
 	"context"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -34,9 +37,13 @@ import (
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
-const GRPCGracefulStopTimeout = 10 * time.Second
+const (
+	GRPCGracefulStopTimeout = 10 * time.Second
+	DefaultShutdownTimeout  = GRPCGracefulStopTimeout
+)
 
 var moduleFields = logrus.Fields{"layer": "lib", "module": "plugin-rpc"}
 
@@ -70,22 +77,33 @@ func NewGRPCServer(ctx context.Context, opt *plugin.Options) (Server, error) {
 	return grpc.NewServer(grpc.Creds(tlsCreds)), nil
 }
 
-func StartCollectorGRPC(ctx context.Context, srv Server, proxy CollectorProxy, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint) {
+func StartCollectorGRPC(ctx context.Context, srv Server, proxy CollectorProxy, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint, shutdownTimeout time.Duration) {
 	pluginrpc.RegisterHandlerCollector(srv, newCollectService(ctx, proxy))
-	startGRPC(ctx, srv, grpcLn, pingTimeout, pingMaxMissedCount)
+	startGRPC(ctx, srv, proxy, grpcLn, pingTimeout, pingMaxMissedCount, shutdownTimeout)
 }
 
-func StartPublisherGRPC(ctx context.Context, srv Server, proxy PublisherProxy, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint) {
+func StartPublisherGRPC(ctx context.Context, srv Server, proxy PublisherProxy, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint, shutdownTimeout time.Duration) {
 	pluginrpc.RegisterHandlerPublisher(srv, newPublishingService(ctx, proxy))
-	startGRPC(ctx, srv, grpcLn, pingTimeout, pingMaxMissedCount)
+	startGRPC(ctx, srv, proxy, grpcLn, pingTimeout, pingMaxMissedCount, shutdownTimeout)
+}
+
+func StartProcessorGRPC(ctx context.Context, srv Server, proxy ProcessorProxy, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint, shutdownTimeout time.Duration) {
+	pluginrpc.RegisterHandlerProcessor(srv, newProcessingService(ctx, proxy))
+	startGRPC(ctx, srv, proxy, grpcLn, pingTimeout, pingMaxMissedCount, shutdownTimeout)
 }
 
-func startGRPC(ctx context.Context, srv Server, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint) {
+type pluginProxy interface {
+	HealthChecker
+	Shutdowner
+}
+
+func startGRPC(ctx context.Context, srv Server, proxy pluginProxy, grpcLn net.Listener, pingTimeout time.Duration, pingMaxMissedCount uint, shutdownTimeout time.Duration) {
 	logF := log.WithCtx(ctx).WithFields(moduleFields)
 	errChan := make(chan error)
 
 	csCtx, cancelFn := context.WithCancel(ctx)
-	pluginrpc.RegisterHandlerController(srv, newControlService(csCtx, errChan, pingTimeout, pingMaxMissedCount))
+	pluginrpc.RegisterHandlerController(srv, newControlService(csCtx, errChan, proxy, pingTimeout, pingMaxMissedCount))
+	grpc_health_v1.RegisterHealthServer(srv, newHealthService(csCtx, proxy))
 
 	go func() {
 		err := srv.Serve(grpcLn) // may be blocking (depending on implementation)
@@ -94,18 +112,42 @@ func startGRPC(ctx context.Context, srv Server, grpcLn net.Listener, pingTimeout
 		}
 	}()
 
-	err := <-errChan // may be blocking (depending on implementation)
-	cancelFn()       // signal ping monitor (via ctx)
+	// Plugin running as a thread shares process with snap, signals are handled by the host
+	sigCh := make(chan os.Signal, 1)
+	if _, asThread := srv.(*Channel); !asThread {
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(sigCh)
+	}
 
-	if err != nil && err != RequestedKillError {
-		logF.WithError(err).Errorf("Major error occurred - plugin will be shut down")
+	select {
+	case err := <-errChan: // may be blocking (depending on implementation)
+		if err != nil && err != RequestedKillError {
+			logF.WithError(err).Errorf("Major error occurred - plugin will be shut down")
+		}
+	case sig := <-sigCh:
+		logF.WithField("signal", sig.String()).Info("Signal received - plugin will be shut down")
+	case <-ctx.Done():
+		logF.Debug("Plugin context canceled - plugin will be shut down")
 	}
+	cancelFn() // signal ping monitor (via ctx)
 
-	shutdownPlugin(ctx, srv)
+	shutdownPlugin(ctx, srv, proxy, shutdownTimeout)
 }
 
-func shutdownPlugin(ctx context.Context, srv Server) {
+// Stop plugin in orderly manner within timeout (0 - default):
+// cancel requests in progress (streams send last chunk), stop GRPC server, unload tasks and call plugin shutdown hook
+func shutdownPlugin(ctx context.Context, srv Server, proxy Shutdowner, timeout time.Duration) {
 	logF := log.WithCtx(ctx).WithFields(moduleFields)
+
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	shutdownCtx, cancelFn := context.WithTimeout(context.Background(), timeout)
+	defer cancelFn()
+
+	proxy.ReleaseAllTasks()
+
 	stopped := make(chan bool, 1)
 
 	// try to complete all remaining rpc calls
@@ -118,8 +160,11 @@ func shutdownPlugin(ctx context.Context, srv Server) {
 	select {
 	case <-stopped:
 		logF.Debug("GRPC server stopped gracefully")
-	case <-time.After(GRPCGracefulStopTimeout):
+	case <-shutdownCtx.Done():
 		srv.Stop()
 		logF.Warn("GRPC server couldn't have been stopped gracefully. Some metrics might have been lost")
 	}
+
+	proxy.Shutdown(shutdownCtx)
+	logF.Debug("Plugin has been shut down")
 }
@@ -50,6 +50,10 @@ func (c *controlMock) Kill(ctx context.Context, request *pluginrpc.KillRequest)
 	return &pluginrpc.KillResponse{}, nil
 }
 
+func (c *controlMock) Health(ctx context.Context, request *pluginrpc.HealthRequest) (*pluginrpc.HealthResponse, error) {
+	return &pluginrpc.HealthResponse{}, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 const (
@@ -0,0 +1,243 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package configschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+// Export declared fields as JSON Schema (draft-07)
+func (s *Schema) JSONSchema() ([]byte, error) {
+	root := newJSONSchemaObject()
+	root["$schema"] = jsonSchemaVersion
+
+	for _, f := range s.fields {
+		pathElems := strings.Split(f.Path, pathSeparator)
+
+		parent := root
+		for _, el := range pathElems[:len(pathElems)-1] {
+			props := parent["properties"].(map[string]interface{})
+			if _, ok := props[el]; !ok {
+				props[el] = newJSONSchemaObject()
+			}
+
+			if f.Required {
+				appendRequired(parent, el)
+			}
+
+			parent = props[el].(map[string]interface{})
+		}
+
+		key := pathElems[len(pathElems)-1]
+		parent["properties"].(map[string]interface{})[key] = fieldJSONSchema(f)
+
+		if f.Required {
+			appendRequired(parent, key)
+		}
+	}
+
+	return json.MarshalIndent(root, "", "    ")
+}
+
+func newJSONSchemaObject() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+func appendRequired(obj map[string]interface{}, key string) {
+	required, _ := obj["required"].([]string)
+	for _, r := range required {
+		if r == key {
+			return
+		}
+	}
+
+	obj["required"] = append(required, key)
+}
+
+func fieldJSONSchema(f *Field) map[string]interface{} {
+	fs := map[string]interface{}{}
+
+	switch f.Type {
+	case plugin.ConfigTypeString, plugin.ConfigTypeDuration:
+		fs["type"] = "string"
+	case plugin.ConfigTypeInt:
+		fs["type"] = "integer"
+	case plugin.ConfigTypeFloat:
+		fs["type"] = "number"
+	case plugin.ConfigTypeBool:
+		fs["type"] = "boolean"
+	case plugin.ConfigTypeStringList:
+		fs["type"] = "array"
+	}
+
+	// constraints of string list are applied to each element
+	constraints := fs
+	if f.Type == plugin.ConfigTypeStringList {
+		constraints = map[string]interface{}{"type": "string"}
+		fs["items"] = constraints
+	}
+
+	if f.Description != "" {
+		fs["description"] = f.Description
+	}
+	if f.Default != nil {
+		fs["default"] = jsonValue(f.Default)
+	}
+	if f.Secret {
+		fs["writeOnly"] = true
+	}
+
+	// JSON Schema doesn't support limits for strings (durations are represented as strings, ie. "10s")
+	if f.Type != plugin.ConfigTypeDuration {
+		if f.Min != nil {
+			fs["minimum"] = f.Min
+		}
+		if f.Max != nil {
+			fs["maximum"] = f.Max
+		}
+	}
+
+	if len(f.AllowedValues) != 0 {
+		enum := make([]interface{}, 0, len(f.AllowedValues))
+		for _, v := range f.AllowedValues {
+			enum = append(enum, jsonValue(v))
+		}
+		constraints["enum"] = enum
+	}
+	if f.Pattern != nil {
+		constraints["pattern"] = f.Pattern.String()
+	}
+
+	return fs
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Build example configuration (YAML) based on declared fields.
+// Default values are used when available, otherwise field is filled with a value representing its type.
+func (s *Schema) ExampleConfig() (*yaml.Node, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+
+	for _, f := range s.fields {
+		pathElems := strings.Split(f.Path, pathSeparator)
+
+		parent := root
+		for _, el := range pathElems[:len(pathElems)-1] {
+			child := findMappingValue(parent, el)
+			if child == nil {
+				child = &yaml.Node{Kind: yaml.MappingNode}
+				parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: el}, child)
+			}
+
+			parent = child
+		}
+
+		keyNode := &yaml.Node{
+			Kind:        yaml.ScalarNode,
+			Value:       pathElems[len(pathElems)-1],
+			HeadComment: f.Description,
+		}
+
+		valueNode := &yaml.Node{}
+		err := valueNode.Encode(exampleValue(f))
+		if err != nil {
+			return nil, fmt.Errorf("can't build example value for field %s: %v", f.Path, err)
+		}
+		valueNode.LineComment = fieldSummary(f)
+
+		parent.Content = append(parent.Content, keyNode, valueNode)
+	}
+
+	return root, nil
+}
+
+func findMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func exampleValue(f *Field) interface{} {
+	if f.Default != nil {
+		return jsonValue(f.Default)
+	}
+
+	if len(f.AllowedValues) != 0 {
+		if f.Type == plugin.ConfigTypeStringList {
+			return []interface{}{f.AllowedValues[0]}
+		}
+		return jsonValue(f.AllowedValues[0])
+	}
+
+	if f.Min != nil {
+		return jsonValue(f.Min)
+	}
+
+	switch f.Type {
+	case plugin.ConfigTypeInt:
+		return 0
+	case plugin.ConfigTypeFloat:
+		return 0.0
+	case plugin.ConfigTypeBool:
+		return false
+	case plugin.ConfigTypeDuration:
+		return time.Duration(0).String()
+	case plugin.ConfigTypeStringList:
+		return []string{}
+	default:
+		return ""
+	}
+}
+
+// Short description of field constraints (ie. "int, required, min: 1, max: 10")
+func fieldSummary(f *Field) string {
+	summary := []string{f.Type.String()}
+
+	if f.Required {
+		summary = append(summary, "required")
+	}
+	if f.Secret {
+		summary = append(summary, "secret")
+	}
+	if f.Min != nil {
+		summary = append(summary, fmt.Sprintf("min: %s", formatValue(f.Min)))
+	}
+	if f.Max != nil {
+		summary = append(summary, fmt.Sprintf("max: %s", formatValue(f.Max)))
+	}
+	if len(f.AllowedValues) != 0 {
+		summary = append(summary, fmt.Sprintf("one of: %s", formatValues(f.AllowedValues)))
+	}
+	if f.Pattern != nil {
+		summary = append(summary, fmt.Sprintf("pattern: %s", f.Pattern.String()))
+	}
+
+	return strings.Join(summary, ", ")
+}
@@ -0,0 +1,366 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package configschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+// Field represents single typed configuration field (declared by plugin with DefineConfigField)
+type Field struct {
+	Path          string
+	Type          plugin.ConfigFieldType
+	Required      bool
+	Default       interface{} // normalized value (see normalize())
+	Min           interface{} // normalized value (see normalize())
+	Max           interface{} // normalized value (see normalize())
+	AllowedValues []interface{}
+	Pattern       *regexp.Regexp
+	Secret        bool
+	Description   string
+
+	errs []error // errors raised by modifiers
+}
+
+func newField(path string, typ plugin.ConfigFieldType) *Field {
+	return &Field{
+		Path: path,
+		Type: typ,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// plugin.ConfigFieldSetter related methods
+
+func (f *Field) SetRequired(required bool) {
+	f.Required = required
+}
+
+func (f *Field) SetDefault(value interface{}) {
+	v, err := normalize(f.Type, value)
+	if err != nil {
+		f.errs = append(f.errs, fmt.Errorf("invalid default value: %v", err))
+		return
+	}
+
+	f.Default = v
+}
+
+func (f *Field) SetMin(value interface{}) {
+	v, err := f.normalizeLimit(value)
+	if err != nil {
+		f.errs = append(f.errs, fmt.Errorf("invalid minimal value: %v", err))
+		return
+	}
+
+	f.Min = v
+}
+
+func (f *Field) SetMax(value interface{}) {
+	v, err := f.normalizeLimit(value)
+	if err != nil {
+		f.errs = append(f.errs, fmt.Errorf("invalid maximal value: %v", err))
+		return
+	}
+
+	f.Max = v
+}
+
+func (f *Field) SetAllowedValues(values []interface{}) {
+	f.AllowedValues = nil
+
+	elemType := f.Type
+	if f.Type == plugin.ConfigTypeStringList {
+		elemType = plugin.ConfigTypeString // allowed values are checked against each element of list
+	}
+
+	for _, value := range values {
+		v, err := normalize(elemType, value)
+		if err != nil {
+			f.errs = append(f.errs, fmt.Errorf("invalid allowed value: %v", err))
+			continue
+		}
+
+		f.AllowedValues = append(f.AllowedValues, v)
+	}
+}
+
+func (f *Field) SetPattern(regex string) {
+	if f.Type != plugin.ConfigTypeString && f.Type != plugin.ConfigTypeStringList {
+		f.errs = append(f.errs, fmt.Errorf("pattern can be used only for string fields"))
+		return
+	}
+
+	r, err := regexp.Compile(regex)
+	if err != nil {
+		f.errs = append(f.errs, fmt.Errorf("invalid pattern: %v", err))
+		return
+	}
+
+	f.Pattern = r
+}
+
+func (f *Field) SetSecret(secret bool) {
+	f.Secret = secret
+}
+
+func (f *Field) SetDescription(description string) {
+	f.Description = description
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Validate normalized value against field constraints
+func (f *Field) validate(v interface{}) error {
+	if f.Min != nil && numericView(v) < numericView(f.Min) {
+		return fmt.Errorf("value %s is lower than minimum (%s)", formatValue(v), formatValue(f.Min))
+	}
+
+	if f.Max != nil && numericView(v) > numericView(f.Max) {
+		return fmt.Errorf("value %s is greater than maximum (%s)", formatValue(v), formatValue(f.Max))
+	}
+
+	elems := []interface{}{v}
+	if list, ok := v.([]string); ok {
+		elems = nil
+		for _, el := range list {
+			elems = append(elems, el)
+		}
+	}
+
+	for _, el := range elems {
+		if len(f.AllowedValues) > 0 && !f.isAllowed(el) {
+			return fmt.Errorf("value %s is not one of allowed values (%s)", formatValue(el), formatValues(f.AllowedValues))
+		}
+
+		if f.Pattern != nil && !f.Pattern.MatchString(el.(string)) {
+			return fmt.Errorf("value %s doesn't match pattern (%s)", formatValue(el), f.Pattern.String())
+		}
+	}
+
+	return nil
+}
+
+func (f *Field) isAllowed(v interface{}) bool {
+	for _, allowed := range f.AllowedValues {
+		if allowed == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *Field) normalizeLimit(value interface{}) (interface{}, error) {
+	switch f.Type {
+	case plugin.ConfigTypeInt, plugin.ConfigTypeFloat, plugin.ConfigTypeDuration:
+		return normalize(f.Type, value)
+	default:
+		return nil, fmt.Errorf("limits can be used only for numeric and duration fields")
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Convert value to one of the representations (depending on field type):
+// string, int64, float64, bool, time.Duration, []string
+func normalize(typ plugin.ConfigFieldType, value interface{}) (interface{}, error) {
+	switch typ {
+	case plugin.ConfigTypeString:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case plugin.ConfigTypeInt:
+		if i, ok := toInt(value); ok {
+			return i, nil
+		}
+	case plugin.ConfigTypeFloat:
+		if f, ok := toFloat(value); ok {
+			return f, nil
+		}
+	case plugin.ConfigTypeBool:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	case plugin.ConfigTypeDuration:
+		switch v := value.(type) {
+		case time.Duration:
+			return v, nil
+		case string:
+			if d, err := time.ParseDuration(v); err == nil {
+				return d, nil
+			}
+		}
+	case plugin.ConfigTypeStringList:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []interface{}:
+			list := make([]string, 0, len(v))
+			for _, el := range v {
+				s, ok := el.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected %s, got %s", typ, formatValue(value))
+				}
+				list = append(list, s)
+			}
+			return list, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown field type (%d)", typ)
+	}
+
+	return nil, fmt.Errorf("expected %s, got %s", typ, formatValue(value))
+}
+
+// Convert value provided by user or decoded from JSON to integer (values above 2^53 are parsed without loss of precision)
+func toInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return i, true
+		}
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i, true
+		}
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), uint64(v) <= math.MaxInt64
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	}
+
+	// values like 1e3 or 10.0
+	f, ok := toFloat(value)
+	if !ok || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+
+	return int64(f), true
+}
+
+// Convert value provided by user or decoded from JSON to float
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+// Representation of normalized numeric value used for comparisons (limits)
+func numericView(v interface{}) float64 {
+	switch nv := v.(type) {
+	case int64:
+		return float64(nv)
+	case float64:
+		return nv
+	case time.Duration:
+		return float64(nv)
+	}
+
+	return 0
+}
+
+// Representation of normalized value which can be stored in JSON
+func jsonValue(v interface{}) interface{} {
+	if d, ok := v.(time.Duration); ok {
+		return d.String()
+	}
+
+	return v
+}
+
+func formatValue(v interface{}) string {
+	switch tv := v.(type) {
+	case string:
+		return strconv.Quote(tv)
+	case time.Duration:
+		return tv.String()
+	case json.Number:
+		return tv.String()
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func formatValues(values []interface{}) string {
+	formatted := make([]string, 0, len(values))
+	for _, v := range values {
+		formatted = append(formatted, formatValue(v))
+	}
+
+	return strings.Join(formatted, ", ")
+}
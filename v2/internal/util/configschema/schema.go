@@ -0,0 +1,206 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+/*
+Package configschema:
+* holds typed configuration fields declared by plugin (DefineConfigField)
+* validates task configuration and fills default values
+* exports declared fields as JSON Schema and example (YAML) configuration
+*/
+package configschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	pathSeparator = "."
+
+	jsonSchemaVersion = "http://json-schema.org/draft-07/schema#"
+)
+
+type Schema struct {
+	fields []*Field // fields in order of definition
+}
+
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// Define new field. Path elements are separated with '.', ie. "server.port" (the same keys are used by ctx.ConfigValue)
+func (s *Schema) DefineField(path string, typ plugin.ConfigFieldType, modifiers ...plugin.ConfigFieldModifier) error {
+	if err := s.validatePath(path); err != nil {
+		return err
+	}
+
+	if typ < plugin.ConfigTypeString || typ > plugin.ConfigTypeStringList {
+		return fmt.Errorf("can't define config field %s: unknown field type (%d)", path, typ)
+	}
+
+	f := newField(path, typ)
+
+	for _, m := range modifiers {
+		m.UpdateConfigField(f)
+	}
+
+	if len(f.errs) != 0 {
+		return fmt.Errorf("can't define config field %s: %v", path, joinErrors(f.errs))
+	}
+
+	if f.Default != nil {
+		if err := f.validate(f.Default); err != nil {
+			return fmt.Errorf("can't define config field %s: default value doesn't satisfy constraints: %v", path, err)
+		}
+	}
+
+	s.fields = append(s.fields, f)
+	return nil
+}
+
+func (s *Schema) Fields() []*Field {
+	return s.fields
+}
+
+func (s *Schema) IsEmpty() bool {
+	return len(s.fields) == 0
+}
+
+// Validate configuration (JSON) against declared fields and return configuration containing default values.
+// When schema is empty configuration is returned without any changes.
+func (s *Schema) Apply(rawConfig []byte) ([]byte, error) {
+	if s.IsEmpty() {
+		return rawConfig, nil
+	}
+
+	cfg := map[string]interface{}{}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawConfig))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("can't validate configuration due to invalid json: %v", err)
+	}
+	if cfg == nil { // null is treated as empty configuration
+		cfg = map[string]interface{}{}
+	}
+
+	var errs []error
+	for _, f := range s.fields {
+		if err := s.applyField(cfg, f); err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %v", f.Path, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return nil, fmt.Errorf("invalid configuration: %v", joinErrors(errs))
+	}
+
+	return json.Marshal(cfg)
+}
+
+func (s *Schema) applyField(cfg map[string]interface{}, f *Field) error {
+	pathElems := strings.Split(f.Path, pathSeparator)
+
+	parent := cfg
+	for _, el := range pathElems[:len(pathElems)-1] {
+		child, ok := parent[el]
+		if !ok {
+			if !f.Required && f.Default == nil {
+				return nil // nothing to validate or fill
+			}
+
+			child = map[string]interface{}{}
+			parent[el] = child
+		}
+
+		childMap, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("element %s should be an object", el)
+		}
+
+		parent = childMap
+	}
+
+	key := pathElems[len(pathElems)-1]
+
+	value, ok := parent[key]
+	if !ok {
+		if f.Required {
+			return errors.New("required field is missing")
+		}
+
+		if f.Default != nil {
+			parent[key] = jsonValue(f.Default)
+		}
+
+		return nil
+	}
+
+	v, err := normalize(f.Type, value)
+	if err != nil {
+		return err
+	}
+
+	err = f.validate(v)
+	if err != nil {
+		return err
+	}
+
+	switch f.Type {
+	case plugin.ConfigTypeInt, plugin.ConfigTypeFloat, plugin.ConfigTypeBool:
+		parent[key] = v // values provided as strings (ie. "10") are stored with valid JSON type
+	}
+
+	return nil
+}
+
+func (s *Schema) validatePath(path string) error {
+	if path == "" {
+		return errors.New("can't define config field with empty path")
+	}
+
+	for _, el := range strings.Split(path, pathSeparator) {
+		if el == "" {
+			return fmt.Errorf("can't define config field %s: path contains empty element", path)
+		}
+	}
+
+	for _, f := range s.fields {
+		if f.Path == path {
+			return fmt.Errorf("can't define config field %s: field was already defined", path)
+		}
+
+		if strings.HasPrefix(f.Path, path+pathSeparator) || strings.HasPrefix(path, f.Path+pathSeparator) {
+			return fmt.Errorf("can't define config field %s: path conflicts with field %s", path, f.Path)
+		}
+	}
+
+	return nil
+}
+
+func joinErrors(errs []error) string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
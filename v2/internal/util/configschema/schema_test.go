@@ -0,0 +1,182 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package configschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func exampleSchema() *Schema {
+	s := NewSchema()
+
+	_ = s.DefineField("server.address", plugin.ConfigTypeString, plugin.ConfigRequired(), plugin.ConfigPattern(`^[a-z0-9.]+$`))
+	_ = s.DefineField("server.port", plugin.ConfigTypeInt, plugin.ConfigDefault(8080), plugin.ConfigMin(1), plugin.ConfigMax(65535))
+	_ = s.DefineField("timeout", plugin.ConfigTypeDuration, plugin.ConfigDefault(10*time.Second), plugin.ConfigMax("1m"))
+	_ = s.DefineField("mode", plugin.ConfigTypeString, plugin.ConfigAllowedValues("fast", "slow"))
+	_ = s.DefineField("ratio", plugin.ConfigTypeFloat)
+	_ = s.DefineField("enabled", plugin.ConfigTypeBool, plugin.ConfigDefault(true))
+	_ = s.DefineField("devices", plugin.ConfigTypeStringList, plugin.ConfigPattern(`^sd[a-z]$`))
+	_ = s.DefineField("password", plugin.ConfigTypeString, plugin.ConfigSecret())
+
+	return s
+}
+
+func TestSchemaDefinition(t *testing.T) {
+	Convey("Validate that invalid field definitions are rejected", t, func() {
+		s := NewSchema()
+
+		So(s.DefineField("server.port", plugin.ConfigTypeInt), ShouldBeNil)
+		So(s.IsEmpty(), ShouldBeFalse)
+
+		So(s.DefineField("", plugin.ConfigTypeInt), ShouldBeError)
+		So(s.DefineField("server..ip", plugin.ConfigTypeString), ShouldBeError)
+		So(s.DefineField("server.port", plugin.ConfigTypeInt), ShouldBeError)
+		So(s.DefineField("server", plugin.ConfigTypeString), ShouldBeError)
+		So(s.DefineField("server.port.number", plugin.ConfigTypeInt), ShouldBeError)
+		So(s.DefineField("unknown", plugin.ConfigFieldType(100)), ShouldBeError)
+		So(s.DefineField("count", plugin.ConfigTypeInt, plugin.ConfigDefault("abc")), ShouldBeError)
+		So(s.DefineField("count", plugin.ConfigTypeInt, plugin.ConfigDefault(1.5)), ShouldBeError)
+		So(s.DefineField("count", plugin.ConfigTypeInt, plugin.ConfigDefault(0), plugin.ConfigMin(1)), ShouldBeError)
+		So(s.DefineField("name", plugin.ConfigTypeString, plugin.ConfigMin(1)), ShouldBeError)
+		So(s.DefineField("name", plugin.ConfigTypeString, plugin.ConfigPattern("[a-")), ShouldBeError)
+		So(s.DefineField("flag", plugin.ConfigTypeBool, plugin.ConfigPattern("true")), ShouldBeError)
+
+		So(len(s.Fields()), ShouldEqual, 1)
+	})
+}
+
+func TestSchemaApply(t *testing.T) {
+	Convey("Validate that configuration is validated and filled with defaults", t, func() {
+		s := exampleSchema()
+
+		Convey("Valid configuration", func() {
+			cfg, err := s.Apply([]byte(`{"server": {"address": "localhost", "port": "9090"}, "ratio": 0.5, "devices": ["sda", "sdb"], "other": 1}`))
+			So(err, ShouldBeNil)
+
+			m := map[string]interface{}{}
+			So(json.Unmarshal(cfg, &m), ShouldBeNil)
+
+			So(m["server"], ShouldResemble, map[string]interface{}{"address": "localhost", "port": 9090.0})
+			So(m["timeout"], ShouldEqual, "10s")
+			So(m["enabled"], ShouldEqual, true)
+			So(m["ratio"], ShouldEqual, 0.5)
+			So(m["other"], ShouldEqual, 1.0)
+			So(m, ShouldNotContainKey, "mode")
+			So(m, ShouldNotContainKey, "password")
+		})
+
+		Convey("Invalid configuration", func() {
+			_, err := s.Apply([]byte(`{"server": {"port": 0}, "timeout": "2m", "mode": "medium", "ratio": "x", "devices": ["sda", "loop0"], "enabled": "maybe"}`))
+			So(err, ShouldBeError)
+			So(err.Error(), ShouldContainSubstring, "field server.address: required field is missing")
+			So(err.Error(), ShouldContainSubstring, "field server.port: value 0 is lower than minimum (1)")
+			So(err.Error(), ShouldContainSubstring, "field timeout: value 2m0s is greater than maximum (1m0s)")
+			So(err.Error(), ShouldContainSubstring, `field mode: value "medium" is not one of allowed values ("fast", "slow")`)
+			So(err.Error(), ShouldContainSubstring, "field ratio: expected float")
+			So(err.Error(), ShouldContainSubstring, `field devices: value "loop0" doesn't match pattern`)
+			So(err.Error(), ShouldContainSubstring, "field enabled: expected bool")
+		})
+
+		Convey("Integers above 2^53 keep full precision", func() {
+			s := NewSchema()
+			So(s.DefineField("id", plugin.ConfigTypeInt), ShouldBeNil)
+			So(s.DefineField("ref", plugin.ConfigTypeInt), ShouldBeNil)
+			So(s.DefineField("count", plugin.ConfigTypeInt), ShouldBeNil)
+
+			cfg, err := s.Apply([]byte(`{"id": 9007199254740993, "ref": "9223372036854775807", "count": 1e3}`))
+			So(err, ShouldBeNil)
+			So(string(cfg), ShouldEqual, `{"count":1000,"id":9007199254740993,"ref":9223372036854775807}`)
+
+			_, err = s.Apply([]byte(`{"id": 9223372036854775808}`))
+			So(err, ShouldBeError)
+			So(err.Error(), ShouldContainSubstring, "field id: expected int")
+		})
+
+		Convey("Intermediate element is not an object", func() {
+			_, err := s.Apply([]byte(`{"server": "localhost"}`))
+			So(err, ShouldBeError)
+			So(err.Error(), ShouldContainSubstring, "field server.address: element server should be an object")
+		})
+
+		Convey("Null configuration is treated as empty one", func() {
+			s := NewSchema()
+			So(s.DefineField("a.b", plugin.ConfigTypeInt, plugin.ConfigDefault(5)), ShouldBeNil)
+
+			cfg, err := s.Apply([]byte("null"))
+			So(err, ShouldBeNil)
+			So(string(cfg), ShouldEqual, `{"a":{"b":5}}`)
+
+			_, err = s.Apply([]byte("[1]"))
+			So(err, ShouldBeError)
+		})
+
+		Convey("Configuration is not changed when schema is empty", func() {
+			rawCfg := []byte(`{"a": "1"}`)
+			cfg, err := NewSchema().Apply(rawCfg)
+			So(err, ShouldBeNil)
+			So(cfg, ShouldResemble, rawCfg)
+		})
+	})
+}
+
+func TestSchemaExport(t *testing.T) {
+	Convey("Validate that schema can be exported", t, func() {
+		s := exampleSchema()
+
+		Convey("As JSON Schema", func() {
+			b, err := s.JSONSchema()
+			So(err, ShouldBeNil)
+
+			js := map[string]interface{}{}
+			So(json.Unmarshal(b, &js), ShouldBeNil)
+
+			So(js["$schema"], ShouldEqual, jsonSchemaVersion)
+			So(js["required"], ShouldResemble, []interface{}{"server"})
+
+			props := js["properties"].(map[string]interface{})
+			server := props["server"].(map[string]interface{})
+			So(server["required"], ShouldResemble, []interface{}{"address"})
+
+			port := server["properties"].(map[string]interface{})["port"].(map[string]interface{})
+			So(port, ShouldResemble, map[string]interface{}{"type": "integer", "default": 8080.0, "minimum": 1.0, "maximum": 65535.0})
+
+			So(props["mode"].(map[string]interface{})["enum"], ShouldResemble, []interface{}{"fast", "slow"})
+			So(props["password"].(map[string]interface{})["writeOnly"], ShouldEqual, true)
+			So(props["devices"].(map[string]interface{})["items"], ShouldResemble, map[string]interface{}{"type": "string", "pattern": "^sd[a-z]$"})
+		})
+
+		Convey("As example configuration", func() {
+			node, err := s.ExampleConfig()
+			So(err, ShouldBeNil)
+
+			cfg := map[string]interface{}{}
+			So(node.Decode(&cfg), ShouldBeNil)
+
+			So(cfg["server"], ShouldResemble, map[string]interface{}{"address": "", "port": 8080})
+			So(cfg["timeout"], ShouldEqual, "10s")
+			So(cfg["mode"], ShouldEqual, "fast")
+			So(cfg["enabled"], ShouldEqual, true)
+		})
+	})
+}
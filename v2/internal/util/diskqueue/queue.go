@@ -0,0 +1,650 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+/*
+Package diskqueue implements durable FIFO queue of records stored on disk.
+
+Records are appended to segment files (<id>.seg). Position of the oldest not acknowledged record
+is saved in write-ahead log (cursor.wal), so queue content survives restarts of the process.
+Segments are removed when all their records are acknowledged.
+*/
+package diskqueue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	segmentExt   = ".seg"
+	cursorFile   = "cursor.wal"
+	segmentIDFmt = "%020d"
+
+	recordHeaderSize = 16 // length (4B), crc (4B), timestamp (8B)
+	cursorEntrySize  = 20 // segment id (8B), offset (8B), crc (4B)
+
+	maxCursorEntries = 4096 // number of entries after which write-ahead log is compacted
+
+	DefaultMaxSize     = 256 * 1024 * 1024
+	DefaultSegmentSize = 8 * 1024 * 1024
+)
+
+var (
+	ErrEmpty  = errors.New("queue is empty")
+	ErrClosed = errors.New("queue is closed")
+)
+
+type Options struct {
+	MaxSize     int64         // maximum size of all segments (oldest records are dropped when exceeded)
+	SegmentSize int64         // size after which new segment is created
+	MaxAge      time.Duration // age after which records are dropped (0 - no limit)
+}
+
+// Position of record in queue (returned by Peek, used to acknowledge exactly that record)
+type Position struct {
+	segment uint64
+	offset  int64
+}
+
+type segment struct {
+	id      uint64
+	size    int64
+	records int
+}
+
+type Queue struct {
+	mu  sync.Mutex
+	dir string
+	opt Options
+
+	segments []*segment // ordered from the oldest
+	writer   *os.File   // last segment (opened for appending)
+	reader   *os.File   // first segment (containing read cursor)
+
+	readOffset  int64 // offset of the oldest record in the first segment
+	readRecords int   // number of records already consumed from the first segment
+
+	cursorLog     *os.File
+	cursorEntries int
+
+	count   int
+	dropped int
+	closed  bool
+
+	notifyCh chan struct{}
+	now      func() time.Time
+}
+
+// Open queue stored in a given directory (directory is created when needed)
+func Open(dir string, opt Options) (*Queue, error) {
+	if opt.MaxSize <= 0 {
+		opt.MaxSize = DefaultMaxSize
+	}
+	if opt.SegmentSize <= 0 {
+		opt.SegmentSize = DefaultSegmentSize
+	}
+	if opt.SegmentSize > opt.MaxSize {
+		opt.SegmentSize = opt.MaxSize
+	}
+
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("can't create queue directory: %v", err)
+	}
+
+	q := &Queue{
+		dir:      dir,
+		opt:      opt,
+		notifyCh: make(chan struct{}, 1),
+		now:      time.Now,
+	}
+
+	err = q.load()
+	if err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Append record to the end of queue. Returns when record is written (and synced) to disk.
+func (q *Queue) Append(data []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	recSize := int64(recordHeaderSize + len(data))
+	if recSize > q.opt.MaxSize {
+		return fmt.Errorf("record size (%d) exceeds maximum size of queue (%d)", recSize, q.opt.MaxSize)
+	}
+
+	last := q.segments[len(q.segments)-1]
+	if last.size > 0 && last.size+recSize > q.opt.SegmentSize {
+		err := q.rotate()
+		if err != nil {
+			return err
+		}
+		last = q.segments[len(q.segments)-1]
+	}
+
+	buf := make([]byte, recSize)
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(data))
+	binary.LittleEndian.PutUint64(buf[8:16], uint64(q.now().UnixNano()))
+	copy(buf[recordHeaderSize:], data)
+
+	_, err := q.writer.WriteAt(buf, last.size)
+	if err == nil {
+		err = q.writer.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("can't write record to segment: %v", err)
+	}
+
+	last.size += recSize
+	last.records++
+	q.count++
+
+	err = q.enforceMaxSize()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case q.notifyCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Return the oldest record (without removing it from queue) and its position. Records older than MaxAge are dropped.
+func (q *Queue) Peek() ([]byte, Position, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, Position{}, ErrClosed
+	}
+
+	for q.count > 0 {
+		data, ts, err := q.readRecord()
+		if err != nil {
+			return nil, Position{}, err
+		}
+
+		if q.opt.MaxAge > 0 && q.now().Sub(ts) > q.opt.MaxAge {
+			q.dropped++
+			err = q.advance(int64(recordHeaderSize + len(data)))
+			if err != nil {
+				return nil, Position{}, err
+			}
+			continue
+		}
+
+		return data, q.headPosition(), nil
+	}
+
+	return nil, Position{}, ErrEmpty
+}
+
+// Remove record at a given position (after it was successfully processed).
+// Nothing is done when record is not at the head of queue anymore (ie. it was dropped due to size or age limits).
+func (q *Queue) Ack(pos Position) error {
+	return q.remove(pos, false)
+}
+
+// Remove record at a given position, counting it as dropped (ie. when it can't be processed).
+// Nothing is done when record is not at the head of queue anymore.
+func (q *Queue) Discard(pos Position) error {
+	return q.remove(pos, true)
+}
+
+// Channel notified when new record is appended
+func (q *Queue) Notify() <-chan struct{} {
+	return q.notifyCh
+}
+
+// Number of records in queue
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.count
+}
+
+// Size of queue on disk (in bytes)
+func (q *Queue) Size() int64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.totalSize()
+}
+
+// Number of records dropped due to size or age limits (or discarded) since queue was opened
+func (q *Queue) Dropped() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.dropped
+}
+
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+
+	return q.closeFiles()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (q *Queue) load() error {
+	files, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return fmt.Errorf("can't read queue directory: %v", err)
+	}
+
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), segmentExt) {
+			continue
+		}
+
+		id, err := strconv.ParseUint(strings.TrimSuffix(f.Name(), segmentExt), 10, 64)
+		if err != nil {
+			continue // not a segment
+		}
+
+		q.segments = append(q.segments, &segment{id: id, size: f.Size()})
+	}
+
+	sort.Slice(q.segments, func(i, j int) bool { return q.segments[i].id < q.segments[j].id })
+
+	cursorSeg, cursorOffset, err := q.readCursor()
+	if err != nil {
+		return err
+	}
+
+	// remove segments consumed before cursor was moved to the next one
+	for len(q.segments) > 0 && q.segments[0].id < cursorSeg {
+		err := os.Remove(q.segmentPath(q.segments[0].id))
+		if err != nil {
+			return fmt.Errorf("can't remove consumed segment: %v", err)
+		}
+		q.segments = q.segments[1:]
+	}
+
+	if len(q.segments) == 0 {
+		q.segments = append(q.segments, &segment{id: cursorSeg})
+	}
+	if q.segments[0].id != cursorSeg {
+		cursorOffset = 0
+	}
+
+	for i, seg := range q.segments {
+		start := int64(0)
+		if i == 0 {
+			start = cursorOffset
+		}
+
+		err := q.scanSegment(seg, start)
+		if err != nil {
+			return err
+		}
+	}
+
+	q.readOffset = cursorOffset
+	if q.readOffset > q.segments[0].size {
+		q.readOffset = q.segments[0].size
+	}
+
+	q.writer, err = os.OpenFile(q.segmentPath(q.segments[len(q.segments)-1].id), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("can't open segment: %v", err)
+	}
+
+	return q.writeCursor()
+}
+
+// Count valid records of segment, truncating it on first invalid (ie. partially written) record
+func (q *Queue) scanSegment(seg *segment, start int64) error {
+	path := q.segmentPath(seg.id)
+
+	f, err := os.OpenFile(path, os.O_RDWR, 0600)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't open segment: %v", err)
+	}
+	defer f.Close()
+
+	offset := start
+	header := make([]byte, recordHeaderSize)
+
+	for offset < seg.size {
+		_, err := f.ReadAt(header, offset)
+		if err != nil {
+			break
+		}
+
+		length := int64(binary.LittleEndian.Uint32(header[0:4]))
+		if offset+recordHeaderSize+length > seg.size {
+			break
+		}
+
+		data := make([]byte, length)
+		_, err = f.ReadAt(data, offset+recordHeaderSize)
+		if err != nil || crc32.ChecksumIEEE(data) != binary.LittleEndian.Uint32(header[4:8]) {
+			break
+		}
+
+		seg.records++
+		q.count++
+		offset += recordHeaderSize + length
+	}
+
+	if offset < seg.size {
+		err := f.Truncate(offset)
+		if err != nil {
+			return fmt.Errorf("can't truncate corrupted segment: %v", err)
+		}
+		seg.size = offset
+	}
+
+	return nil
+}
+
+func (q *Queue) readRecord() ([]byte, time.Time, error) {
+	seg := q.segments[0]
+
+	for q.readOffset >= seg.size && len(q.segments) > 1 {
+		err := q.nextSegment()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		seg = q.segments[0]
+	}
+
+	if q.reader == nil {
+		f, err := os.Open(q.segmentPath(seg.id))
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("can't open segment: %v", err)
+		}
+		q.reader = f
+	}
+
+	header := make([]byte, recordHeaderSize)
+	_, err := q.reader.ReadAt(header, q.readOffset)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("can't read record header: %v", err)
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint32(header[0:4]))
+	_, err = q.reader.ReadAt(data, q.readOffset+recordHeaderSize)
+	if err != nil && err != io.EOF {
+		return nil, time.Time{}, fmt.Errorf("can't read record: %v", err)
+	}
+
+	ts := time.Unix(0, int64(binary.LittleEndian.Uint64(header[8:16])))
+	return data, ts, nil
+}
+
+func (q *Queue) remove(pos Position, dropped bool) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	if q.count == 0 {
+		return nil // record has already been removed
+	}
+
+	data, _, err := q.readRecord()
+	if err != nil {
+		return err
+	}
+
+	if q.headPosition() != pos {
+		return nil // head has moved past the record
+	}
+
+	if dropped {
+		q.dropped++
+	}
+
+	return q.advance(int64(recordHeaderSize + len(data)))
+}
+
+// Position of the oldest record (valid after readRecord)
+func (q *Queue) headPosition() Position {
+	return Position{segment: q.segments[0].id, offset: q.readOffset}
+}
+
+// Move read cursor after the oldest record
+func (q *Queue) advance(recSize int64) error {
+	q.readOffset += recSize
+	q.readRecords++
+	q.count--
+
+	if q.readOffset >= q.segments[0].size && len(q.segments) > 1 {
+		return q.nextSegment()
+	}
+
+	return q.writeCursor()
+}
+
+// Remove the first segment (all records consumed or dropped) and move cursor to the next one
+func (q *Queue) nextSegment() error {
+	if q.reader != nil {
+		_ = q.reader.Close()
+		q.reader = nil
+	}
+
+	first := q.segments[0]
+	q.count -= first.records - q.readRecords
+	q.segments = q.segments[1:]
+	q.readOffset = 0
+	q.readRecords = 0
+
+	err := q.writeCursor() // cursor should point to the next segment before previous one is removed
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(q.segmentPath(first.id))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("can't remove consumed segment: %v", err)
+	}
+
+	return nil
+}
+
+func (q *Queue) rotate() error {
+	last := q.segments[len(q.segments)-1]
+
+	err := q.writer.Close()
+	if err != nil {
+		return fmt.Errorf("can't close segment: %v", err)
+	}
+
+	newSeg := &segment{id: last.id + 1}
+	q.writer, err = os.OpenFile(q.segmentPath(newSeg.id), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("can't create segment: %v", err)
+	}
+
+	q.segments = append(q.segments, newSeg)
+	return nil
+}
+
+// Drop the oldest segments when queue exceeds its maximum size
+func (q *Queue) enforceMaxSize() error {
+	for q.totalSize() > q.opt.MaxSize && len(q.segments) > 1 {
+		q.dropped += q.segments[0].records - q.readRecords
+
+		err := q.nextSegment()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (q *Queue) totalSize() int64 {
+	size := int64(0)
+	for _, seg := range q.segments {
+		size += seg.size
+	}
+	return size
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Read the last valid entry of write-ahead log (position of read cursor)
+func (q *Queue) readCursor() (uint64, int64, error) {
+	segID, offset := uint64(0), int64(0)
+	if len(q.segments) > 0 {
+		segID = q.segments[0].id
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(q.dir, cursorFile))
+	if os.IsNotExist(err) {
+		return segID, offset, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("can't read cursor: %v", err)
+	}
+
+	for pos := 0; pos+cursorEntrySize <= len(content); pos += cursorEntrySize {
+		entry := content[pos : pos+cursorEntrySize]
+		if crc32.ChecksumIEEE(entry[0:16]) != binary.LittleEndian.Uint32(entry[16:20]) {
+			break
+		}
+
+		segID = binary.LittleEndian.Uint64(entry[0:8])
+		offset = int64(binary.LittleEndian.Uint64(entry[8:16]))
+	}
+
+	return segID, offset, nil
+}
+
+// Append position of read cursor to write-ahead log (log is compacted when it contains too many entries)
+func (q *Queue) writeCursor() error {
+	if q.cursorLog == nil || q.cursorEntries >= maxCursorEntries {
+		err := q.compactCursor()
+		if err != nil {
+			return err
+		}
+	}
+
+	entry := make([]byte, cursorEntrySize)
+	binary.LittleEndian.PutUint64(entry[0:8], q.segments[0].id)
+	binary.LittleEndian.PutUint64(entry[8:16], uint64(q.readOffset))
+	binary.LittleEndian.PutUint32(entry[16:20], crc32.ChecksumIEEE(entry[0:16]))
+
+	_, err := q.cursorLog.Write(entry)
+	if err == nil {
+		err = q.cursorLog.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("can't write cursor: %v", err)
+	}
+
+	q.cursorEntries++
+	return nil
+}
+
+func (q *Queue) compactCursor() error {
+	if q.cursorLog != nil {
+		_ = q.cursorLog.Close()
+		q.cursorLog = nil
+	}
+
+	path := filepath.Join(q.dir, cursorFile)
+
+	// new log is created aside and renamed, so there is always valid cursor on disk
+	tmpPath := path + ".tmp"
+	entry := make([]byte, cursorEntrySize)
+	binary.LittleEndian.PutUint64(entry[0:8], q.segments[0].id)
+	binary.LittleEndian.PutUint64(entry[8:16], uint64(q.readOffset))
+	binary.LittleEndian.PutUint32(entry[16:20], crc32.ChecksumIEEE(entry[0:16]))
+
+	err := ioutil.WriteFile(tmpPath, entry, 0600)
+	if err != nil {
+		return fmt.Errorf("can't compact cursor: %v", err)
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		return fmt.Errorf("can't compact cursor: %v", err)
+	}
+
+	q.cursorLog, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("can't open cursor: %v", err)
+	}
+
+	q.cursorEntries = 1
+	return nil
+}
+
+func (q *Queue) closeFiles() error {
+	var errs []string
+
+	for _, f := range []*os.File{q.writer, q.reader, q.cursorLog} {
+		if f == nil {
+			continue
+		}
+
+		err := f.Close()
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	q.writer, q.reader, q.cursorLog = nil, nil, nil
+
+	if len(errs) > 0 {
+		return fmt.Errorf("can't close queue files: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (q *Queue) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf(segmentIDFmt, id)+segmentExt)
+}
@@ -0,0 +1,220 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package diskqueue
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func appendRecords(q *Queue, from, to int) {
+	for i := from; i < to; i++ {
+		So(q.Append([]byte(fmt.Sprintf("record-%d", i))), ShouldBeNil)
+	}
+}
+
+func consumeRecords(q *Queue, n int) []string {
+	var records []string
+	for i := 0; i < n; i++ {
+		data, pos, err := q.Peek()
+		if err != nil {
+			break
+		}
+		records = append(records, string(data))
+		So(q.Ack(pos), ShouldBeNil)
+	}
+	return records
+}
+
+func segmentFiles(dir string) []string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	return files
+}
+
+func TestQueue(t *testing.T) {
+	Convey("Validate that records can be appended and consumed", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		q, err := Open(dir, Options{SegmentSize: 100})
+		So(err, ShouldBeNil)
+
+		_, _, err = q.Peek()
+		So(err, ShouldEqual, ErrEmpty)
+
+		appendRecords(q, 0, 10)
+		So(q.Len(), ShouldEqual, 10)
+		So(len(segmentFiles(dir)), ShouldBeGreaterThan, 1)
+
+		data, firstPos, err := q.Peek()
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, "record-0")
+		So(q.Len(), ShouldEqual, 10) // peek doesn't remove record
+
+		So(consumeRecords(q, 10), ShouldResemble, []string{
+			"record-0", "record-1", "record-2", "record-3", "record-4",
+			"record-5", "record-6", "record-7", "record-8", "record-9",
+		})
+		So(q.Len(), ShouldEqual, 0)
+		So(len(segmentFiles(dir)), ShouldEqual, 1) // consumed segments are removed
+
+		appendRecords(q, 10, 11)
+		So(q.Ack(firstPos), ShouldBeNil) // stale position - no-op
+		So(q.Len(), ShouldEqual, 1)
+		So(q.Close(), ShouldBeNil)
+		So(q.Append([]byte("x")), ShouldEqual, ErrClosed)
+	})
+
+	Convey("Validate that queue content is preserved after reopening", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		q, _ := Open(dir, Options{SegmentSize: 100})
+		appendRecords(q, 0, 10)
+		So(consumeRecords(q, 3), ShouldResemble, []string{"record-0", "record-1", "record-2"})
+		So(q.Close(), ShouldBeNil)
+
+		q, err := Open(dir, Options{SegmentSize: 100})
+		So(err, ShouldBeNil)
+		So(q.Len(), ShouldEqual, 7)
+
+		appendRecords(q, 10, 12)
+		records := consumeRecords(q, 20)
+		So(records, ShouldHaveLength, 9)
+		So(records[0], ShouldEqual, "record-3")
+		So(records[8], ShouldEqual, "record-11")
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("Validate that partially written record is ignored", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		q, _ := Open(dir, Options{})
+		appendRecords(q, 0, 3)
+		So(q.Close(), ShouldBeNil)
+
+		segPath := segmentFiles(dir)[0]
+		info, _ := os.Stat(segPath)
+		So(os.Truncate(segPath, info.Size()-2), ShouldBeNil) // simulate crash during write
+
+		q, err := Open(dir, Options{})
+		So(err, ShouldBeNil)
+		So(q.Len(), ShouldEqual, 2)
+
+		appendRecords(q, 3, 4)
+		So(consumeRecords(q, 10), ShouldResemble, []string{"record-0", "record-1", "record-3"})
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("Validate that the oldest records are dropped when queue exceeds its size", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		recordSize := int64(recordHeaderSize + len("record-0"))
+		q, _ := Open(dir, Options{MaxSize: 4 * recordSize, SegmentSize: 2 * recordSize})
+
+		appendRecords(q, 0, 6)
+		So(q.Size(), ShouldBeLessThanOrEqualTo, 4*recordSize)
+		So(q.Dropped(), ShouldEqual, 2)
+		So(consumeRecords(q, 10), ShouldResemble, []string{"record-2", "record-3", "record-4", "record-5"})
+
+		So(q.Append(make([]byte, 4*recordSize)), ShouldNotBeNil) // record can't be bigger than queue
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("Validate that too old records are dropped", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		now := time.Now()
+		q, _ := Open(dir, Options{MaxAge: time.Minute})
+		q.now = func() time.Time { return now }
+
+		appendRecords(q, 0, 2)
+		now = now.Add(30 * time.Second)
+		appendRecords(q, 2, 3)
+		now = now.Add(45 * time.Second)
+
+		So(consumeRecords(q, 10), ShouldResemble, []string{"record-2"})
+		So(q.Dropped(), ShouldEqual, 2)
+
+		_, _, err := q.Peek()
+		So(err, ShouldEqual, ErrEmpty)
+		appendRecords(q, 3, 4)
+		_, pos, err := q.Peek()
+		So(err, ShouldBeNil)
+		So(q.Discard(pos), ShouldBeNil)
+		So(q.Dropped(), ShouldEqual, 3)
+		So(q.Len(), ShouldEqual, 0)
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("Validate that record dropped after Peek isn't acknowledged in place of the next one", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		recordSize := int64(recordHeaderSize + len("record-0"))
+		q, _ := Open(dir, Options{MaxSize: 4 * recordSize, SegmentSize: 2 * recordSize})
+
+		appendRecords(q, 0, 4)
+		data, pos, err := q.Peek()
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, "record-0")
+
+		appendRecords(q, 4, 5) // overflow drops the first segment (with peeked record)
+		So(q.Dropped(), ShouldEqual, 2)
+
+		So(q.Ack(pos), ShouldBeNil)
+		So(q.Len(), ShouldEqual, 3)
+		So(consumeRecords(q, 10), ShouldResemble, []string{"record-2", "record-3", "record-4"})
+		So(q.Close(), ShouldBeNil)
+	})
+
+	Convey("Validate that record dropped due to age after Peek isn't acknowledged in place of the next one", t, func() {
+		dir, _ := ioutil.TempDir("", "diskqueue")
+		defer os.RemoveAll(dir)
+
+		now := time.Now()
+		q, _ := Open(dir, Options{MaxAge: time.Minute})
+		q.now = func() time.Time { return now }
+
+		appendRecords(q, 0, 1)
+		_, pos, err := q.Peek()
+		So(err, ShouldBeNil)
+
+		now = now.Add(45 * time.Second)
+		appendRecords(q, 1, 2)
+		now = now.Add(30 * time.Second)
+
+		data, _, err := q.Peek() // record-0 expires
+		So(err, ShouldBeNil)
+		So(string(data), ShouldEqual, "record-1")
+
+		So(q.Ack(pos), ShouldBeNil)
+		So(q.Len(), ShouldEqual, 1)
+		So(q.Close(), ShouldBeNil)
+	})
+}
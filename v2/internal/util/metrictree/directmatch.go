@@ -22,6 +22,25 @@ import (
 )
 
 func MatchNsToFilter(ns string, filter string) (bool, error) {
+	return MatchNsToFilterWithTags(ns, nil, filter)
+}
+
+// Match metric (namespace and tags) to filter, which may contain tag predicates (ie. /plugin/**{env=prod})
+func MatchNsToFilterWithTags(ns string, tags map[string]string, filter string) (bool, error) {
+	filter, predicates, err := SplitTagPredicates(filter)
+	if err != nil {
+		return false, err
+	}
+
+	match, err := matchNsToFilter(ns, filter)
+	if err != nil || !match {
+		return false, err
+	}
+
+	return predicates.Match(tags), nil
+}
+
+func matchNsToFilter(ns string, filter string) (bool, error) {
 	parsedNs, err := ParseNamespace(ns, false)
 	if err != nil {
 		return false, err
@@ -34,6 +34,7 @@ const (
 	dynamicElementBeginIndicator = "["
 	dynamicElementEndIndicator   = "]"
 	dynamicElementEqualIndicator = "="
+	exclusionIndicator           = "!"
 )
 
 const minNamespaceElements = 2
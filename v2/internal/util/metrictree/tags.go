@@ -0,0 +1,206 @@
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package metrictree
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	tagPredicatesBeginIndicator = "{"
+	tagPredicatesEndIndicator   = "}"
+	tagPredicatesSeparator      = ","
+)
+
+type tagOperator string
+
+const (
+	tagEqual         tagOperator = "="
+	tagNotEqual      tagOperator = "!="
+	tagRegexpMatch   tagOperator = "=~"
+	tagRegexpNoMatch tagOperator = "!~"
+)
+
+// Single condition of tag predicate clause (ie. env=prod, device!~loop.*)
+type tagPredicate struct {
+	key   string
+	op    tagOperator
+	value string
+	regex *regexp.Regexp // used by =~ and !~ (value has to match entirely)
+}
+
+// TagPredicates is a list of conditions appended to selector (ie. /plugin/**{env=prod,device!~loop.*}).
+// Metric matches when all conditions are met. Missing tag is treated as tag with empty value.
+type TagPredicates []tagPredicate
+
+// Split selector into namespace and tag predicate clause, ie. "/plugin/**{env=prod}" -> "/plugin/**", [env=prod]
+// Selector without clause is returned unchanged (element like "/plugin/{regex}" is not considered a clause).
+func SplitTagPredicates(selector string) (string, TagPredicates, error) {
+	begin := tagPredicatesBegin(selector)
+	if begin == -1 {
+		return selector, nil, nil
+	}
+
+	predicates, err := parseTagPredicates(selector[begin+1 : len(selector)-1])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid tag predicates in selector (%s): %w", selector, err)
+	}
+
+	return selector[:begin], predicates, nil
+}
+
+// Find position of clause beginning (-1 when selector doesn't contain clause)
+func tagPredicatesBegin(selector string) int {
+	if len(selector) < 2 || !strings.HasSuffix(selector, tagPredicatesEndIndicator) {
+		return -1
+	}
+
+	depth := 0
+	for i := len(selector) - 1; i > 0; i-- {
+		switch selector[i] {
+		case '}':
+			depth++
+		case '{':
+			depth--
+		}
+
+		if depth == 0 {
+			// clause has to follow namespace element, otherwise it's a regular expression element (ie. /plugin/{m.*})
+			if selector[i-1] == selector[0] {
+				return -1
+			}
+			return i
+		}
+	}
+
+	return -1
+}
+
+func parseTagPredicates(clause string) (TagPredicates, error) {
+	var predicates TagPredicates
+
+	for _, s := range splitTagPredicates(clause) {
+		p, err := parseTagPredicate(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, p)
+	}
+
+	if len(predicates) == 0 {
+		return nil, fmt.Errorf("empty tag predicate clause")
+	}
+
+	return predicates, nil
+}
+
+// Split clause by separator, ignoring separators placed inside brackets of regular expressions (ie. "{1,3}")
+func splitTagPredicates(clause string) []string {
+	var result []string
+	depth := 0
+	last := 0
+
+	for i, ch := range clause {
+		switch ch {
+		case '{', '(', '[':
+			depth++
+		case '}', ')', ']':
+			depth--
+		case rune(tagPredicatesSeparator[0]):
+			if depth == 0 {
+				result = append(result, clause[last:i])
+				last = i + 1
+			}
+		}
+	}
+
+	return append(result, clause[last:])
+}
+
+func parseTagPredicate(s string) (tagPredicate, error) {
+	opIndex := strings.IndexAny(s, "=!")
+	if opIndex == -1 {
+		return tagPredicate{}, fmt.Errorf("missing operator in tag predicate (%s)", s)
+	}
+
+	p := tagPredicate{key: s[:opIndex]}
+	if !isValidIdentifier(p.key) {
+		return tagPredicate{}, fmt.Errorf("invalid character(s) used for tag key (%s)", p.key)
+	}
+
+	for _, op := range []tagOperator{tagNotEqual, tagRegexpMatch, tagRegexpNoMatch, tagEqual} {
+		if strings.HasPrefix(s[opIndex:], string(op)) {
+			p.op = op
+			p.value = s[opIndex+len(op):]
+			break
+		}
+	}
+
+	switch p.op {
+	case "":
+		return tagPredicate{}, fmt.Errorf("invalid operator in tag predicate (%s)", s)
+	case tagRegexpMatch, tagRegexpNoMatch:
+		r, err := regexp.Compile("^(?:" + p.value + ")$")
+		if err != nil {
+			return tagPredicate{}, fmt.Errorf("invalid regular expression (%s): %s", p.value, err)
+		}
+		p.regex = r
+	}
+
+	return p, nil
+}
+
+// Check if tags meet all conditions
+func (tp TagPredicates) Match(tags map[string]string) bool {
+	for _, p := range tp {
+		if !p.match(tags[p.key]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p tagPredicate) match(v string) bool {
+	switch p.op {
+	case tagEqual:
+		return v == p.value
+	case tagNotEqual:
+		return v != p.value
+	case tagRegexpMatch:
+		return p.regex.MatchString(v)
+	case tagRegexpNoMatch:
+		return !p.regex.MatchString(v)
+	}
+
+	return false
+}
+
+func (p tagPredicate) String() string {
+	return p.key + string(p.op) + p.value
+}
+
+func (tp TagPredicates) String() string {
+	s := make([]string, 0, len(tp))
+	for _, p := range tp {
+		s = append(s, p.String())
+	}
+
+	return tagPredicatesBeginIndicator + strings.Join(s, tagPredicatesSeparator) + tagPredicatesEndIndicator
+}
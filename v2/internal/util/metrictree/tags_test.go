@@ -0,0 +1,132 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package metrictree
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSplitTagPredicates(t *testing.T) {
+	Convey("Validate that tag predicate clause is separated from namespace selector", t, func() {
+		ns, predicates, err := SplitTagPredicates("/plugin/**{env=prod,device!~loop.*}")
+		So(err, ShouldBeNil)
+		So(ns, ShouldEqual, "/plugin/**")
+		So(predicates.String(), ShouldEqual, "{env=prod,device!~loop.*}")
+
+		ns, predicates, err = SplitTagPredicates("/plugin/[disk]/io{device=~sd[a-z]{1,2}, env!=dev}")
+		So(err, ShouldBeNil)
+		So(ns, ShouldEqual, "/plugin/[disk]/io")
+		So(predicates, ShouldHaveLength, 2)
+
+		Convey("Validate that regular expression elements are not considered a clause", func() {
+			for _, selector := range []string{"/plugin/{.*}", "/plugin/group/{id[234]{1,}}", "_plugin_{.*}", "/plugin/metric"} {
+				ns, predicates, err := SplitTagPredicates(selector)
+				So(err, ShouldBeNil)
+				So(ns, ShouldEqual, selector)
+				So(predicates, ShouldBeEmpty)
+			}
+		})
+
+		Convey("Validate that invalid clauses are reported", func() {
+			for _, selector := range []string{"/plugin/**{}", "/plugin/**{env}", "/plugin/**{env!prod}", "/plugin/**{=prod}", "/plugin/**{dev=~sd[a}"} {
+				_, _, err := SplitTagPredicates(selector)
+				So(err, ShouldBeError)
+			}
+		})
+	})
+}
+
+func TestTagPredicates_Match(t *testing.T) {
+	Convey("Validate that tags are matched against all predicates", t, func() {
+		_, predicates, err := SplitTagPredicates("/plugin/**{env=prod,device!~loop.*,rack!=r1}")
+		So(err, ShouldBeNil)
+
+		So(predicates.Match(map[string]string{"env": "prod", "device": "sda"}), ShouldBeTrue)
+		So(predicates.Match(map[string]string{"env": "prod", "device": "sda", "rack": "r2"}), ShouldBeTrue)
+		So(predicates.Match(map[string]string{"env": "prod", "device": "loop0"}), ShouldBeFalse)
+		So(predicates.Match(map[string]string{"env": "dev", "device": "sda"}), ShouldBeFalse)
+		So(predicates.Match(map[string]string{"env": "prod", "rack": "r1"}), ShouldBeFalse)
+		So(predicates.Match(nil), ShouldBeFalse) // missing tag is treated as empty
+
+		_, predicates, err = SplitTagPredicates("/plugin/**{device=~sd.}")
+		So(err, ShouldBeNil)
+		So(predicates.Match(map[string]string{"device": "sda"}), ShouldBeTrue)
+		So(predicates.Match(map[string]string{"device": "sda1"}), ShouldBeFalse) // regular expression has to match entire value
+	})
+}
+
+func TestMetricFilterValidator_TagPredicates(t *testing.T) {
+	Convey("Validate that filtering tree evaluates rules with tag predicates", t, func() {
+		v := NewMetricFilter(NewMetricDefinition())
+
+		So(v.AddRule("/plugin/cpu/**"), ShouldBeNil)
+		So(v.AddRule("/plugin/disk/**{env=prod,device!~loop.*}"), ShouldBeNil)
+		So(v.AddRule("/plugin/disk/**{env=dev}"), ShouldBeNil)
+		So(v.HasTagPredicates(), ShouldBeTrue)
+		So(v.ListRules(), ShouldResemble, []string{"/plugin/cpu/**", "/plugin/disk/**{env=dev}", "/plugin/disk/**{env=prod,device!~loop.*}"})
+
+		// namespace only (as if predicates were met)
+		So(v.IsPartiallyValid("/plugin/disk"), ShouldBeTrue)
+		ok, _ := v.IsValid("/plugin/disk/io")
+		So(ok, ShouldBeTrue)
+		ok, _ = v.IsValid("/plugin/memory/used")
+		So(ok, ShouldBeFalse)
+
+		So(v.IsValidWithTags("/plugin/cpu/usage", nil), ShouldBeTrue)
+		So(v.IsValidWithTags("/plugin/disk/io", map[string]string{"env": "prod", "device": "sda"}), ShouldBeTrue)
+		So(v.IsValidWithTags("/plugin/disk/io", map[string]string{"env": "prod", "device": "loop1"}), ShouldBeFalse)
+		So(v.IsValidWithTags("/plugin/disk/io", map[string]string{"env": "dev", "device": "loop1"}), ShouldBeTrue)
+		So(v.IsValidWithTags("/plugin/disk/io", nil), ShouldBeFalse)
+
+		Convey("Validate that tree containing only rules with tag predicates doesn't accept everything", func() {
+			v := NewMetricFilter(NewMetricDefinition())
+			So(v.AddRule("/plugin/disk/**{env=prod}"), ShouldBeNil)
+			So(v.HasRules(), ShouldBeTrue)
+
+			ok, _ := v.IsValid("/plugin/cpu/usage")
+			So(ok, ShouldBeFalse)
+			So(v.IsValidWithTags("/plugin/disk/io", map[string]string{"env": "prod"}), ShouldBeTrue)
+		})
+
+		Convey("Validate that tag predicates are not allowed in metric definition", func() {
+			So(NewMetricDefinition().AddRule("/plugin/disk/io{env=prod}"), ShouldBeError)
+		})
+	})
+}
+
+func TestMatchNsToFilterWithTags(t *testing.T) {
+	Convey("Validate that namespace and tags are matched to filter", t, func() {
+		match, err := MatchNsToFilterWithTags("/plugin/[disk=sda]/io", map[string]string{"env": "prod"}, "/plugin/[disk]{env=prod}")
+		So(err, ShouldBeNil)
+		So(match, ShouldBeTrue)
+
+		match, err = MatchNsToFilterWithTags("/plugin/[disk=sda]/io", map[string]string{"env": "dev"}, "/plugin/[disk]{env=prod}")
+		So(err, ShouldBeNil)
+		So(match, ShouldBeFalse)
+
+		match, err = MatchNsToFilter("/plugin/[disk=sda]/io", "/plugin/[disk]{env!=prod}")
+		So(err, ShouldBeNil)
+		So(match, ShouldBeTrue)
+
+		_, err = MatchNsToFilter("/plugin/[disk=sda]/io", "/plugin/[disk]{env}")
+		So(err, ShouldBeError)
+	})
+}
@@ -83,6 +83,17 @@ type TreeValidator struct {
 	definitionTree *TreeValidator // used in filtering tree (reference to definition tree)
 
 	head *Node
+
+	taggedRules []taggedRule // rules with tag predicates (only filtering tree), evaluated when metric tags are known
+
+	excluded *TreeValidator // negated rules, ie. !/plugin/debug/** (only filtering tree, take precedence over other rules)
+}
+
+// Filtering rule with tag predicate clause, ie. /plugin/**{env=prod}
+type taggedRule struct {
+	selector   string
+	validator  *TreeValidator // namespace part of selector
+	predicates TagPredicates
 }
 
 type Node struct {
@@ -108,6 +119,18 @@ func NewMetricFilter(definitionTree *TreeValidator) *TreeValidator {
 }
 
 func (tv *TreeValidator) AddRule(ns string) error {
+	if strings.HasPrefix(ns, exclusionIndicator) {
+		return tv.addExclusion(ns)
+	}
+
+	nsPart, predicates, err := SplitTagPredicates(ns)
+	if err != nil {
+		return err
+	}
+	if len(predicates) > 0 {
+		return tv.addTaggedRule(ns, nsPart, predicates)
+	}
+
 	parsedNs, err := ParseNamespace(ns, tv.strategy == metricFilteringStrategy)
 	if err != nil {
 		return err
@@ -134,26 +157,136 @@ func (tv *TreeValidator) AddRule(ns string) error {
 	return tv.updateTree(parsedNs)
 }
 
+func (tv *TreeValidator) addTaggedRule(selector string, ns string, predicates TagPredicates) error {
+	if tv.strategy != metricFilteringStrategy {
+		return fmt.Errorf("can't add rule (%s) - tag predicates are allowed only in filtering", selector)
+	}
+
+	validator := NewMetricFilter(tv.definitionTree)
+	err := validator.AddRule(ns)
+	if err != nil {
+		return err
+	}
+
+	tv.taggedRules = append(tv.taggedRules, taggedRule{
+		selector:   selector,
+		validator:  validator,
+		predicates: predicates,
+	})
+
+	return nil
+}
+
+func (tv *TreeValidator) addExclusion(selector string) error {
+	if tv.strategy != metricFilteringStrategy {
+		return fmt.Errorf("can't add rule (%s) - negated rules are allowed only in filtering", selector)
+	}
+
+	ns := strings.TrimPrefix(selector, exclusionIndicator)
+	if strings.HasPrefix(ns, exclusionIndicator) {
+		return fmt.Errorf("can't add rule (%s) - negated rule can't be negated again", selector)
+	}
+
+	excluded := tv.excluded
+	if excluded == nil {
+		excluded = NewMetricFilter(tv.definitionTree)
+	}
+
+	err := excluded.AddRule(ns)
+	if err != nil {
+		return err
+	}
+
+	tv.excluded = excluded
+	return nil
+}
+
 func (tv *TreeValidator) IsPartiallyValid(ns string) bool {
+	if tv.excluded != nil && tv.excluded.coversSubtree(ns) {
+		return false
+	}
+
 	isValid, _ := tv.isValid(ns, false, false)
 	return isValid
 }
 
 func (tv *TreeValidator) IsValid(ns string) (bool, []string) {
 	isValid, trace := tv.isValid(ns, true, false)
+	if isValid && tv.isExcluded(ns) {
+		return false, trace
+	}
+
 	return isValid, trace
 }
 
+// Check if metric matches rules, taking its tags into account (IsValid checks namespace only, as if tag predicates were met)
+func (tv *TreeValidator) IsValidWithTags(ns string, tags map[string]string) bool {
+	if tv.excluded != nil && tv.excluded.IsValidWithTags(ns, tags) {
+		return false
+	}
+
+	if isValid, _ := tv.matchTree(ns, true, false); isValid {
+		return true
+	}
+
+	for _, rule := range tv.taggedRules {
+		if isValid, _ := rule.validator.IsValid(ns); isValid && rule.predicates.Match(tags) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check if rules contain tag predicates (so metrics have to be validated with IsValidWithTags)
+func (tv *TreeValidator) HasTagPredicates() bool {
+	return len(tv.taggedRules) > 0 || (tv.excluded != nil && tv.excluded.HasTagPredicates())
+}
+
+// Check if rules contain negated rules (ie. !/plugin/debug/**)
+func (tv *TreeValidator) HasExclusions() bool {
+	return tv.excluded != nil
+}
+
+// Check if metric is matched by negated rules without tag predicates (those are evaluated by IsValidWithTags)
+func (tv *TreeValidator) isExcluded(ns string) bool {
+	if tv.excluded == nil {
+		return false
+	}
+
+	isExcluded, _ := tv.excluded.matchTree(ns, true, false)
+	return isExcluded
+}
+
 func (tv *TreeValidator) IsCompatible(ns string) bool {
 	isCompatible, _ := tv.isValid(ns, false, true)
 	return isCompatible
 }
 
 func (tv *TreeValidator) HasRules() bool {
-	return tv.head != nil
+	return tv.hasInclusions() || tv.excluded != nil
+}
+
+func (tv *TreeValidator) hasInclusions() bool {
+	return tv.head != nil || len(tv.taggedRules) > 0
 }
 
 func (tv *TreeValidator) isValid(ns string, fullMatch bool, compatibilityMode bool) (bool, []string) {
+	isValid, groupIndicator := tv.matchTree(ns, fullMatch, compatibilityMode)
+	if isValid {
+		return true, groupIndicator
+	}
+
+	for _, rule := range tv.taggedRules {
+		if isValid, trace := rule.validator.isValid(ns, fullMatch, compatibilityMode); isValid {
+			return true, trace
+		}
+	}
+
+	return false, groupIndicator
+}
+
+func (tv *TreeValidator) matchTree(ns string, fullMatch bool, compatibilityMode bool) (bool, []string) {
 	if compatibilityMode && tv.strategy != metricDefinitionStrategy {
 		panic("compatibilityMode can be only used for definition tree")
 	}
@@ -168,7 +301,7 @@ func (tv *TreeValidator) isValid(ns string, fullMatch bool, compatibilityMode bo
 
 	// special case - no rules defined - everything is valid and there are no groups (2nd param contains empty strings)
 	if tv.head == nil {
-		return true, groupIndicator
+		return !tv.hasInclusions(), groupIndicator
 	}
 
 	toVisit := nodeStack{}
@@ -217,11 +350,15 @@ func (tv *TreeValidator) isValid(ns string, fullMatch bool, compatibilityMode bo
 }
 
 func (tv *TreeValidator) ListRules() []string {
-	if tv.head == nil {
-		return []string{}
+	nsList := []string{}
+	for _, rule := range tv.taggedRules {
+		nsList = append(nsList, rule.selector)
 	}
 
-	var nsList []string
+	if tv.head == nil {
+		sort.Strings(nsList)
+		return nsList
+	}
 
 	toVisit := nodeStack{}
 	toVisit.Push(tv.head)
@@ -240,9 +377,61 @@ func (tv *TreeValidator) ListRules() []string {
 	}
 
 	sort.Strings(nsList)
+	return append(nsList, tv.listExclusions()...)
+}
+
+// Negated rules are listed with "!" prefix (after other rules)
+func (tv *TreeValidator) listExclusions() []string {
+	if tv.excluded == nil {
+		return nil
+	}
+
+	var nsList []string
+	for _, rule := range tv.excluded.ListRules() {
+		nsList = append(nsList, exclusionIndicator+rule)
+	}
+
 	return nsList
 }
 
+// Check if all metrics which namespace starts with ns are matched by rules (ie. /plugin/debug by /plugin/debug/**)
+func (tv *TreeValidator) coversSubtree(ns string) bool {
+	nsElems, _, err := SplitNamespace(ns)
+	if err != nil || tv.head == nil {
+		return false
+	}
+	nsElems = nsElems[1:]
+
+	toVisit := nodeStack{}
+	toVisit.Push(tv.head)
+
+	for !toVisit.Empty() {
+		visitedNode, _ := toVisit.Pop()
+		_, isRecursiveAny := visitedNode.currentElement.(*staticRecursiveAnyElement)
+
+		if visitedNode.level >= len(nsElems) { // node directly below namespace
+			if isRecursiveAny {
+				return true
+			}
+			continue
+		}
+
+		if !visitedNode.currentElement.Match(nsElems[visitedNode.level]) {
+			continue
+		}
+
+		if isRecursiveAny {
+			return true
+		}
+
+		for _, subNode := range visitedNode.subNodes {
+			toVisit.Push(subNode)
+		}
+	}
+
+	return false
+}
+
 // this function looks where to put new namespace elements and if tree conditions are met, updates the tree
 func (tv *TreeValidator) updateTree(parsedNs *Namespace) error {
 	// special case - tree doesn't contain anything
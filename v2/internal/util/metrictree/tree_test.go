@@ -197,3 +197,80 @@ func TestMetricFilterValidator_MetricDefinition(t *testing.T) {
 	})
 
 }
+
+func TestMetricFilterValidator_Exclusions(t *testing.T) {
+
+	Convey("Validate that negated rules take precedence over other filtering rules", t, func() {
+		d := NewMetricDefinition()
+		v := NewMetricFilter(d)
+
+		So(d.AddRule("/plugin/group1/[dyn1]/metric1"), ShouldBeNil)
+		So(d.AddRule("/plugin/group1/[dyn1]/metric2"), ShouldBeNil)
+		So(d.AddRule("/plugin/debug/sub1/metric1"), ShouldBeNil)
+		So(d.AddRule("/plugin/debug/sub2/metric2"), ShouldBeNil)
+
+		// Add valid rules
+		So(v.AddRule("/plugin/**"), ShouldBeNil)
+		So(v.AddRule("!/plugin/debug/**"), ShouldBeNil)
+		So(v.AddRule("!/plugin/group1/[dyn1=id2]/metric2"), ShouldBeNil)
+
+		// Add invalid rules (not compatible with definitions or malformed)
+		So(v.AddRule("!/plugin/group2/**"), ShouldBeError)
+		So(v.AddRule("!!/plugin/debug/**"), ShouldBeError)
+		So(v.AddRule("!"), ShouldBeError)
+		So(d.AddRule("!/plugin/debug/sub1/metric1"), ShouldBeError)
+
+		So(v.HasExclusions(), ShouldBeTrue)
+		So(v.ListRules(), ShouldResemble, []string{"/plugin/**", "!/plugin/debug/**", "!/plugin/group1/[dyn1=id2]/metric2"})
+
+		validMetricsToAdd := []string{
+			"/plugin/group1/[dyn1=id1]/metric1",
+			"/plugin/group1/[dyn1=id1]/metric2",
+			"/plugin/group1/[dyn1=id2]/metric1",
+		}
+
+		for _, mt := range validMetricsToAdd {
+			ok, _ := v.IsValid(mt)
+			So(ok, ShouldBeTrue)
+			So(v.IsValidWithTags(mt, nil), ShouldBeTrue)
+		}
+
+		invalidMetricsToAdd := []string{
+			"/plugin/debug/sub1/metric1",
+			"/plugin/debug/sub2/metric2",
+			"/plugin/group1/[dyn1=id2]/metric2",
+		}
+
+		for _, mt := range invalidMetricsToAdd {
+			ok, _ := v.IsValid(mt)
+			So(ok, ShouldBeFalse)
+			So(v.IsValidWithTags(mt, nil), ShouldBeFalse)
+		}
+
+		// Excluded subtrees are skipped early
+		So(v.IsPartiallyValid("/plugin/debug"), ShouldBeFalse)
+		So(v.IsPartiallyValid("/plugin/debug/sub1"), ShouldBeFalse)
+		So(v.IsPartiallyValid("/plugin/group1"), ShouldBeTrue)
+		So(v.IsPartiallyValid("/plugin/group1/[dyn1=id2]"), ShouldBeTrue)
+	})
+
+	Convey("Validate that only negated rules are applied to all metrics", t, func() {
+		v := NewMetricFilter(NewMetricDefinition())
+
+		So(v.AddRule("!/plugin/debug/**"), ShouldBeNil)
+		So(v.AddRule("!/plugin/disk/**{device=~loop.*}"), ShouldBeNil)
+		So(v.HasRules(), ShouldBeTrue)
+		So(v.HasTagPredicates(), ShouldBeTrue)
+
+		ok, _ := v.IsValid("/plugin/cpu/usage")
+		So(ok, ShouldBeTrue)
+		ok, _ = v.IsValid("/plugin/debug/counter")
+		So(ok, ShouldBeFalse)
+		ok, _ = v.IsValid("/plugin/disk/io") // tags are not known yet
+		So(ok, ShouldBeTrue)
+
+		So(v.IsValidWithTags("/plugin/disk/io", map[string]string{"device": "sda"}), ShouldBeTrue)
+		So(v.IsValidWithTags("/plugin/disk/io", map[string]string{"device": "loop0"}), ShouldBeFalse)
+	})
+
+}
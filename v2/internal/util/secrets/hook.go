@@ -0,0 +1,74 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var hookedLoggers sync.Map // *logrus.Logger -> struct{}
+
+// LogHook redacts registered secret values from log messages and fields
+type LogHook struct{}
+
+func (LogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (LogHook) Fire(entry *logrus.Entry) error {
+	entry.Message = Redact(entry.Message)
+
+	// entry has its own copy of fields (logrus duplicates entry before firing hooks)
+	for k, v := range entry.Data {
+		switch tv := v.(type) {
+		case string:
+			entry.Data[k] = Redact(tv)
+		case error:
+			if msg := Redact(tv.Error()); msg != tv.Error() {
+				entry.Data[k] = errors.New(msg)
+			}
+		case fmt.Stringer:
+			if msg := Redact(tv.String()); msg != tv.String() {
+				entry.Data[k] = msg
+			}
+		}
+	}
+
+	return nil
+}
+
+// Install LogHook in logger used by plugin (at most once per logger)
+func AddLogHook(logger logrus.FieldLogger) {
+	var l *logrus.Logger
+
+	switch tl := logger.(type) {
+	case *logrus.Logger:
+		l = tl
+	case *logrus.Entry:
+		l = tl.Logger
+	default:
+		return
+	}
+
+	if _, loaded := hookedLoggers.LoadOrStore(l, struct{}{}); !loaded {
+		l.AddHook(LogHook{})
+	}
+}
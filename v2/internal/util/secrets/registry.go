@@ -0,0 +1,153 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package secrets
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Shorter values are not redacted from logs (it would garble the output without protecting anything)
+const minRedactedLength = 4
+
+var defaultRegistry = newRegistry()
+
+// Registry holds secret values of loaded tasks (the same value may be registered by many tasks)
+type registry struct {
+	mutex    sync.RWMutex
+	values   map[string]int // value -> number of registrations
+	replacer *strings.Replacer
+}
+
+func newRegistry() *registry {
+	return &registry{
+		values: map[string]int{},
+	}
+}
+
+// Registration keeps secret values redacted until it's released
+type Registration struct {
+	r       *registry
+	values  []string
+	release sync.Once
+}
+
+// Register secret values, so they are redacted from logs, errors and debug output (until Release is called)
+func Register(values ...string) *Registration {
+	return defaultRegistry.register(values)
+}
+
+// Replace all registered secret values found in string with Mask
+func Redact(s string) string {
+	return defaultRegistry.redact(s)
+}
+
+// Return error with redacted message (or the same error when it doesn't contain any secret)
+func RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+	if redacted := Redact(msg); redacted != msg {
+		return errors.New(redacted)
+	}
+
+	return err
+}
+
+func (r *registry) register(values []string) *Registration {
+	reg := &Registration{r: r}
+
+	for _, v := range values {
+		if len(v) >= minRedactedLength {
+			reg.values = append(reg.values, v)
+		}
+	}
+
+	if len(reg.values) == 0 {
+		return reg
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, v := range reg.values {
+		r.values[v]++
+	}
+	r.rebuild()
+
+	return reg
+}
+
+// Stop redacting values (unless they are still registered by other task). Safe to call many times and on nil.
+func (reg *Registration) Release() {
+	if reg == nil || len(reg.values) == 0 {
+		return
+	}
+
+	reg.release.Do(func() {
+		r := reg.r
+
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		for _, v := range reg.values {
+			r.values[v]--
+			if r.values[v] <= 0 {
+				delete(r.values, v)
+			}
+		}
+		r.rebuild()
+	})
+}
+
+func (r *registry) redact(s string) string {
+	r.mutex.RLock()
+	replacer := r.replacer
+	r.mutex.RUnlock()
+
+	if replacer == nil {
+		return s
+	}
+
+	return replacer.Replace(s)
+}
+
+func (r *registry) rebuild() {
+	if len(r.values) == 0 {
+		r.replacer = nil
+		return
+	}
+
+	values := make([]string, 0, len(r.values))
+	for v := range r.values {
+		values = append(values, v)
+	}
+
+	// longer values first, so the secret containing other secret is fully masked
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	oldnew := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		oldnew = append(oldnew, v, Mask)
+	}
+
+	r.replacer = strings.NewReplacer(oldnew...)
+}
@@ -0,0 +1,139 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	envReference  = "env"
+	fileReference = "file"
+)
+
+// Matches ${env:VAR} and ${file:/path}. Reference preceded by additional '$' (ie. $${env:VAR}) is left as literal text.
+var referencePattern = regexp.MustCompile(`\$?\$\{(env|file):([^}]*)\}`)
+
+// Replace references to environment variables (${env:VAR}) and files (${file:/path}) found in string values of
+// configuration (JSON). Returns resolved configuration and paths of values containing references (treated as secrets).
+// Configuration without references is returned without any changes.
+func ResolveReferences(rawConfig []byte) ([]byte, []string, error) {
+	if !bytes.Contains(rawConfig, []byte("${")) {
+		return rawConfig, nil, nil
+	}
+
+	var cfg interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawConfig))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, nil, fmt.Errorf("can't resolve references due to invalid json: %v", err)
+	}
+
+	r := resolver{}
+	cfg = r.resolve(cfg, "")
+
+	if len(r.errs) != 0 {
+		return nil, nil, fmt.Errorf("can't resolve references: %s", strings.Join(r.errs, "; "))
+	}
+
+	resolved, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't resolve references: %v", err)
+	}
+
+	return resolved, r.paths, nil
+}
+
+type resolver struct {
+	paths []string
+	errs  []string
+}
+
+func (r *resolver) resolve(v interface{}, path string) interface{} {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for key, child := range tv {
+			tv[key] = r.resolve(child, joinPath(path, key))
+		}
+	case []interface{}:
+		for i, el := range tv {
+			tv[i] = r.resolve(el, path)
+		}
+	case string:
+		return r.resolveString(tv, path)
+	}
+
+	return v
+}
+
+func (r *resolver) resolveString(s string, path string) string {
+	resolved := false
+
+	res := referencePattern.ReplaceAllStringFunc(s, func(ref string) string {
+		if strings.HasPrefix(ref, "$$") {
+			return ref[1:] // escaped reference
+		}
+
+		sm := referencePattern.FindStringSubmatch(ref)
+		kind, name := sm[1], sm[2]
+
+		value, err := lookupReference(kind, name)
+		if err != nil {
+			r.errs = append(r.errs, fmt.Sprintf("field %s: %v", path, err))
+			return ""
+		}
+
+		resolved = true
+		return value
+	})
+
+	if resolved && (len(r.paths) == 0 || r.paths[len(r.paths)-1] != path) {
+		r.paths = append(r.paths, path)
+	}
+
+	return res
+}
+
+func lookupReference(kind, name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("empty %s reference", kind)
+	}
+
+	switch kind {
+	case envReference:
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	case fileReference:
+		content, err := ioutil.ReadFile(name)
+		if err != nil {
+			return "", fmt.Errorf("can't read file %s: %v", name, err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	return "", fmt.Errorf("unknown reference type %s", kind)
+}
@@ -0,0 +1,139 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+/*
+Package secrets:
+* decides which configuration keys hold secrets (declared with ConfigSecret or matched by naming pattern)
+* resolves references to environment variables (${env:VAR}) and files (${file:/path}) in configuration
+* masks secret values in configuration presented to the user (ie. in statistics)
+* keeps registry of secret values and redacts them from logs, errors and debug output
+*/
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	Mask = "*****"
+
+	pathSeparator = "."
+)
+
+// Last element of a configuration path matching pattern is treated as secret, ie. "db.password", "auth.apiKey"
+var secretKeyPattern = regexp.MustCompile(`(?i)(password|passwd|passphrase|secret|token|api_?key|private_?key|credential)`)
+
+// Keys ending with one of the words hold settings related to secret rather than secret itself, ie. "token_ttl", "token_file"
+var nonSecretKeySuffix = regexp.MustCompile(`(?i)(ttl|enabled?|file|path|dir|url|length|timeout|expiry|expiration|type|name|header)$`)
+
+// Check if configuration key (path elements separated with '.') holds secret according to naming pattern
+func IsSecretKey(path string) bool {
+	pathElems := strings.Split(path, pathSeparator)
+	key := pathElems[len(pathElems)-1]
+
+	return secretKeyPattern.MatchString(key) && !nonSecretKeySuffix.MatchString(key)
+}
+
+// Return copy of configuration (JSON) in which values of secret keys are replaced with Mask, and secret values
+// themselves. When secret key holds an object or an array, the whole value is masked. Only string values are returned
+// (numbers and booleans are masked, but they are too common to be redacted from logs and errors).
+func Scan(rawConfig []byte, isSecret func(path string) bool) ([]byte, []string, error) {
+	if len(bytes.TrimSpace(rawConfig)) == 0 {
+		return rawConfig, nil, nil
+	}
+
+	var cfg interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawConfig))
+	decoder.UseNumber()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, nil, fmt.Errorf("can't scan configuration for secrets due to invalid json: %v", err)
+	}
+
+	var values []string
+	found := maskSecrets(cfg, "", isSecret, &values)
+	if !found {
+		return rawConfig, nil, nil
+	}
+
+	redacted, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't mask secrets in configuration: %v", err)
+	}
+
+	return redacted, values, nil
+}
+
+func maskSecrets(v interface{}, path string, isSecret func(string) bool, values *[]string) bool {
+	found := false
+
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		for key, child := range tv {
+			childPath := joinPath(path, key)
+			if isSecret(childPath) {
+				*values = append(*values, leafValues(child)...)
+				tv[key] = Mask
+				found = true
+				continue
+			}
+
+			if maskSecrets(child, childPath, isSecret, values) {
+				found = true
+			}
+		}
+	case []interface{}:
+		for _, el := range tv {
+			if maskSecrets(el, path, isSecret, values) {
+				found = true
+			}
+		}
+	}
+
+	return found
+}
+
+func leafValues(v interface{}) []string {
+	switch tv := v.(type) {
+	case map[string]interface{}:
+		var res []string
+		for _, child := range tv {
+			res = append(res, leafValues(child)...)
+		}
+		return res
+	case []interface{}:
+		var res []string
+		for _, el := range tv {
+			res = append(res, leafValues(el)...)
+		}
+		return res
+	case string:
+		return []string{tv}
+	default:
+		return nil
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + pathSeparator + key
+}
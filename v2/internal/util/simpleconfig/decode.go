@@ -0,0 +1,441 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package simpleconfig
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pathSeparator = "."
+
+	nameTag         = "json"
+	defaultTag      = "default"
+	defaultListSep  = ","
+	skipFieldTagVal = "-"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+	errKeyNotFound = errors.New("key not found")
+)
+
+// Parse json into a tree of objects (map[string]interface{}, []interface{}, json.Number, string, bool, nil)
+func ParseJSON(rawConfig []byte) (map[string]interface{}, error) {
+	tree := map[string]interface{}{}
+
+	decoder := json.NewDecoder(bytes.NewReader(rawConfig))
+	decoder.UseNumber()
+
+	err := decoder.Decode(&tree)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse configuration: %v", err)
+	}
+
+	return tree, nil
+}
+
+// Decode configuration tree (see ParseJSON) into structure, map, slice or simple type pointed by dest.
+//
+// Notes:
+// * structure fields are matched by `json` tag (or field name, case-insensitively),
+// * `default` tag provides value used when field is missing in configuration (lists are given as comma separated values),
+// * time.Duration should be provided as a string accepted by time.ParseDuration (ie. "10s"),
+// * numbers and booleans provided as strings (ie. "10", "true") are accepted,
+// * returned error contains paths of all invalid fields (ie. "server.port", "devices[1]").
+func Unmarshal(tree map[string]interface{}, dest interface{}) error {
+	vDest := reflect.ValueOf(dest)
+	if vDest.Kind() != reflect.Ptr || vDest.IsNil() {
+		return fmt.Errorf("passed variable should be a non-nil pointer")
+	}
+
+	d := &decoder{}
+	d.decode("", tree, vDest.Elem())
+
+	return d.err()
+}
+
+// Decode element of configuration tree pointed by key (path elements separated with '.', ie. "server.port")
+func UnmarshalKey(tree map[string]interface{}, key string, dest interface{}) error {
+	vDest := reflect.ValueOf(dest)
+	if vDest.Kind() != reflect.Ptr || vDest.IsNil() {
+		return fmt.Errorf("passed variable should be a non-nil pointer")
+	}
+
+	src, err := lookup(tree, key)
+	if err != nil {
+		return fmt.Errorf("config field %s: %v", key, err)
+	}
+
+	d := &decoder{}
+	d.decode(key, src, vDest.Elem())
+
+	return d.err()
+}
+
+func lookup(tree map[string]interface{}, key string) (interface{}, error) {
+	var node interface{} = tree
+
+	for _, el := range strings.Split(key, pathSeparator) {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, errKeyNotFound
+		}
+
+		node, ok = m[el]
+		if !ok {
+			return nil, errKeyNotFound
+		}
+	}
+
+	return node, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type decoder struct {
+	errs []string
+}
+
+func (d *decoder) fail(path string, format string, args ...interface{}) {
+	if path == "" {
+		path = "<root>"
+	}
+
+	d.errs = append(d.errs, fmt.Sprintf("config field %s: %s", path, fmt.Sprintf(format, args...)))
+}
+
+func (d *decoder) err() error {
+	if len(d.errs) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(d.errs, "; "))
+}
+
+func (d *decoder) decode(path string, src interface{}, dst reflect.Value) {
+	if src == nil {
+		return // null in json - leave destination unchanged
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		d.decode(path, src, dst.Elem())
+		return
+	}
+
+	if s, ok := src.(string); ok && dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshalerType) {
+		err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		if err != nil {
+			d.fail(path, "%v", err)
+		}
+		return
+	}
+
+	if dst.Type() == durationType {
+		d.decodeDuration(path, src, dst)
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		d.decodeStruct(path, src, dst)
+	case reflect.Map:
+		d.decodeMap(path, src, dst)
+	case reflect.Slice:
+		d.decodeSlice(path, src, dst)
+	case reflect.Interface:
+		if dst.NumMethod() != 0 {
+			d.fail(path, "unsupported destination type %s", dst.Type())
+			return
+		}
+		dst.Set(reflect.ValueOf(plainValue(src)))
+	default:
+		d.decodeScalar(path, src, dst)
+	}
+}
+
+func (d *decoder) decodeStruct(path string, src interface{}, dst reflect.Value) {
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		d.fail(path, "expected object, got %s", describe(src))
+		return
+	}
+
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := dst.Field(i)
+
+		if sf.PkgPath != "" && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue // unexported (fields of embedded structure are still promoted)
+		}
+
+		name, skip := fieldName(sf)
+		if skip {
+			continue
+		}
+
+		if sf.Anonymous && name == "" && indirectType(sf.Type).Kind() == reflect.Struct {
+			d.decode(path, m, fv) // embedded structure shares keys with parent
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		fieldPath := joinPath(path, name)
+
+		value, found := findKey(m, name)
+		if found {
+			d.decode(fieldPath, value, fv)
+			continue
+		}
+
+		if defValue, ok := sf.Tag.Lookup(defaultTag); ok {
+			d.decodeDefault(fieldPath, defValue, fv)
+			continue
+		}
+
+		if fv.Kind() == reflect.Struct && fv.Type() != durationType {
+			d.decode(fieldPath, map[string]interface{}{}, fv) // apply defaults of nested structure
+		}
+	}
+}
+
+func (d *decoder) decodeDefault(path string, defValue string, dst reflect.Value) {
+	if indirectType(dst.Type()).Kind() == reflect.Slice {
+		var list []interface{}
+		if defValue != "" {
+			for _, el := range strings.Split(defValue, defaultListSep) {
+				list = append(list, strings.TrimSpace(el))
+			}
+		}
+
+		d.decode(path, list, dst)
+		return
+	}
+
+	d.decode(path, defValue, dst)
+}
+
+func (d *decoder) decodeMap(path string, src interface{}, dst reflect.Value) {
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		d.fail(path, "expected object, got %s", describe(src))
+		return
+	}
+
+	if dst.Type().Key().Kind() != reflect.String {
+		d.fail(path, "unsupported destination type %s (map key should be a string)", dst.Type())
+		return
+	}
+
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+
+	for k, v := range m {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		d.decode(joinPath(path, k), v, elem)
+		dst.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+	}
+}
+
+func (d *decoder) decodeSlice(path string, src interface{}, dst reflect.Value) {
+	list, ok := src.([]interface{})
+	if !ok {
+		d.fail(path, "expected list, got %s", describe(src))
+		return
+	}
+
+	slice := reflect.MakeSlice(dst.Type(), len(list), len(list))
+	for i, v := range list {
+		d.decode(fmt.Sprintf("%s[%d]", path, i), v, slice.Index(i))
+	}
+
+	dst.Set(slice)
+}
+
+func (d *decoder) decodeDuration(path string, src interface{}, dst reflect.Value) {
+	switch v := src.(type) {
+	case string:
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			d.fail(path, "expected duration (ie. \"10s\"), got %s", describe(src))
+			return
+		}
+		dst.SetInt(int64(dur))
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			d.fail(path, "expected duration (ie. \"10s\"), got %s", describe(src))
+			return
+		}
+		dst.SetInt(n) // number is treated as nanoseconds (the same as in encoding/json)
+	default:
+		d.fail(path, "expected duration (ie. \"10s\"), got %s", describe(src))
+	}
+}
+
+func (d *decoder) decodeScalar(path string, src interface{}, dst reflect.Value) {
+	text, ok := scalarText(src)
+	if !ok {
+		d.fail(path, "expected %s, got %s", dst.Kind(), describe(src))
+		return
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			d.fail(path, "expected bool, got %s", describe(src))
+			return
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, dst.Type().Bits())
+		if err != nil {
+			d.fail(path, "expected %s, got %s", dst.Kind(), describe(src))
+			return
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, dst.Type().Bits())
+		if err != nil {
+			d.fail(path, "expected %s, got %s", dst.Kind(), describe(src))
+			return
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, dst.Type().Bits())
+		if err != nil {
+			d.fail(path, "expected %s, got %s", dst.Kind(), describe(src))
+			return
+		}
+		dst.SetFloat(f)
+	default:
+		d.fail(path, "unsupported destination type %s", dst.Type())
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func fieldName(sf reflect.StructField) (name string, skip bool) {
+	tag := sf.Tag.Get(nameTag)
+	if tag == skipFieldTagVal {
+		return "", true
+	}
+
+	return strings.Split(tag, ",")[0], false
+}
+
+// Find key in object (exact match is preferred, case-insensitive match is accepted - the same as in encoding/json)
+func findKey(m map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+
+	for k, v := range m {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + pathSeparator + key
+}
+
+func scalarText(src interface{}) (string, bool) {
+	switch v := src.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+
+	return "", false
+}
+
+// Convert element of configuration tree to value produced by encoding/json (json.Number -> float64)
+func plainValue(src interface{}) interface{} {
+	switch v := src.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, el := range v {
+			m[k] = plainValue(el)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, 0, len(v))
+		for _, el := range v {
+			list = append(list, plainValue(el))
+		}
+		return list
+	}
+
+	return src
+}
+
+func describe(src interface{}) string {
+	switch v := src.(type) {
+	case string:
+		return strconv.Quote(v)
+	case json.Number:
+		return v.String()
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "list"
+	}
+
+	return fmt.Sprintf("%v", src)
+}
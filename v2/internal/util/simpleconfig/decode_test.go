@@ -0,0 +1,166 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package simpleconfig
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type serverConfig struct {
+	Address string        `json:"address" default:"localhost"`
+	Port    int           `json:"port" default:"8080"`
+	IP      net.IP        `json:"ip"`
+	Timeout time.Duration `json:"timeout" default:"5s"`
+}
+
+type commonConfig struct {
+	Verbose bool `json:"verbose"`
+}
+
+type exampleConfig struct {
+	commonConfig
+
+	Server   serverConfig            `json:"server"`
+	Backups  []serverConfig          `json:"backups"`
+	Devices  []string                `json:"devices" default:"sda,sdb"`
+	Ratio    float64                 `json:"ratio" default:"0.5"`
+	Interval *time.Duration          `json:"interval"`
+	Labels   map[string]string       `json:"labels"`
+	Extra    interface{}             `json:"extra"`
+	Limits   map[string]serverConfig `json:"limits"`
+	Ignored  string                  `json:"-"`
+	Name     string
+	internal string
+}
+
+func TestUnmarshal(t *testing.T) {
+	Convey("Validate that configuration can be decoded into a structure", t, func() {
+		Convey("Values provided in configuration", func() {
+			tree, err := ParseJSON([]byte(`{
+				"verbose": true,
+				"server": {"address": "10.0.0.1", "port": "9090", "ip": "10.0.0.2", "timeout": "1m"},
+				"backups": [{"address": "10.0.0.3"}, {"port": 10}],
+				"devices": ["sdc"],
+				"ratio": 0.25,
+				"interval": "30s",
+				"labels": {"env": "prod"},
+				"extra": {"a": [1, "b"]},
+				"Ignored": "value",
+				"name": "example"
+			}`))
+			So(err, ShouldBeNil)
+
+			cfg := exampleConfig{}
+			err = Unmarshal(tree, &cfg)
+			So(err, ShouldBeNil)
+
+			So(cfg.Verbose, ShouldBeTrue)
+			So(cfg.Server, ShouldResemble, serverConfig{Address: "10.0.0.1", Port: 9090, IP: net.ParseIP("10.0.0.2"), Timeout: time.Minute})
+			So(cfg.Backups, ShouldResemble, []serverConfig{
+				{Address: "10.0.0.3", Port: 8080, Timeout: 5 * time.Second},
+				{Address: "localhost", Port: 10, Timeout: 5 * time.Second},
+			})
+			So(cfg.Devices, ShouldResemble, []string{"sdc"})
+			So(cfg.Ratio, ShouldEqual, 0.25)
+			So(*cfg.Interval, ShouldEqual, 30*time.Second)
+			So(cfg.Labels, ShouldResemble, map[string]string{"env": "prod"})
+			So(cfg.Extra, ShouldResemble, map[string]interface{}{"a": []interface{}{1.0, "b"}})
+			So(cfg.Ignored, ShouldEqual, "")
+			So(cfg.Name, ShouldEqual, "example")
+		})
+
+		Convey("Default values are used when fields are missing", func() {
+			tree, err := ParseJSON([]byte(`{}`))
+			So(err, ShouldBeNil)
+
+			cfg := exampleConfig{}
+			err = Unmarshal(tree, &cfg)
+			So(err, ShouldBeNil)
+
+			So(cfg.Server, ShouldResemble, serverConfig{Address: "localhost", Port: 8080, Timeout: 5 * time.Second})
+			So(cfg.Devices, ShouldResemble, []string{"sda", "sdb"})
+			So(cfg.Ratio, ShouldEqual, 0.5)
+			So(cfg.Interval, ShouldBeNil)
+			So(cfg.Backups, ShouldBeNil)
+		})
+
+		Convey("Errors contain paths of invalid fields", func() {
+			tree, err := ParseJSON([]byte(`{
+				"server": {"port": "abc", "timeout": 10.5},
+				"backups": [{"port": 1}, {"address": ["a"]}],
+				"devices": "sda",
+				"labels": {"env": {"a": 1}}
+			}`))
+			So(err, ShouldBeNil)
+
+			cfg := exampleConfig{}
+			err = Unmarshal(tree, &cfg)
+			So(err, ShouldBeError)
+			So(err.Error(), ShouldContainSubstring, `config field server.port: expected int, got "abc"`)
+			So(err.Error(), ShouldContainSubstring, `config field server.timeout: expected duration`)
+			So(err.Error(), ShouldContainSubstring, `config field backups[1].address: expected string, got list`)
+			So(err.Error(), ShouldContainSubstring, `config field devices: expected list, got "sda"`)
+			So(err.Error(), ShouldContainSubstring, `config field labels.env: expected string, got object`)
+		})
+
+		Convey("Destination should be a non-nil pointer", func() {
+			cfg := exampleConfig{}
+			So(Unmarshal(map[string]interface{}{}, cfg), ShouldBeError)
+		})
+	})
+}
+
+func TestUnmarshalKey(t *testing.T) {
+	Convey("Validate that single configuration value can be decoded", t, func() {
+		tree, err := ParseJSON([]byte(`{"server": {"port": 8080, "debug": "true", "timeout": "10s"}, "devices": ["sda", "sdb"], "ratio": 1.5}`))
+		So(err, ShouldBeNil)
+
+		var port int
+		So(UnmarshalKey(tree, "server.port", &port), ShouldBeNil)
+		So(port, ShouldEqual, 8080)
+
+		var debug bool
+		So(UnmarshalKey(tree, "server.debug", &debug), ShouldBeNil)
+		So(debug, ShouldBeTrue)
+
+		var timeout time.Duration
+		So(UnmarshalKey(tree, "server.timeout", &timeout), ShouldBeNil)
+		So(timeout, ShouldEqual, 10*time.Second)
+
+		var devices []string
+		So(UnmarshalKey(tree, "devices", &devices), ShouldBeNil)
+		So(devices, ShouldResemble, []string{"sda", "sdb"})
+
+		var ratio int
+		err = UnmarshalKey(tree, "ratio", &ratio)
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldEqual, "config field ratio: expected int, got 1.5")
+
+		err = UnmarshalKey(tree, "server.address", &devices)
+		So(err, ShouldBeError)
+		So(err.Error(), ShouldEqual, "config field server.address: key not found")
+
+		err = UnmarshalKey(tree, "devices.name", &devices)
+		So(err, ShouldBeError)
+	})
+}
@@ -0,0 +1,273 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+/*
+Package statestore implements persistent state of tasks (plugin.PersistentState).
+
+State of each task is kept in a separate JSON file (<state-dir>/<plugin-name>/task-<hex encoded task id>.json).
+File is rewritten atomically (temporary file + rename) on each modification.
+*/
+package statestore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	SchemaVersion = 1 // version of state file format
+
+	stateFilePrefix = "task-"
+	stateFileExt    = ".json"
+	tmpFileExt      = ".tmp"
+)
+
+var ErrNotAvailable = errors.New("persistent state is not available")
+
+type stateFile struct {
+	Version int                        `json:"version"`
+	TaskID  string                     `json:"task_id"`
+	Values  map[string]json.RawMessage `json:"values"`
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Store struct {
+	dir string
+}
+
+// Create store keeping state of plugin tasks in a subdirectory of stateDir
+func NewStore(stateDir string, pluginName string) *Store {
+	return &Store{
+		dir: filepath.Join(stateDir, sanitizeName(pluginName)),
+	}
+}
+
+// Load state of a given task. Errors (ie. when file is corrupted) are returned by methods of returned object.
+func (s *Store) TaskState(taskID string) *TaskState {
+	ts := &TaskState{
+		taskID: taskID,
+		path:   filepath.Join(s.dir, stateFilePrefix+hex.EncodeToString([]byte(taskID))+stateFileExt),
+		values: map[string]json.RawMessage{},
+	}
+
+	ts.err = ts.load()
+	return ts
+}
+
+// State returned when store is not configured
+func UnavailableState() *TaskState {
+	return &TaskState{
+		err: ErrNotAvailable,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type TaskState struct {
+	mu     sync.RWMutex
+	taskID string
+	path   string
+	values map[string]json.RawMessage
+	err    error // error which occurred when state was loaded
+}
+
+func (ts *TaskState) Store(key string, value interface{}) error {
+	rawValue, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("can't serialize value of %s: %v", key, err)
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.err != nil {
+		return ts.err
+	}
+
+	prevValue, existed := ts.values[key]
+	ts.values[key] = rawValue
+
+	err = ts.save()
+	if err != nil {
+		if existed {
+			ts.values[key] = prevValue
+		} else {
+			delete(ts.values, key)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (ts *TaskState) LoadTo(key string, dest interface{}) error {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	if ts.err != nil {
+		return ts.err
+	}
+
+	rawValue, ok := ts.values[key]
+	if !ok {
+		return fmt.Errorf("couldn't find object with a given key (%s)", key)
+	}
+
+	err := json.Unmarshal(rawValue, dest)
+	if err != nil {
+		return fmt.Errorf("can't deserialize value of %s: %v", key, err)
+	}
+
+	return nil
+}
+
+func (ts *TaskState) Has(key string) bool {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	_, ok := ts.values[key]
+	return ok
+}
+
+func (ts *TaskState) Delete(key string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.err != nil {
+		return ts.err
+	}
+
+	prevValue, ok := ts.values[key]
+	if !ok {
+		return nil
+	}
+
+	delete(ts.values, key)
+
+	err := ts.save()
+	if err != nil {
+		ts.values[key] = prevValue
+		return err
+	}
+
+	return nil
+}
+
+func (ts *TaskState) Keys() []string {
+	ts.mu.RLock()
+	defer ts.mu.RUnlock()
+
+	keys := make([]string, 0, len(ts.values))
+	for k := range ts.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (ts *TaskState) load() error {
+	content, err := ioutil.ReadFile(ts.path)
+	if os.IsNotExist(err) {
+		return nil // no state saved yet
+	}
+	if err != nil {
+		return fmt.Errorf("can't read persistent state: %v", err)
+	}
+
+	sf := stateFile{}
+	err = json.Unmarshal(content, &sf)
+	if err != nil {
+		return fmt.Errorf("can't read persistent state (%s): %v", ts.path, err)
+	}
+
+	if sf.Version > SchemaVersion {
+		return fmt.Errorf("can't read persistent state (%s): unsupported version %d (max. supported: %d)", ts.path, sf.Version, SchemaVersion)
+	}
+
+	if sf.Values != nil {
+		ts.values = sf.Values
+	}
+
+	return nil
+}
+
+// Write state to temporary file which replaces the previous one, so file on disk is always complete
+func (ts *TaskState) save() error {
+	content, err := json.Marshal(stateFile{
+		Version: SchemaVersion,
+		TaskID:  ts.taskID,
+		Values:  ts.values,
+	})
+	if err != nil {
+		return fmt.Errorf("can't serialize persistent state: %v", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(ts.path), 0700)
+	if err != nil {
+		return fmt.Errorf("can't create state directory: %v", err)
+	}
+
+	tmpPath := ts.path + tmpFileExt
+
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("can't write persistent state: %v", err)
+	}
+
+	_, err = f.Write(content)
+	if err == nil {
+		err = f.Sync()
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("can't write persistent state: %v", err)
+	}
+
+	err = os.Rename(tmpPath, ts.path)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("can't write persistent state: %v", err)
+	}
+
+	return nil
+}
+
+func sanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	if name == "" || name == "." || name == ".." {
+		return "_"
+	}
+	return name
+}
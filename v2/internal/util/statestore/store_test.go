@@ -0,0 +1,114 @@
+// +build small
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package statestore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type cursor struct {
+	File   string `json:"file"`
+	Offset int64  `json:"offset"`
+}
+
+func TestTaskState(t *testing.T) {
+	Convey("Validate that task state is persisted", t, func() {
+		stateDir, _ := ioutil.TempDir("", "state")
+		defer os.RemoveAll(stateDir)
+
+		store := NewStore(stateDir, "test/plugin")
+
+		Convey("Values are available after state is loaded again", func() {
+			// Act
+			ts := store.TaskState("task-1")
+			So(ts.Store("cursor", cursor{File: "/var/log/syslog", Offset: 1024}), ShouldBeNil)
+			So(ts.Store("count", 5), ShouldBeNil)
+			So(ts.Store("tmp", true), ShouldBeNil)
+			So(ts.Delete("tmp"), ShouldBeNil)
+			So(ts.Delete("unknown"), ShouldBeNil)
+
+			// Assert
+			reloaded := store.TaskState("task-1")
+			So(reloaded.Keys(), ShouldResemble, []string{"count", "cursor"})
+
+			c := cursor{}
+			So(reloaded.LoadTo("cursor", &c), ShouldBeNil)
+			So(c, ShouldResemble, cursor{File: "/var/log/syslog", Offset: 1024})
+
+			So(reloaded.Has("tmp"), ShouldBeFalse)
+			So(reloaded.LoadTo("tmp", &c), ShouldBeError)
+
+			So(store.TaskState("task-2").Keys(), ShouldBeEmpty)
+
+			tmpFiles, _ := filepath.Glob(filepath.Join(stateDir, "*", "*"+tmpFileExt))
+			So(tmpFiles, ShouldBeEmpty)
+		})
+
+		Convey("Values which can't be serialized are rejected", func() {
+			ts := store.TaskState("task-1")
+			So(ts.Store("fn", func() {}), ShouldBeError)
+			So(ts.Has("fn"), ShouldBeFalse)
+		})
+
+		Convey("State can be modified concurrently", func() {
+			ts := store.TaskState("task-1")
+
+			wg := sync.WaitGroup{}
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_ = ts.Store(fmt.Sprintf("key-%d", i), i)
+				}(i)
+			}
+			wg.Wait()
+
+			So(store.TaskState("task-1").Keys(), ShouldHaveLength, 10)
+		})
+
+		Convey("State written in unsupported version isn't modified", func() {
+			ts := store.TaskState("task-1")
+			So(ts.Store("count", 5), ShouldBeNil)
+
+			content := []byte(fmt.Sprintf(`{"version": %d, "values": {"count": 5}}`, SchemaVersion+1))
+			So(ioutil.WriteFile(ts.path, content, 0600), ShouldBeNil)
+
+			newerTs := store.TaskState("task-1")
+			So(newerTs.Store("count", 6), ShouldBeError)
+			So(newerTs.LoadTo("count", new(int)), ShouldBeError)
+
+			storedContent, _ := ioutil.ReadFile(ts.path)
+			So(storedContent, ShouldResemble, content)
+		})
+	})
+
+	Convey("Validate that unavailable state returns errors", t, func() {
+		ts := UnavailableState()
+		So(ts.Store("count", 1), ShouldEqual, ErrNotAvailable)
+		So(ts.LoadTo("count", new(int)), ShouldEqual, ErrNotAvailable)
+		So(ts.Keys(), ShouldBeEmpty)
+	})
+}
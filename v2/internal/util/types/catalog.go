@@ -0,0 +1,36 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package types
+
+import "github.com/solarwinds/snap-plugin-lib/v2/plugin"
+
+// Metric declared by plugin (see plugin.CollectorDefinition), element of metrics catalog
+type MetricDefinition struct {
+	Namespace   string // ie. /plugin/[disk]/io_time
+	Groups      []MetricGroup
+	Description string
+	Unit        string
+	IsDefault   bool
+	Kind        plugin.MetricKind
+}
+
+// Dynamic element of metric namespace (see plugin.CollectorDefinition.DefineGroup)
+type MetricGroup struct {
+	Name        string
+	Description string
+	Position    int // index of namespace element
+}
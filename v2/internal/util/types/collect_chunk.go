@@ -16,8 +16,14 @@
 
 package types
 
+import "errors"
+
+// Error returned when collect request didn't complete within configured timeout (chunk contains partial results)
+var ErrCollectTimeout = errors.New("collect timeout exceeded")
+
 type CollectChunk struct {
 	Metrics  []*Metric
 	Warnings []Warning
+	Events   []*Event
 	Err      error
 }
@@ -0,0 +1,70 @@
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+type Event struct {
+	Title_     string
+	Text_      string
+	Severity_  plugin.EventSeverity
+	Tags_      map[string]string
+	Timestamp_ time.Time
+}
+
+func (e Event) Title() string {
+	return e.Title_
+}
+
+func (e Event) Text() string {
+	return e.Text_
+}
+
+func (e Event) Severity() plugin.EventSeverity {
+	return e.Severity_
+}
+
+func (e Event) Tags() map[string]string {
+	return e.Tags_
+}
+
+func (e Event) Timestamp() time.Time {
+	return e.Timestamp_
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("[%s] %s: %s {%v}", e.Severity_, e.Title_, e.Text_, e.Tags_)
+}
+
+func (e *Event) AddTags(tags map[string]string) {
+	if e.Tags_ == nil { // lazy initialization
+		e.Tags_ = map[string]string{}
+	}
+
+	for k, v := range tags {
+		e.Tags_[k] = v
+	}
+}
+
+func (e *Event) SetTimestamp(timestamp time.Time) {
+	e.Timestamp_ = timestamp
+}
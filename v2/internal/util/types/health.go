@@ -0,0 +1,53 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package types
+
+import "github.com/solarwinds/snap-plugin-lib/v2/plugin"
+
+type TaskHealth struct {
+	TaskID  string
+	Status  plugin.HealthStatus
+	Details string
+}
+
+type HealthReport struct {
+	Status       plugin.HealthStatus // the worst of plugin-wide and tasks statuses
+	PluginStatus plugin.HealthStatus
+	Details      string
+	Tasks        []TaskHealth
+}
+
+// Update overall status when given one is worse
+func (r *HealthReport) degrade(status plugin.HealthStatus) {
+	if status > r.Status {
+		r.Status = status
+	}
+}
+
+func (r *HealthReport) SetPluginHealth(status plugin.HealthStatus, details string) {
+	r.PluginStatus = status
+	r.Details = details
+	r.degrade(status)
+}
+
+func (r *HealthReport) AddTaskHealth(taskID string, status plugin.HealthStatus, details string) {
+	r.Tasks = append(r.Tasks, TaskHealth{
+		TaskID:  taskID,
+		Status:  status,
+		Details: details,
+	})
+	r.degrade(status)
+}
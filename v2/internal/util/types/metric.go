@@ -34,6 +34,9 @@ type Metric struct {
 	Unit_        string
 	Timestamp_   time.Time
 	Description_ string
+	Kind_        plugin.MetricKind
+
+	Conversion_ plugin.CounterConversion // applied by collector context (not sent)
 }
 
 func (m Metric) Namespace() plugin.Namespace {
@@ -66,6 +69,10 @@ func (m Metric) Timestamp() time.Time {
 	return m.Timestamp_
 }
 
+func (m Metric) Kind() plugin.MetricKind {
+	return m.Kind_
+}
+
 func (m Metric) String() string {
 	return fmt.Sprintf("%s %v {%v}", m.Namespace().String(), m.Value_, m.Tags_)
 }
@@ -97,3 +104,57 @@ func (m *Metric) SetUnit(unit string) {
 func (m *Metric) SetTimestamp(timestamp time.Time) {
 	m.Timestamp_ = timestamp
 }
+
+func (m *Metric) SetKind(kind plugin.MetricKind) {
+	m.Kind_ = kind
+}
+
+func (m *Metric) SetCounterConversion(conversion plugin.CounterConversion) {
+	m.Conversion_ = conversion
+}
+
+func IsValidValueType(value interface{}) bool {
+	// when adding new type(s) apply changes also in toGRPCValue() function
+	switch value.(type) {
+	case string:
+	case float64:
+	case float32:
+	case int32:
+	case int:
+	case int64:
+	case uint32:
+	case uint64:
+	case uint:
+	case []byte:
+	case bool:
+	case int16:
+	case uint16:
+	case plugin.Histogram:
+	case plugin.Summary:
+	case *plugin.Histogram:
+		return value.(*plugin.Histogram) != nil
+	case *plugin.Summary:
+		return value.(*plugin.Summary) != nil
+	case nil:
+	default:
+		return false
+	}
+
+	return true
+}
+
+// ValidateValue checks content of values which have internal structure (histograms, summaries)
+func ValidateValue(value interface{}) error {
+	switch v := value.(type) {
+	case plugin.Histogram:
+		return v.Validate()
+	case *plugin.Histogram:
+		return v.Validate()
+	case plugin.Summary:
+		return v.Validate()
+	case *plugin.Summary:
+		return v.Validate()
+	}
+
+	return nil
+}
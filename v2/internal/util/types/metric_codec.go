@@ -0,0 +1,89 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package types
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func init() {
+	// value types not known to gob by default (pointers are decoded as values)
+	gob.Register(plugin.Histogram{})
+	gob.Register(plugin.Summary{})
+}
+
+// Serialize metrics (preserving type of values), ie. to store them on disk
+func EncodeMetrics(mts []*Metric) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	err := gob.NewEncoder(&buf).Encode(mts)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode metrics: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func DecodeMetrics(data []byte) ([]*Metric, error) {
+	var mts []*Metric
+
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&mts)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode metrics: %v", err)
+	}
+
+	return mts, nil
+}
+
+// Metrics and events published together (unit of data stored in disk buffer)
+type Batch struct {
+	Metrics []*Metric
+	Events  []*Event
+}
+
+// Serialize metrics and events (preserving type of values), ie. to store them on disk
+func EncodeBatch(b Batch) ([]byte, error) {
+	buf := bytes.Buffer{}
+
+	err := gob.NewEncoder(&buf).Encode(b)
+	if err != nil {
+		return nil, fmt.Errorf("can't encode batch: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Deserialize data created with EncodeBatch (or EncodeMetrics, in which case batch contains only metrics)
+func DecodeBatch(data []byte) (Batch, error) {
+	var b Batch
+
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&b)
+	if err != nil {
+		mts, mtsErr := DecodeMetrics(data)
+		if mtsErr != nil {
+			return Batch{}, fmt.Errorf("can't decode batch: %v", err)
+		}
+
+		return Batch{Metrics: mts}, nil
+	}
+
+	return b, nil
+}
@@ -19,10 +19,13 @@
 package types
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
 
 func TestStaticMetric(t *testing.T) {
@@ -154,3 +157,138 @@ func TestDynamicMetric(t *testing.T) {
 		})
 	})
 }
+
+func TestValidateValue(t *testing.T) {
+	Convey("Validate that content of distribution values is checked", t, func() {
+		So(IsValidValueType(plugin.Histogram{}), ShouldBeTrue)
+		So(IsValidValueType(&plugin.Summary{}), ShouldBeTrue)
+		So(IsValidValueType((*plugin.Histogram)(nil)), ShouldBeFalse)
+
+		So(ValidateValue(10), ShouldBeNil)
+		So(ValidateValue(plugin.Histogram{
+			Buckets: []plugin.HistogramBucket{{UpperBound: 1, Count: 2}, {UpperBound: math.Inf(1), Count: 4}},
+			Count:   4,
+		}), ShouldBeNil)
+		So(ValidateValue(plugin.Histogram{
+			Buckets: []plugin.HistogramBucket{{UpperBound: 2, Count: 2}, {UpperBound: 1, Count: 4}},
+			Count:   4,
+		}), ShouldBeError)
+		So(ValidateValue(&plugin.Histogram{
+			Buckets: []plugin.HistogramBucket{{UpperBound: 1, Count: 4}, {UpperBound: 2, Count: 2}},
+			Count:   4,
+		}), ShouldBeError)
+		So(ValidateValue(plugin.Histogram{
+			Buckets: []plugin.HistogramBucket{{UpperBound: 1, Count: 5}},
+			Count:   4,
+		}), ShouldBeError)
+
+		So(ValidateValue(plugin.Summary{
+			Quantiles: []plugin.SummaryQuantile{{Quantile: 0.5, Value: 3}, {Quantile: 0.9, Value: 7}},
+		}), ShouldBeNil)
+		So(ValidateValue(plugin.Summary{
+			Quantiles: []plugin.SummaryQuantile{{Quantile: 1.5, Value: 3}},
+		}), ShouldBeError)
+		So(ValidateValue(&plugin.Summary{
+			Quantiles: []plugin.SummaryQuantile{{Quantile: 0.9, Value: 3}, {Quantile: 0.5, Value: 7}},
+		}), ShouldBeError)
+	})
+}
+
+func TestEncodeMetrics(t *testing.T) {
+	Convey("Validate that metrics are the same after encoding and decoding", t, func() {
+		// Arrange
+		ts := time.Unix(1600000000, 0).UTC()
+		mts := []*Metric{
+			{
+				Namespace_: []NamespaceElement{{Value_: "system"}, {Name_: "cpu_id", Value_: "1", Description_: "cpu"}},
+				Value_:     uint16(10),
+				Tags_:      map[string]string{"host": "a"},
+				Unit_:      "%",
+				Timestamp_: ts,
+				Kind_:      plugin.MetricKindGauge,
+			},
+			{
+				Namespace_: []NamespaceElement{{Value_: "system"}, {Value_: "latency"}},
+				Value_: plugin.Histogram{
+					Buckets: []plugin.HistogramBucket{{UpperBound: 1, Count: 2}, {UpperBound: math.Inf(1), Count: 3}},
+					Sum:     4.5,
+					Count:   3,
+				},
+				Timestamp_: ts,
+			},
+			{
+				Namespace_: []NamespaceElement{{Value_: "system"}, {Value_: "name"}},
+				Value_:     "host-1",
+				Timestamp_: ts,
+			},
+			{
+				Namespace_: []NamespaceElement{{Value_: "system"}, {Value_: "response_time"}},
+				Value_:     &plugin.Summary{Quantiles: []plugin.SummaryQuantile{{Quantile: 0.5, Value: 2}}, Sum: 4, Count: 2},
+				Timestamp_: ts,
+			},
+		}
+
+		// Act
+		data, err := EncodeMetrics(mts)
+		So(err, ShouldBeNil)
+
+		decodedMts, err := DecodeMetrics(data)
+		So(err, ShouldBeNil)
+
+		// Assert
+		So(decodedMts[:3], ShouldResemble, mts[:3])
+		So(decodedMts[0].Value(), ShouldHaveSameTypeAs, uint16(0))
+		So(decodedMts[3].Value(), ShouldResemble, *mts[3].Value_.(*plugin.Summary)) // pointers are decoded as values
+
+		_, err = DecodeMetrics([]byte("invalid"))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestEncodeBatch(t *testing.T) {
+	Convey("Validate that metrics and events are the same after encoding and decoding", t, func() {
+		// Arrange
+		ts := time.Unix(1600000000, 0).UTC()
+		b := Batch{
+			Metrics: []*Metric{
+				{
+					Namespace_: []NamespaceElement{{Value_: "system"}, {Value_: "load"}},
+					Value_:     1.5,
+					Timestamp_: ts,
+				},
+			},
+			Events: []*Event{
+				{
+					Title_:     "Service restarted",
+					Text_:      "nginx has been restarted",
+					Severity_:  plugin.EventSeverityWarning,
+					Tags_:      map[string]string{"service": "nginx"},
+					Timestamp_: ts,
+				},
+			},
+		}
+
+		// Act
+		data, err := EncodeBatch(b)
+		So(err, ShouldBeNil)
+
+		decoded, err := DecodeBatch(data)
+		So(err, ShouldBeNil)
+
+		// Assert
+		So(decoded, ShouldResemble, b)
+
+		Convey("Data encoded as metrics only is decoded as batch", func() {
+			data, err := EncodeMetrics(b.Metrics)
+			So(err, ShouldBeNil)
+
+			decoded, err := DecodeBatch(data)
+			So(err, ShouldBeNil)
+			So(decoded.Metrics, ShouldResemble, b.Metrics)
+			So(decoded.Events, ShouldBeEmpty)
+		})
+
+		_, err = DecodeBatch([]byte("invalid"))
+		So(err, ShouldNotBeNil)
+	})
+}
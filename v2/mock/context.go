@@ -18,6 +18,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
@@ -44,6 +45,36 @@ func (m *Context) RawConfig() []byte {
 	return args.Get(0).([]byte)
 }
 
+func (m *Context) UnmarshalConfig(dest interface{}) error {
+	args := m.Called(dest)
+	return args.Error(0)
+}
+
+func (m *Context) ConfigInt(key string) (int, error) {
+	args := m.Called(key)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *Context) ConfigFloat(key string) (float64, error) {
+	args := m.Called(key)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *Context) ConfigBool(key string) (bool, error) {
+	args := m.Called(key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Context) ConfigDuration(key string) (time.Duration, error) {
+	args := m.Called(key)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
+func (m *Context) ConfigStringList(key string) ([]string, error) {
+	args := m.Called(key)
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *Context) Store(key string, value interface{}) {
 	m.Called(key, value)
 }
@@ -58,6 +89,16 @@ func (m *Context) LoadTo(key string, dest interface{}) error {
 	return args.Error(0)
 }
 
+func (m *Context) PersistentState() plugin.PersistentState {
+	args := m.Called()
+	return args.Get(0).(plugin.PersistentState)
+}
+
+func (m *Context) Shared() plugin.SharedStore {
+	args := m.Called()
+	return args.Get(0).(plugin.SharedStore)
+}
+
 func (m *Context) AddWarning(msg string) {
 	m.Called(msg)
 }
@@ -67,6 +108,11 @@ func (m *Context) AddMetric(ns string, value interface{}, modifiers ...plugin.Me
 	return args.Error(0)
 }
 
+func (m *Context) Namespace(template string) plugin.NamespaceHandle {
+	args := m.Called(template)
+	return args.Get(0).(plugin.NamespaceHandle)
+}
+
 func (m *Context) AlwaysApply(namespaceSelector string, modifiers ...plugin.MetricModifier) (plugin.Dismisser, error) {
 	args := m.Called(namespaceSelector, modifiers)
 	return args.Get(0).(plugin.Dismisser), args.Error(1)
@@ -76,6 +122,25 @@ func (m *Context) DismissAllModifiers() {
 	m.Called()
 }
 
+func (m *Context) AddEvent(title string, text string, severity plugin.EventSeverity, modifiers ...plugin.EventModifier) error {
+	args := m.Called(title, text, severity, modifiers)
+	return args.Error(0)
+}
+
+func (m *Context) Go(fn func() error) {
+	m.Called(fn)
+}
+
+func (m *Context) WorkerPool(n int) plugin.WorkerPool {
+	args := m.Called(n)
+	return args.Get(0).(plugin.WorkerPool)
+}
+
+func (m *Context) Flush() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 func (m *Context) ShouldProcess(ns string) bool {
 	args := m.Called(ns)
 	return args.Bool(0)
@@ -116,3 +181,89 @@ func (m *Context) Count() int {
 	args := m.Called()
 	return args.Int(0)
 }
+
+func (m *Context) ListAllEvents() []plugin.Event {
+	args := m.Called()
+	return args.Get(0).([]plugin.Event)
+}
+
+// processor context
+func (m *Context) ModifyMetric(mt plugin.Metric, modifiers ...plugin.MetricModifier) error {
+	args := m.Called(mt, modifiers)
+	return args.Error(0)
+}
+
+func (m *Context) DropMetric(mt plugin.Metric) error {
+	args := m.Called(mt)
+	return args.Error(0)
+}
+
+type PersistentState struct {
+	mock.Mock
+}
+
+func (m *PersistentState) Store(key string, value interface{}) error {
+	args := m.Called(key, value)
+	return args.Error(0)
+}
+
+func (m *PersistentState) LoadTo(key string, dest interface{}) error {
+	args := m.Called(key, dest)
+	return args.Error(0)
+}
+
+func (m *PersistentState) Has(key string) bool {
+	args := m.Called(key)
+	return args.Bool(0)
+}
+
+func (m *PersistentState) Delete(key string) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *PersistentState) Keys() []string {
+	args := m.Called()
+	return args.Get(0).([]string)
+}
+
+type SharedStore struct {
+	mock.Mock
+}
+
+func (m *SharedStore) Store(key string, value interface{}) {
+	m.Called(key, value)
+}
+
+func (m *SharedStore) Load(key string) (interface{}, bool) {
+	args := m.Called(key)
+	return args.Get(0), args.Bool(1)
+}
+
+func (m *SharedStore) LoadTo(key string, dest interface{}) error {
+	args := m.Called(key, dest)
+	return args.Error(0)
+}
+
+func (m *SharedStore) Delete(key string) {
+	m.Called(key)
+}
+
+type NamespaceHandle struct {
+	mock.Mock
+}
+
+func (m *NamespaceHandle) Add(value interface{}, groupValues ...string) error {
+	args := m.Called(value, groupValues)
+	return args.Error(0)
+}
+
+func (m *NamespaceHandle) AddWithModifiers(value interface{}, groupValues []string, modifiers ...plugin.MetricModifier) error {
+	args := m.Called(value, groupValues, modifiers)
+	return args.Error(0)
+}
+
+func (m *NamespaceHandle) Err() error {
+	args := m.Called()
+	return args.Error(0)
+}
@@ -16,7 +16,13 @@
 
 package mock
 
-import "github.com/stretchr/testify/mock"
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
 
 type Definition struct {
 	mock.Mock
@@ -32,19 +38,61 @@ func (m *Definition) DefineInstancesLimit(limit int) error {
 	return args.Error(0)
 }
 
+func (m *Definition) DefineConfigField(path string, typ plugin.ConfigFieldType, modifiers ...plugin.ConfigFieldModifier) error {
+	args := m.Called(path, typ, modifiers)
+	return args.Error(0)
+}
+
 type CollectorDefinition struct {
 	Definition
 }
 
-func (m *CollectorDefinition) DefineMetric(namespace string, unit string, isDefault bool, description string) {
-	m.Called(namespace, unit, isDefault, description)
+func (m *CollectorDefinition) DefineMetric(namespace string, unit string, isDefault bool, description string, modifiers ...plugin.MetricDefinitionModifier) {
+	if len(modifiers) == 0 {
+		m.Called(namespace, unit, isDefault, description)
+		return
+	}
+	m.Called(namespace, unit, isDefault, description, modifiers)
 }
 
 func (m *CollectorDefinition) DefineGroup(name string, description string) {
 	m.Called(name, description)
 }
 
+func (m *CollectorDefinition) DefineFlushThreshold(metricsCount int) error {
+	args := m.Called(metricsCount)
+	return args.Error(0)
+}
+
+func (m *CollectorDefinition) DefineMinCollectInterval(interval time.Duration) error {
+	args := m.Called(interval)
+	return args.Error(0)
+}
+
 func (m *CollectorDefinition) DefineExampleConfig(cfg string) error {
 	args := m.Called(cfg)
 	return args.Error(0)
 }
+
+type ProcessorDefinition struct {
+	Definition
+}
+
+func (m *ProcessorDefinition) DefineExampleConfig(cfg string) error {
+	args := m.Called(cfg)
+	return args.Error(0)
+}
+
+type PublisherDefinition struct {
+	Definition
+}
+
+func (m *PublisherDefinition) DefineExampleConfig(cfg string) error {
+	args := m.Called(cfg)
+	return args.Error(0)
+}
+
+func (m *PublisherDefinition) DefineDiskBuffer(dir string, modifiers ...plugin.DiskBufferModifier) error {
+	args := m.Called(dir, modifiers)
+	return args.Error(0)
+}
@@ -21,6 +21,11 @@ which allows to perform any collection-related operation.
 
 package plugin
 
+import (
+	"context"
+	"time"
+)
+
 type Collector interface {
 	Collect(ctx CollectContext) error
 }
@@ -37,6 +42,26 @@ type UnloadableCollector interface {
 	Unload(ctx Context) error
 }
 
+// ReconfigurableCollector may approve new configuration of a task (or reject it by returning error).
+// Approved configuration replaces current one between collections, objects stored in ctx are preserved.
+// Tasks of collectors not implementing this interface are unloaded and loaded again with new configuration.
+type ReconfigurableCollector interface {
+	Reconfigure(ctx Context, change ConfigChange) error
+}
+
+// InitializableCollector is called once after plugin definition and before plugin starts serving requests.
+// Resources used by all tasks should be created here and put into shared store (available via Context.Shared()).
+// Error stops the plugin.
+type InitializableCollector interface {
+	Init(ctx context.Context, shared SharedStore) error
+}
+
+// ShutdownableCollector is notified once when plugin is being stopped (after all tasks have been unloaded).
+// ctx is canceled when shutdown grace period (-shutdown-timeout) is exceeded.
+type ShutdownableCollector interface {
+	Shutdown(ctx context.Context) error
+}
+
 type DefinableCollector interface {
 	PluginDefinition(def CollectorDefinition) error
 }
@@ -54,6 +79,11 @@ type CollectContext interface {
 	// Add concrete metric with calculated value
 	AddMetric(namespace string, value interface{}, modifier ...MetricModifier) error
 
+	// Compile namespace template (ie. "/plugin/[disk]/io_time") once and use returned handle to add metrics.
+	// Parsing, validation and filtering results are cached by the handle, which makes it a faster alternative
+	// to AddMetric when plenty of metrics are gathered. Calls with the same template return the same handle.
+	Namespace(template string) NamespaceHandle
+
 	// Always apply specific modifier(s) for a metrics matching namespace selector
 	// Returns object which may be used to dismiss modifiers (make them no-active)
 	AlwaysApply(namespaceSelector string, modifier ...MetricModifier) (Dismisser, error)
@@ -61,6 +91,22 @@ type CollectContext interface {
 	// Dismisses all modifiers created by calling AlwaysApply
 	DismissAllModifiers()
 
+	// Report event (ie. service restart, configuration change) which is sent to publishers together with metrics.
+	// Event is timestamped with current time unless plugin.EventTimestamp() modifier is provided.
+	AddEvent(title string, text string, severity EventSeverity, modifier ...EventModifier) error
+
+	// Run function in background goroutine (up to 10 functions are running at once, next calls block).
+	// Errors and panics are reported as warnings. Collection is completed when all functions have returned.
+	Go(fn func() error)
+
+	// Create pool running up to n functions at once (n <= 0 means default limit of 10). Errors and panics
+	// are returned by pool's Wait() (panics are reported as warnings as well). Collection waits for pool's functions.
+	WorkerPool(n int) WorkerPool
+
+	// Send metrics added so far without waiting for the end of collection (limits memory usage when
+	// plenty of metrics are gathered). Blocks until metrics are handed over to the sending routine.
+	Flush() error
+
 	// Provide information whether metric or metric group is reasonable to process (won't be filtered).
 	ShouldProcess(namespace string) bool
 
@@ -76,12 +122,26 @@ type CollectContext interface {
 type CollectorDefinition interface {
 	Definition
 
-	// Define supported metric, its description and indication if metric is default
-	DefineMetric(namespace string, unit string, isDefault bool, description string)
+	// Define supported metric, its description and indication if metric is default.
+	// Optional modifiers may declare additional properties, ie. kind of metric (plugin.MetricKindCounter).
+	DefineMetric(namespace string, unit string, isDefault bool, description string, modifiers ...MetricDefinitionModifier)
 
 	// Define description for dynamic element
 	DefineGroup(name string, description string)
 
 	// Define example config (which will be presented when example task is printed)
 	DefineExampleConfig(cfg string) error
+
+	// Define number of metrics after which gathered metrics are sent automatically (as if Flush() was called).
+	// 0 (default) means that metrics are sent when collection is completed.
+	DefineFlushThreshold(metricsCount int) error
+
+	// Define minimum interval between collections of tasks with identical configuration.
+	// Tasks share collection in progress and results younger than interval (filtered by metrics requested by each task).
+	// 0 means that only collection in progress is shared.
+	DefineMinCollectInterval(interval time.Duration) error
+
+	// Define typed configuration field (path elements are separated with '.', ie. "server.port").
+	// Task configuration is validated against defined fields (and filled with default values) before Load is called.
+	DefineConfigField(path string, typ ConfigFieldType, modifier ...ConfigFieldModifier) error
 }
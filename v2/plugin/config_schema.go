@@ -0,0 +1,185 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+// Type of configuration field declared with DefineConfigField
+type ConfigFieldType int
+
+const (
+	ConfigTypeString     ConfigFieldType = iota // JSON string
+	ConfigTypeInt                               // JSON number without fractional part
+	ConfigTypeFloat                             // JSON number
+	ConfigTypeBool                              // JSON boolean
+	ConfigTypeDuration                          // JSON string accepted by time.ParseDuration (ie. "10s")
+	ConfigTypeStringList                        // JSON array of strings
+)
+
+func (t ConfigFieldType) String() string {
+	switch t {
+	case ConfigTypeString:
+		return "string"
+	case ConfigTypeInt:
+		return "int"
+	case ConfigTypeFloat:
+		return "float"
+	case ConfigTypeBool:
+		return "bool"
+	case ConfigTypeDuration:
+		return "duration"
+	case ConfigTypeStringList:
+		return "string list"
+	default:
+		return "unknown"
+	}
+}
+
+// Interface for setting constraints of configuration field
+type ConfigFieldSetter interface {
+	// Mark field as required (task can't be loaded when field is missing)
+	SetRequired(bool)
+
+	// Set value used when field is missing in task configuration
+	SetDefault(interface{})
+
+	// Set minimal value (for numbers and durations)
+	SetMin(interface{})
+
+	// Set maximal value (for numbers and durations)
+	SetMax(interface{})
+
+	// Set list of allowed values
+	SetAllowedValues([]interface{})
+
+	// Set regular expression which value should match (for strings and string lists)
+	SetPattern(string)
+
+	// Mark field as containing sensitive data (ie. passwords, tokens)
+	SetSecret(bool)
+
+	// Set description of field (presented in example task and JSON Schema)
+	SetDescription(string)
+}
+
+type ConfigFieldModifier interface {
+	UpdateConfigField(f ConfigFieldSetter)
+}
+
+func ConfigRequired() ConfigFieldModifier {
+	return &configRequired{}
+}
+
+func ConfigDefault(value interface{}) ConfigFieldModifier {
+	return &configDefault{
+		value: value,
+	}
+}
+
+func ConfigMin(value interface{}) ConfigFieldModifier {
+	return &configMin{
+		value: value,
+	}
+}
+
+func ConfigMax(value interface{}) ConfigFieldModifier {
+	return &configMax{
+		value: value,
+	}
+}
+
+func ConfigAllowedValues(values ...interface{}) ConfigFieldModifier {
+	return &configAllowedValues{
+		values: values,
+	}
+}
+
+func ConfigPattern(regex string) ConfigFieldModifier {
+	return &configPattern{
+		regex: regex,
+	}
+}
+
+func ConfigSecret() ConfigFieldModifier {
+	return &configSecret{}
+}
+
+func ConfigDescription(description string) ConfigFieldModifier {
+	return &configDescription{
+		description: description,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type configRequired struct{}
+
+func (m configRequired) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetRequired(true)
+}
+
+type configDefault struct {
+	value interface{}
+}
+
+func (m configDefault) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetDefault(m.value)
+}
+
+type configMin struct {
+	value interface{}
+}
+
+func (m configMin) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetMin(m.value)
+}
+
+type configMax struct {
+	value interface{}
+}
+
+func (m configMax) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetMax(m.value)
+}
+
+type configAllowedValues struct {
+	values []interface{}
+}
+
+func (m configAllowedValues) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetAllowedValues(m.values)
+}
+
+type configPattern struct {
+	regex string
+}
+
+func (m configPattern) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetPattern(m.regex)
+}
+
+type configSecret struct{}
+
+func (m configSecret) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetSecret(true)
+}
+
+type configDescription struct {
+	description string
+}
+
+func (m configDescription) UpdateConfigField(f ConfigFieldSetter) {
+	f.SetDescription(m.description)
+}
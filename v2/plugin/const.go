@@ -18,4 +18,14 @@ package plugin
 
 const (
 	NoLimit = 0 // special value indicating no limits (number of instances or tasks)
+
+	// Reserved task configuration key with maximum duration of a single collect request (ie. "30s").
+	// Overrides plugin-wide default (-collect-timeout).
+	CollectTimeoutConfigKey = "__collectTimeout"
+
+	// Reserved task configuration keys limiting number of distinct series (namespace + tags) emitted by a task.
+	// Override plugin-wide defaults (-max-series-per-collect, -max-series-per-window, -series-window).
+	MaxSeriesPerCollectConfigKey = "__maxSeriesPerCollect"
+	MaxSeriesPerWindowConfigKey  = "__maxSeriesPerWindow"
+	SeriesWindowConfigKey        = "__seriesWindow"
 )
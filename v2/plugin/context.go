@@ -18,6 +18,7 @@ package plugin
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -33,6 +34,26 @@ type Context interface {
 	// Return raw configuration (JSON string)
 	RawConfig() []byte
 
+	// Decode configuration into structure pointed by dest.
+	// Fields are matched by `json` tag, `default` tag provides value of missing field, time.Duration is given as a string (ie. "10s").
+	// Returned error contains paths of invalid fields (ie. "server.port").
+	UnmarshalConfig(dest interface{}) error
+
+	// Returns configuration value converted to int (error when value is missing or can't be converted)
+	ConfigInt(key string) (int, error)
+
+	// Returns configuration value converted to float64 (error when value is missing or can't be converted)
+	ConfigFloat(key string) (float64, error)
+
+	// Returns configuration value converted to bool (error when value is missing or can't be converted)
+	ConfigBool(key string) (bool, error)
+
+	// Returns configuration value converted to time.Duration (error when value is missing or can't be converted)
+	ConfigDuration(key string) (time.Duration, error)
+
+	// Returns configuration list (error when value is missing or isn't a list of strings)
+	ConfigStringList(key string) ([]string, error)
+
 	// Store any object using key to have access from different Collect requests
 	Store(key string, value interface{})
 
@@ -43,6 +64,12 @@ type Context interface {
 	// Will throw error when dest type doesn't match to type of stored value or object with a given key wasn't found.
 	LoadTo(key string, dest interface{}) error
 
+	// Access state which is preserved between plugin restarts (stored on disk, see -state-dir)
+	PersistentState() PersistentState
+
+	// Access objects shared by all tasks of plugin (ie. connection pools, caches), see InitializableCollector
+	Shared() SharedStore
+
 	// Add warning information to current collect / process operation.
 	AddWarning(msg string)
 
@@ -58,3 +85,39 @@ type Context interface {
 	// Reference to logger object
 	Logger() logrus.FieldLogger
 }
+
+// State of a task stored on disk (identified by plugin name and task id), so it's available after plugin is restarted.
+// Values are serialized as JSON. Each modification is written to disk atomically.
+type PersistentState interface {
+	// Store value using key (returns error when value can't be serialized or written to disk)
+	Store(key string, value interface{}) error
+
+	// Load value with a given key (passing it to provided reference). Will return error when key wasn't found.
+	LoadTo(key string, dest interface{}) error
+
+	// Check if value with a given key exists
+	Has(key string) bool
+
+	// Remove value with a given key
+	Delete(key string) error
+
+	// Return list of all stored keys
+	Keys() []string
+}
+
+// Objects shared by all tasks of plugin (ie. connection pools, caches, HTTP client).
+// Store is created once per plugin process, it's also passed to plugin-wide Init hook.
+type SharedStore interface {
+	// Store any object using key to have access from all tasks
+	Store(key string, value interface{})
+
+	// Load object stored with a given key (returns an interface{} which need to be casted to concrete type)
+	Load(key string) (interface{}, bool)
+
+	// Load object stored with a given key (passing it to provided reference).
+	// Will throw error when dest type doesn't match to type of stored value or object with a given key wasn't found.
+	LoadTo(key string, dest interface{}) error
+
+	// Remove object with a given key
+	Delete(key string)
+}
@@ -0,0 +1,115 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+import "time"
+
+// Interface for setting parameters of publisher disk buffer (see PublisherDefinition.DefineDiskBuffer)
+type DiskBufferSetter interface {
+	// Set maximum size of buffer on disk (in bytes). When exceeded, the oldest metrics are dropped
+	SetMaxSize(bytes int64)
+
+	// Set maximum age of buffered metrics. Older metrics are dropped without being published
+	SetMaxAge(age time.Duration)
+
+	// Set size of a single segment file (in bytes)
+	SetSegmentSize(bytes int64)
+
+	// Set delay before publishing is retried (delay is doubled after each failure, up to maxInterval)
+	SetRetryInterval(interval, maxInterval time.Duration)
+
+	// Set number of failed attempts after which metrics are dropped (0 - retry until metrics are too old)
+	SetMaxRetries(retries int)
+}
+
+type DiskBufferModifier interface {
+	UpdateDiskBuffer(b DiskBufferSetter)
+}
+
+func DiskBufferMaxSize(bytes int64) DiskBufferModifier {
+	return &diskBufferMaxSize{
+		bytes: bytes,
+	}
+}
+
+func DiskBufferMaxAge(age time.Duration) DiskBufferModifier {
+	return &diskBufferMaxAge{
+		age: age,
+	}
+}
+
+func DiskBufferSegmentSize(bytes int64) DiskBufferModifier {
+	return &diskBufferSegmentSize{
+		bytes: bytes,
+	}
+}
+
+func DiskBufferRetryInterval(interval, maxInterval time.Duration) DiskBufferModifier {
+	return &diskBufferRetryInterval{
+		interval:    interval,
+		maxInterval: maxInterval,
+	}
+}
+
+func DiskBufferMaxRetries(retries int) DiskBufferModifier {
+	return &diskBufferMaxRetries{
+		retries: retries,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type diskBufferMaxSize struct {
+	bytes int64
+}
+
+func (m diskBufferMaxSize) UpdateDiskBuffer(b DiskBufferSetter) {
+	b.SetMaxSize(m.bytes)
+}
+
+type diskBufferMaxAge struct {
+	age time.Duration
+}
+
+func (m diskBufferMaxAge) UpdateDiskBuffer(b DiskBufferSetter) {
+	b.SetMaxAge(m.age)
+}
+
+type diskBufferSegmentSize struct {
+	bytes int64
+}
+
+func (m diskBufferSegmentSize) UpdateDiskBuffer(b DiskBufferSetter) {
+	b.SetSegmentSize(m.bytes)
+}
+
+type diskBufferRetryInterval struct {
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+func (m diskBufferRetryInterval) UpdateDiskBuffer(b DiskBufferSetter) {
+	b.SetRetryInterval(m.interval, m.maxInterval)
+}
+
+type diskBufferMaxRetries struct {
+	retries int
+}
+
+func (m diskBufferMaxRetries) UpdateDiskBuffer(b DiskBufferSetter) {
+	b.SetMaxRetries(m.retries)
+}
@@ -0,0 +1,109 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+import "time"
+
+// Severity of event
+type EventSeverity int
+
+const (
+	EventSeverityInfo EventSeverity = iota
+	EventSeverityWarning
+	EventSeverityError
+	EventSeverityCritical
+)
+
+func (s EventSeverity) String() string {
+	switch s {
+	case EventSeverityWarning:
+		return "warning"
+	case EventSeverityError:
+		return "error"
+	case EventSeverityCritical:
+		return "critical"
+	}
+
+	return "info"
+}
+
+// Representation of discrete occurrence (ie. service restart, configuration change) reported next to metrics
+type Event interface {
+	// Short summary of event
+	Title() string
+
+	// Detailed description of event
+	Text() string
+
+	// Severity of event (info, warning, error, critical)
+	Severity() EventSeverity
+
+	// Text-like object associated with event
+	Tags() map[string]string
+
+	// Time, when event occurred
+	Timestamp() time.Time
+}
+
+// Interface for setting custom event metadata
+type EventSetter interface {
+	// Add custom text-like object associated with event
+	AddTags(map[string]string)
+
+	// Set custom timestamp (by default, time when event was added)
+	SetTimestamp(time.Time)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type EventModifier interface {
+	UpdateEvent(ev EventSetter)
+}
+
+func EventTag(key string, value string) EventModifier {
+	return &eventTags{
+		tags: map[string]string{key: value},
+	}
+}
+
+func EventTags(tags map[string]string) EventModifier {
+	return &eventTags{
+		tags: tags,
+	}
+}
+
+func EventTimestamp(timestamp time.Time) EventModifier {
+	return &eventTimestamp{
+		timestamp: timestamp,
+	}
+}
+
+type eventTags struct {
+	tags map[string]string
+}
+
+func (m eventTags) UpdateEvent(ev EventSetter) {
+	ev.AddTags(m.tags)
+}
+
+type eventTimestamp struct {
+	timestamp time.Time
+}
+
+func (m eventTimestamp) UpdateEvent(ev EventSetter) {
+	ev.SetTimestamp(m.timestamp)
+}
@@ -0,0 +1,46 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package plugin
+
+// Result of health check
+type HealthStatus int
+
+const (
+	HealthStatusHealthy   HealthStatus = iota
+	HealthStatusDegraded               // plugin works, but some functionality is limited (ie. part of endpoints is unreachable)
+	HealthStatusUnhealthy              // plugin can't work properly (ie. data source is unreachable)
+)
+
+func (s HealthStatus) String() string {
+	switch s {
+	case HealthStatusDegraded:
+		return "degraded"
+	case HealthStatusUnhealthy:
+		return "unhealthy"
+	}
+
+	return "healthy"
+}
+
+// HealthCheckable may be implemented by collector or publisher to report whether it's able to work properly.
+// Results are available via Health RPC, gRPC health service and /healthz, /readyz endpoints of stats server.
+type HealthCheckable interface {
+	// Check plugin-wide health (not related to any task). Returns status and details (ie. reason of failure)
+	HealthCheck() (HealthStatus, string)
+
+	// Check health of a task (ie. whether endpoint defined in task configuration is reachable)
+	TaskHealthCheck(ctx Context) (HealthStatus, string)
+}
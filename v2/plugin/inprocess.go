@@ -35,3 +35,8 @@ type InProcessPublisher interface {
 	Publisher
 	InProcessPlugin
 }
+
+type InProcessProcessor interface {
+	Processor
+	InProcessPlugin
+}
@@ -0,0 +1,69 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+// Kind of metric, describing how consecutive values should be interpreted
+type MetricKind int
+
+const (
+	MetricKindUnspecified MetricKind = iota
+	MetricKindGauge                  // value measured at a given time (ie. temperature, memory usage)
+	MetricKindCounter                // monotonically increasing value (may be reset to 0, ie. after reboot)
+	MetricKindDelta                  // change of value since previous measurement
+)
+
+func (k MetricKind) String() string {
+	switch k {
+	case MetricKindGauge:
+		return "gauge"
+	case MetricKindCounter:
+		return "counter"
+	case MetricKindDelta:
+		return "delta"
+	}
+
+	return "unspecified"
+}
+
+// MetricKind may be passed directly to DefineMetric(), ie:
+//   def.DefineMetric("/net/[iface]/rx_bytes", "B", true, "Received bytes", plugin.MetricKindCounter)
+func (k MetricKind) UpdateMetricDefinition(def MetricDefinitionSetter) {
+	def.SetKind(k)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Interface for setting additional properties of metric definition
+type MetricDefinitionSetter interface {
+	SetKind(kind MetricKind)
+}
+
+type MetricDefinitionModifier interface {
+	UpdateMetricDefinition(def MetricDefinitionSetter)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Conversion of counter values applied by library before metric is sent.
+// Requires numeric values, previous value of each series (namespace + tags) is kept per task.
+type CounterConversion int
+
+const (
+	NoConversion    CounterConversion = iota
+	RateConversion                    // per-second rate of change between consecutive collections
+	DeltaConversion                   // change of value between consecutive collections
+)
@@ -58,6 +58,28 @@ func MetricUnit(unit string) MetricModifier {
 	}
 }
 
+func MetricKindOf(kind MetricKind) MetricModifier {
+	return &metricKind{
+		kind: kind,
+	}
+}
+
+// Emit per-second rate computed from current and previous counter value (instead of raw value).
+// Metric isn't emitted in the first collection of a series.
+func AsRate() MetricModifier {
+	return &metricConversion{
+		conversion: RateConversion,
+	}
+}
+
+// Emit difference between current and previous counter value (instead of raw value).
+// Metric isn't emitted in the first collection of a series.
+func AsDelta() MetricModifier {
+	return &metricConversion{
+		conversion: DeltaConversion,
+	}
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type metricTags struct {
@@ -99,3 +121,19 @@ type metricUnit struct {
 func (m metricUnit) UpdateMetric(mt MetricSetter) {
 	mt.SetUnit(m.unit)
 }
+
+type metricKind struct {
+	kind MetricKind
+}
+
+func (m metricKind) UpdateMetric(mt MetricSetter) {
+	mt.SetKind(m.kind)
+}
+
+type metricConversion struct {
+	conversion CounterConversion
+}
+
+func (m metricConversion) UpdateMetric(mt MetricSetter) {
+	mt.SetCounterConversion(m.conversion)
+}
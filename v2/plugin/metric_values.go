@@ -0,0 +1,132 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Single bucket of histogram
+type HistogramBucket struct {
+	// Upper (inclusive) bound of bucket, math.Inf(1) for the last one
+	UpperBound float64
+
+	// Number of observations less than or equal to UpperBound (cumulative)
+	Count uint64
+}
+
+// Distribution of observations grouped into buckets.
+// Histogram (or pointer to Histogram) may be passed as a value of metric.
+type Histogram struct {
+	// Buckets sorted by upper bound
+	Buckets []HistogramBucket
+
+	// Sum of all observed values
+	Sum float64
+
+	// Number of observations
+	Count uint64
+}
+
+// Validate checks if buckets are sorted and counts are cumulative
+func (h Histogram) Validate() error {
+	for i, b := range h.Buckets {
+		if math.IsNaN(b.UpperBound) {
+			return fmt.Errorf("histogram bucket %d has invalid upper bound (NaN)", i)
+		}
+		if i == 0 {
+			continue
+		}
+
+		prev := h.Buckets[i-1]
+		if b.UpperBound <= prev.UpperBound {
+			return fmt.Errorf("histogram buckets should be sorted by upper bound (%v after %v)", b.UpperBound, prev.UpperBound)
+		}
+		if b.Count < prev.Count {
+			return fmt.Errorf("histogram bucket counts should be cumulative (%d after %d)", b.Count, prev.Count)
+		}
+	}
+
+	if n := len(h.Buckets); n > 0 && h.Buckets[n-1].Count > h.Count {
+		return fmt.Errorf("histogram bucket count (%d) exceeds total number of observations (%d)", h.Buckets[n-1].Count, h.Count)
+	}
+
+	return nil
+}
+
+func (h Histogram) String() string {
+	elems := make([]string, 0, len(h.Buckets)+2)
+	for _, b := range h.Buckets {
+		elems = append(elems, fmt.Sprintf("le=%s:%d", formatFloat(b.UpperBound), b.Count))
+	}
+	elems = append(elems, "sum="+formatFloat(h.Sum), fmt.Sprintf("count=%d", h.Count))
+
+	return "histogram{" + strings.Join(elems, " ") + "}"
+}
+
+// Single quantile of summary
+type SummaryQuantile struct {
+	// Quantile in range [0;1], ie. 0.99
+	Quantile float64
+
+	// Value of observation at a given quantile
+	Value float64
+}
+
+// Distribution of observations described by quantiles.
+// Summary (or pointer to Summary) may be passed as a value of metric.
+type Summary struct {
+	// Quantiles sorted in ascending order
+	Quantiles []SummaryQuantile
+
+	// Sum of all observed values
+	Sum float64
+
+	// Number of observations
+	Count uint64
+}
+
+// Validate checks if quantiles are within range [0;1] and sorted
+func (s Summary) Validate() error {
+	for i, q := range s.Quantiles {
+		if !(q.Quantile >= 0 && q.Quantile <= 1) {
+			return fmt.Errorf("summary quantile %v should be within range [0;1]", q.Quantile)
+		}
+		if i > 0 && q.Quantile <= s.Quantiles[i-1].Quantile {
+			return fmt.Errorf("summary quantiles should be sorted (%v after %v)", q.Quantile, s.Quantiles[i-1].Quantile)
+		}
+	}
+
+	return nil
+}
+
+func (s Summary) String() string {
+	elems := make([]string, 0, len(s.Quantiles)+2)
+	for _, q := range s.Quantiles {
+		elems = append(elems, fmt.Sprintf("q=%s:%s", formatFloat(q.Quantile), formatFloat(q.Value)))
+	}
+	elems = append(elems, "sum="+formatFloat(s.Sum), fmt.Sprintf("count=%d", s.Count))
+
+	return "summary{" + strings.Join(elems, " ") + "}"
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
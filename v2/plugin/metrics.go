@@ -37,6 +37,9 @@ type Metric interface {
 
 	// Time, when measurement was taken
 	Timestamp() time.Time
+
+	// Kind of measurement (gauge, counter, delta)
+	Kind() MetricKind
 }
 
 // Interface for setting custom metric metadata
@@ -55,6 +58,12 @@ type MetricSetter interface {
 
 	// Set custom timestamp
 	SetTimestamp(time.Time)
+
+	// Set kind of measurement
+	SetKind(MetricKind)
+
+	// Request conversion of counter value (rate or delta) before metric is sent
+	SetCounterConversion(CounterConversion)
 }
 
 // Representation of AppOptics measurement name
@@ -0,0 +1,30 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+// NamespaceHandle adds metrics with namespace compiled once (see CollectContext.Namespace)
+type NamespaceHandle interface {
+	// Add metric with calculated value. Group values fill dynamic elements of template ([group]) in order, ie.
+	// h := ctx.Namespace("/plugin/[disk]/io_time"); h.Add(12.5, "sda")
+	Add(value interface{}, groupValues ...string) error
+
+	// Add metric with calculated value and apply modifiers to it
+	AddWithModifiers(value interface{}, groupValues []string, modifiers ...MetricModifier) error
+
+	// Error found when template was compiled (the same error is returned by Add)
+	Err() error
+}
@@ -29,6 +29,7 @@ type Options struct {
 	GRPCPort          int
 	GRPCPingTimeout   time.Duration
 	GRPCPingMaxMissed uint
+	ShutdownTimeout   time.Duration // grace period for stopping plugin (completing requests, unloading tasks)
 	AsThread          bool
 
 	EnableTLS         bool // GRPC Server
@@ -43,12 +44,27 @@ type Options struct {
 	PProfPort         int  `json:",omitempty"`
 	StatsPort         int  `json:",omitempty"`
 	UseAPIv2          bool
+	StateDir          string `json:",omitempty"` // directory where persistent state of tasks is stored
+
+	CollectTimeout      time.Duration `json:",omitempty"` // default maximum duration of a single collect request (0 - no limit)
+	MaxSeriesPerCollect int           `json:",omitempty"` // default maximum number of distinct series in a single collect request (0 - no limit)
+	MaxSeriesPerWindow  int           `json:",omitempty"` // default maximum number of distinct series within rolling window (0 - no limit)
+	SeriesWindow        time.Duration `json:",omitempty"` // duration of rolling window for MaxSeriesPerWindow
 
 	PrintExampleTask     bool          `json:"-"`
+	PrintConfigSchema    bool          `json:"-"`
+	PrintMetricsCatalog  string        `json:"-"` // format of printed metrics catalog (empty - not printed)
 	DebugMode            bool          `json:"-"`
 	PluginConfig         string        `json:"-"`
 	PluginFilter         string        `json:"-"`
 	DebugCollectCounts   int           `json:"-"`
 	DebugCollectInterval time.Duration `json:"-"`
+	DebugOutputFormat    string        `json:"-"` // format of metrics printed in debug mode (text, json, jsonl, influx, prometheus, csv)
+	DebugOutput          string        `json:"-"` // file where metrics are printed in debug mode (empty - stdout)
+	TaskFile             string        `json:"-"` // task manifest (version 2) defining tasks run in debug mode
+	DebugInput           string        `json:"-"` // file with metrics published in debug mode (empty or "-" - stdin)
+	DebugInputFormat     string        `json:"-"` // format of metrics published in debug mode (jsonl, influx)
+	DebugPublishBatch    int           `json:"-"` // max number of metrics sent in a single publish request in debug mode
+	DebugPublishInterval time.Duration `json:"-"` // interval between consecutive publish requests in debug mode
 	PrintVersion         bool          `json:"-"`
 }
@@ -0,0 +1,73 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+type Processor interface {
+	Process(ctx ProcessContext) error
+}
+
+type LoadableProcessor interface {
+	Processor
+	Load(ctx Context) error
+}
+
+type UnloadableProcessor interface {
+	Processor
+	Unload(ctx Context) error
+}
+
+type DefinableProcessor interface {
+	Processor
+	PluginDefinition(def ProcessorDefinition) error
+}
+
+type CustomizableInfoProcessor interface {
+	Processor
+	CustomInfo(ctx Context) interface{}
+}
+
+// ProcessContext provides access to processed metrics, state and configuration API to be used by custom code.
+type ProcessContext interface {
+	Context
+
+	// List all metrics in processed set (including those added during current Process call)
+	ListAllMetrics() []Metric
+
+	// Number of metrics in processed set
+	Count() int
+
+	// Add new metric to processed set
+	AddMetric(namespace string, value interface{}, modifier ...MetricModifier) error
+
+	// Apply modifier(s) to metric from processed set (metric should be obtained by ListAllMetrics)
+	ModifyMetric(mt Metric, modifier ...MetricModifier) error
+
+	// Remove metric from processed set (metric should be obtained by ListAllMetrics)
+	DropMetric(mt Metric) error
+}
+
+// ProcessorDefinition provides API for specifying plugin (processor) metadata
+type ProcessorDefinition interface {
+	Definition
+
+	// Define example config (which will be presented when example task is printed)
+	DefineExampleConfig(cfg string) error
+
+	// Define typed configuration field (path elements are separated with '.', ie. "server.port").
+	// Task configuration is validated against defined fields (and filled with default values) before Load is called.
+	DefineConfigField(path string, typ ConfigFieldType, modifier ...ConfigFieldModifier) error
+}
@@ -16,6 +16,8 @@
 
 package plugin
 
+import "context"
+
 type Publisher interface {
 	Publish(ctx PublishContext) error
 }
@@ -30,6 +32,29 @@ type UnloadablePublisher interface {
 	Unload(ctx Context) error
 }
 
+// ReconfigurablePublisher may approve new configuration of a task (or reject it by returning error).
+// Approved configuration replaces current one between publish requests, objects stored in ctx are preserved.
+// Tasks of publishers not implementing this interface are unloaded and loaded again with new configuration.
+type ReconfigurablePublisher interface {
+	Publisher
+	Reconfigure(ctx Context, change ConfigChange) error
+}
+
+// InitializablePublisher is called once after plugin definition and before plugin starts serving requests.
+// Resources used by all tasks should be created here and put into shared store (available via Context.Shared()).
+// Error stops the plugin.
+type InitializablePublisher interface {
+	Publisher
+	Init(ctx context.Context, shared SharedStore) error
+}
+
+// ShutdownablePublisher is notified once when plugin is being stopped (after all tasks have been unloaded).
+// ctx is canceled when shutdown grace period (-shutdown-timeout) is exceeded.
+type ShutdownablePublisher interface {
+	Publisher
+	Shutdown(ctx context.Context) error
+}
+
 type DefinablePublisher interface {
 	Publisher
 	PluginDefinition(def PublisherDefinition) error
@@ -45,6 +70,9 @@ type PublishContext interface {
 
 	ListAllMetrics() []Metric
 	Count() int
+
+	// List events reported by collectors (sent together with metrics)
+	ListAllEvents() []Event
 }
 
 // PublisherDefinition provides API for specifying plugin (publisher) metadata (supported metrics, descriptions etc)
@@ -53,4 +81,13 @@ type PublisherDefinition interface {
 
 	// Define example config (which will be presented when example task is printed)
 	DefineExampleConfig(cfg string) error
+
+	// Define typed configuration field (path elements are separated with '.', ie. "server.port").
+	// Task configuration is validated against defined fields (and filled with default values) before Load is called.
+	DefineConfigField(path string, typ ConfigFieldType, modifier ...ConfigFieldModifier) error
+
+	// Enable durable buffer stored in a given directory (separate subdirectory is used for each task).
+	// Metrics received from snap are written to disk and acknowledged immediately. Publish is called in background
+	// with buffered metrics and retried (with increasing delay) until it succeeds or metrics are dropped due to limits.
+	DefineDiskBuffer(dir string, modifiers ...DiskBufferModifier) error
 }
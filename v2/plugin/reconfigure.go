@@ -0,0 +1,37 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+package plugin
+
+// ConfigChange describes new configuration requested for a loaded task (see ReconfigurableCollector)
+type ConfigChange interface {
+	// Keys (paths in JSON tree, ie. "server.port") added by new configuration
+	Added() []string
+
+	// Keys removed by new configuration
+	Removed() []string
+
+	// Keys which values are different in new configuration
+	Modified() []string
+
+	// Returns value of new configuration by providing path (representing its position in JSON tree)
+	ConfigValue(key string) (string, bool)
+
+	// Return new configuration (JSON string)
+	RawConfig() []byte
+
+	// Decode new configuration into structure pointed by dest (see Context.UnmarshalConfig)
+	UnmarshalConfig(dest interface{}) error
+}
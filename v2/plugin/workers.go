@@ -0,0 +1,27 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package plugin
+
+// WorkerPool runs functions in background with limited concurrency (see CollectContext.WorkerPool)
+type WorkerPool interface {
+	// Run function in background goroutine. Blocks when limit of running functions is reached.
+	// Function is not started when task has been canceled (it should also watch ctx.Done() itself).
+	Go(fn func() error)
+
+	// Wait until all functions started with Go() are completed. Returns the first error (or recovered panic).
+	Wait() error
+}
@@ -43,6 +43,90 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type HealthStatus int32
+
+const (
+	HealthStatus_HEALTHY   HealthStatus = 0
+	HealthStatus_DEGRADED  HealthStatus = 1
+	HealthStatus_UNHEALTHY HealthStatus = 2
+)
+
+var HealthStatus_name = map[int32]string{
+	0: "HEALTHY",
+	1: "DEGRADED",
+	2: "UNHEALTHY",
+}
+var HealthStatus_value = map[string]int32{
+	"HEALTHY":   0,
+	"DEGRADED":  1,
+	"UNHEALTHY": 2,
+}
+
+func (x HealthStatus) String() string {
+	return proto.EnumName(HealthStatus_name, int32(x))
+}
+func (HealthStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{0}
+}
+
+type MetricKind int32
+
+const (
+	MetricKind_UNSPECIFIED MetricKind = 0
+	MetricKind_GAUGE       MetricKind = 1
+	MetricKind_COUNTER     MetricKind = 2
+	MetricKind_DELTA       MetricKind = 3
+)
+
+var MetricKind_name = map[int32]string{
+	0: "UNSPECIFIED",
+	1: "GAUGE",
+	2: "COUNTER",
+	3: "DELTA",
+}
+var MetricKind_value = map[string]int32{
+	"UNSPECIFIED": 0,
+	"GAUGE":       1,
+	"COUNTER":     2,
+	"DELTA":       3,
+}
+
+func (x MetricKind) String() string {
+	return proto.EnumName(MetricKind_name, int32(x))
+}
+func (MetricKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{1}
+}
+
+type EventSeverity int32
+
+const (
+	EventSeverity_INFO     EventSeverity = 0
+	EventSeverity_WARNING  EventSeverity = 1
+	EventSeverity_ERROR    EventSeverity = 2
+	EventSeverity_CRITICAL EventSeverity = 3
+)
+
+var EventSeverity_name = map[int32]string{
+	0: "INFO",
+	1: "WARNING",
+	2: "ERROR",
+	3: "CRITICAL",
+}
+var EventSeverity_value = map[string]int32{
+	"INFO":     0,
+	"WARNING":  1,
+	"ERROR":    2,
+	"CRITICAL": 3,
+}
+
+func (x EventSeverity) String() string {
+	return proto.EnumName(EventSeverity_name, int32(x))
+}
+func (EventSeverity) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{2}
+}
+
 type PingRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -53,7 +137,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{0}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{0}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
@@ -83,7 +167,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{1}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{1}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
@@ -113,7 +197,7 @@ func (m *KillRequest) Reset()         { *m = KillRequest{} }
 func (m *KillRequest) String() string { return proto.CompactTextString(m) }
 func (*KillRequest) ProtoMessage()    {}
 func (*KillRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{2}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{2}
 }
 func (m *KillRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KillRequest.Unmarshal(m, b)
@@ -143,7 +227,7 @@ func (m *KillResponse) Reset()         { *m = KillResponse{} }
 func (m *KillResponse) String() string { return proto.CompactTextString(m) }
 func (*KillResponse) ProtoMessage()    {}
 func (*KillResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{3}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{3}
 }
 func (m *KillResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KillResponse.Unmarshal(m, b)
@@ -163,6 +247,160 @@ func (m *KillResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_KillResponse proto.InternalMessageInfo
 
+type HealthRequest struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{4}
+}
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (dst *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(dst, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+func (m *HealthRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+type HealthResponse struct {
+	Status               HealthStatus  `protobuf:"varint,1,opt,name=status,proto3,enum=pluginrpc.HealthStatus" json:"status,omitempty"`
+	PluginStatus         HealthStatus  `protobuf:"varint,2,opt,name=plugin_status,json=pluginStatus,proto3,enum=pluginrpc.HealthStatus" json:"plugin_status,omitempty"`
+	Details              string        `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	Tasks                []*TaskHealth `protobuf:"bytes,4,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{5}
+}
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (dst *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(dst, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
+func (m *HealthResponse) GetStatus() HealthStatus {
+	if m != nil {
+		return m.Status
+	}
+	return HealthStatus_HEALTHY
+}
+
+func (m *HealthResponse) GetPluginStatus() HealthStatus {
+	if m != nil {
+		return m.PluginStatus
+	}
+	return HealthStatus_HEALTHY
+}
+
+func (m *HealthResponse) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+func (m *HealthResponse) GetTasks() []*TaskHealth {
+	if m != nil {
+		return m.Tasks
+	}
+	return nil
+}
+
+type TaskHealth struct {
+	TaskId               string       `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status               HealthStatus `protobuf:"varint,2,opt,name=status,proto3,enum=pluginrpc.HealthStatus" json:"status,omitempty"`
+	Details              string       `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *TaskHealth) Reset()         { *m = TaskHealth{} }
+func (m *TaskHealth) String() string { return proto.CompactTextString(m) }
+func (*TaskHealth) ProtoMessage()    {}
+func (*TaskHealth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{6}
+}
+func (m *TaskHealth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaskHealth.Unmarshal(m, b)
+}
+func (m *TaskHealth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaskHealth.Marshal(b, m, deterministic)
+}
+func (dst *TaskHealth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaskHealth.Merge(dst, src)
+}
+func (m *TaskHealth) XXX_Size() int {
+	return xxx_messageInfo_TaskHealth.Size(m)
+}
+func (m *TaskHealth) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaskHealth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaskHealth proto.InternalMessageInfo
+
+func (m *TaskHealth) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *TaskHealth) GetStatus() HealthStatus {
+	if m != nil {
+		return m.Status
+	}
+	return HealthStatus_HEALTHY
+}
+
+func (m *TaskHealth) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
 type CollectRequest struct {
 	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -174,7 +412,7 @@ func (m *CollectRequest) Reset()         { *m = CollectRequest{} }
 func (m *CollectRequest) String() string { return proto.CompactTextString(m) }
 func (*CollectRequest) ProtoMessage()    {}
 func (*CollectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{4}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{7}
 }
 func (m *CollectRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectRequest.Unmarshal(m, b)
@@ -204,6 +442,7 @@ func (m *CollectRequest) GetTaskId() string {
 type CollectResponse struct {
 	MetricSet            []*Metric  `protobuf:"bytes,1,rep,name=metric_set,json=metricSet,proto3" json:"metric_set,omitempty"`
 	Warnings             []*Warning `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	Events               []*Event   `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -213,7 +452,7 @@ func (m *CollectResponse) Reset()         { *m = CollectResponse{} }
 func (m *CollectResponse) String() string { return proto.CompactTextString(m) }
 func (*CollectResponse) ProtoMessage()    {}
 func (*CollectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{5}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{8}
 }
 func (m *CollectResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectResponse.Unmarshal(m, b)
@@ -247,6 +486,13 @@ func (m *CollectResponse) GetWarnings() []*Warning {
 	return nil
 }
 
+func (m *CollectResponse) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 type LoadCollectorRequest struct {
 	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	JsonConfig           []byte   `protobuf:"bytes,2,opt,name=json_config,json=jsonConfig,proto3" json:"json_config,omitempty"`
@@ -260,7 +506,7 @@ func (m *LoadCollectorRequest) Reset()         { *m = LoadCollectorRequest{} }
 func (m *LoadCollectorRequest) String() string { return proto.CompactTextString(m) }
 func (*LoadCollectorRequest) ProtoMessage()    {}
 func (*LoadCollectorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{6}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{9}
 }
 func (m *LoadCollectorRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadCollectorRequest.Unmarshal(m, b)
@@ -311,7 +557,7 @@ func (m *LoadCollectorResponse) Reset()         { *m = LoadCollectorResponse{} }
 func (m *LoadCollectorResponse) String() string { return proto.CompactTextString(m) }
 func (*LoadCollectorResponse) ProtoMessage()    {}
 func (*LoadCollectorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{7}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{10}
 }
 func (m *LoadCollectorResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LoadCollectorResponse.Unmarshal(m, b)
@@ -342,7 +588,7 @@ func (m *UnloadCollectorRequest) Reset()         { *m = UnloadCollectorRequest{}
 func (m *UnloadCollectorRequest) String() string { return proto.CompactTextString(m) }
 func (*UnloadCollectorRequest) ProtoMessage()    {}
 func (*UnloadCollectorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{8}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{11}
 }
 func (m *UnloadCollectorRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnloadCollectorRequest.Unmarshal(m, b)
@@ -379,7 +625,7 @@ func (m *UnloadCollectorResponse) Reset()         { *m = UnloadCollectorResponse
 func (m *UnloadCollectorResponse) String() string { return proto.CompactTextString(m) }
 func (*UnloadCollectorResponse) ProtoMessage()    {}
 func (*UnloadCollectorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{9}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{12}
 }
 func (m *UnloadCollectorResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnloadCollectorResponse.Unmarshal(m, b)
@@ -399,6 +645,290 @@ func (m *UnloadCollectorResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_UnloadCollectorResponse proto.InternalMessageInfo
 
+type ReconfigureCollectorRequest struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	JsonConfig           []byte   `protobuf:"bytes,2,opt,name=json_config,json=jsonConfig,proto3" json:"json_config,omitempty"`
+	MetricSelectors      []string `protobuf:"bytes,3,rep,name=metric_selectors,json=metricSelectors,proto3" json:"metric_selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconfigureCollectorRequest) Reset()         { *m = ReconfigureCollectorRequest{} }
+func (m *ReconfigureCollectorRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconfigureCollectorRequest) ProtoMessage()    {}
+func (*ReconfigureCollectorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{13}
+}
+func (m *ReconfigureCollectorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconfigureCollectorRequest.Unmarshal(m, b)
+}
+func (m *ReconfigureCollectorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconfigureCollectorRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReconfigureCollectorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconfigureCollectorRequest.Merge(dst, src)
+}
+func (m *ReconfigureCollectorRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconfigureCollectorRequest.Size(m)
+}
+func (m *ReconfigureCollectorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconfigureCollectorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconfigureCollectorRequest proto.InternalMessageInfo
+
+func (m *ReconfigureCollectorRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *ReconfigureCollectorRequest) GetJsonConfig() []byte {
+	if m != nil {
+		return m.JsonConfig
+	}
+	return nil
+}
+
+func (m *ReconfigureCollectorRequest) GetMetricSelectors() []string {
+	if m != nil {
+		return m.MetricSelectors
+	}
+	return nil
+}
+
+type ReconfigureCollectorResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconfigureCollectorResponse) Reset()         { *m = ReconfigureCollectorResponse{} }
+func (m *ReconfigureCollectorResponse) String() string { return proto.CompactTextString(m) }
+func (*ReconfigureCollectorResponse) ProtoMessage()    {}
+func (*ReconfigureCollectorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{14}
+}
+func (m *ReconfigureCollectorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconfigureCollectorResponse.Unmarshal(m, b)
+}
+func (m *ReconfigureCollectorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconfigureCollectorResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReconfigureCollectorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconfigureCollectorResponse.Merge(dst, src)
+}
+func (m *ReconfigureCollectorResponse) XXX_Size() int {
+	return xxx_messageInfo_ReconfigureCollectorResponse.Size(m)
+}
+func (m *ReconfigureCollectorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconfigureCollectorResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconfigureCollectorResponse proto.InternalMessageInfo
+
+type DescribeRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DescribeRequest) Reset()         { *m = DescribeRequest{} }
+func (m *DescribeRequest) String() string { return proto.CompactTextString(m) }
+func (*DescribeRequest) ProtoMessage()    {}
+func (*DescribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{15}
+}
+func (m *DescribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeRequest.Unmarshal(m, b)
+}
+func (m *DescribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeRequest.Marshal(b, m, deterministic)
+}
+func (dst *DescribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeRequest.Merge(dst, src)
+}
+func (m *DescribeRequest) XXX_Size() int {
+	return xxx_messageInfo_DescribeRequest.Size(m)
+}
+func (m *DescribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeRequest proto.InternalMessageInfo
+
+type DescribeResponse struct {
+	Metrics              []*MetricDefinition `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *DescribeResponse) Reset()         { *m = DescribeResponse{} }
+func (m *DescribeResponse) String() string { return proto.CompactTextString(m) }
+func (*DescribeResponse) ProtoMessage()    {}
+func (*DescribeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{16}
+}
+func (m *DescribeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeResponse.Unmarshal(m, b)
+}
+func (m *DescribeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeResponse.Marshal(b, m, deterministic)
+}
+func (dst *DescribeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeResponse.Merge(dst, src)
+}
+func (m *DescribeResponse) XXX_Size() int {
+	return xxx_messageInfo_DescribeResponse.Size(m)
+}
+func (m *DescribeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeResponse proto.InternalMessageInfo
+
+func (m *DescribeResponse) GetMetrics() []*MetricDefinition {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+type MetricDefinition struct {
+	Namespace            string         `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Groups               []*MetricGroup `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	Description          string         `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Unit                 string         `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	IsDefault            bool           `protobuf:"varint,5,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	Kind                 MetricKind     `protobuf:"varint,6,opt,name=kind,proto3,enum=pluginrpc.MetricKind" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MetricDefinition) Reset()         { *m = MetricDefinition{} }
+func (m *MetricDefinition) String() string { return proto.CompactTextString(m) }
+func (*MetricDefinition) ProtoMessage()    {}
+func (*MetricDefinition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{17}
+}
+func (m *MetricDefinition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricDefinition.Unmarshal(m, b)
+}
+func (m *MetricDefinition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricDefinition.Marshal(b, m, deterministic)
+}
+func (dst *MetricDefinition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricDefinition.Merge(dst, src)
+}
+func (m *MetricDefinition) XXX_Size() int {
+	return xxx_messageInfo_MetricDefinition.Size(m)
+}
+func (m *MetricDefinition) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricDefinition.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricDefinition proto.InternalMessageInfo
+
+func (m *MetricDefinition) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *MetricDefinition) GetGroups() []*MetricGroup {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
+}
+
+func (m *MetricDefinition) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *MetricDefinition) GetUnit() string {
+	if m != nil {
+		return m.Unit
+	}
+	return ""
+}
+
+func (m *MetricDefinition) GetIsDefault() bool {
+	if m != nil {
+		return m.IsDefault
+	}
+	return false
+}
+
+func (m *MetricDefinition) GetKind() MetricKind {
+	if m != nil {
+		return m.Kind
+	}
+	return MetricKind_UNSPECIFIED
+}
+
+type MetricGroup struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string   `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Position             int32    `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MetricGroup) Reset()         { *m = MetricGroup{} }
+func (m *MetricGroup) String() string { return proto.CompactTextString(m) }
+func (*MetricGroup) ProtoMessage()    {}
+func (*MetricGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{18}
+}
+func (m *MetricGroup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricGroup.Unmarshal(m, b)
+}
+func (m *MetricGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricGroup.Marshal(b, m, deterministic)
+}
+func (dst *MetricGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricGroup.Merge(dst, src)
+}
+func (m *MetricGroup) XXX_Size() int {
+	return xxx_messageInfo_MetricGroup.Size(m)
+}
+func (m *MetricGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricGroup proto.InternalMessageInfo
+
+func (m *MetricGroup) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MetricGroup) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *MetricGroup) GetPosition() int32 {
+	if m != nil {
+		return m.Position
+	}
+	return 0
+}
+
 type InfoRequest struct {
 	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -410,7 +940,7 @@ func (m *InfoRequest) Reset()         { *m = InfoRequest{} }
 func (m *InfoRequest) String() string { return proto.CompactTextString(m) }
 func (*InfoRequest) ProtoMessage()    {}
 func (*InfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{10}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{19}
 }
 func (m *InfoRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InfoRequest.Unmarshal(m, b)
@@ -449,7 +979,7 @@ func (m *InfoResponse) Reset()         { *m = InfoResponse{} }
 func (m *InfoResponse) String() string { return proto.CompactTextString(m) }
 func (*InfoResponse) ProtoMessage()    {}
 func (*InfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{11}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{20}
 }
 func (m *InfoResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InfoResponse.Unmarshal(m, b)
@@ -473,17 +1003,329 @@ func (m *InfoResponse) GetXLegacyInfo() *XLegacyInfo {
 	if m != nil {
 		return m.XLegacyInfo
 	}
-	return nil
+	return nil
+}
+
+func (m *InfoResponse) GetInfo() []byte {
+	if m != nil {
+		return m.Info
+	}
+	return nil
+}
+
+type PublishRequest struct {
+	TaskId               string    `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	MetricSet            []*Metric `protobuf:"bytes,2,rep,name=metric_set,json=metricSet,proto3" json:"metric_set,omitempty"`
+	Events               []*Event  `protobuf:"bytes,3,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
+func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
+func (*PublishRequest) ProtoMessage()    {}
+func (*PublishRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{21}
+}
+func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+}
+func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+}
+func (dst *PublishRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishRequest.Merge(dst, src)
+}
+func (m *PublishRequest) XXX_Size() int {
+	return xxx_messageInfo_PublishRequest.Size(m)
+}
+func (m *PublishRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+
+func (m *PublishRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *PublishRequest) GetMetricSet() []*Metric {
+	if m != nil {
+		return m.MetricSet
+	}
+	return nil
+}
+
+func (m *PublishRequest) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type PublishResponse struct {
+	Warnings             []*Warning `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
+func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
+func (*PublishResponse) ProtoMessage()    {}
+func (*PublishResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{22}
+}
+func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PublishResponse.Unmarshal(m, b)
+}
+func (m *PublishResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PublishResponse.Marshal(b, m, deterministic)
+}
+func (dst *PublishResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PublishResponse.Merge(dst, src)
+}
+func (m *PublishResponse) XXX_Size() int {
+	return xxx_messageInfo_PublishResponse.Size(m)
+}
+func (m *PublishResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PublishResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PublishResponse proto.InternalMessageInfo
+
+func (m *PublishResponse) GetWarnings() []*Warning {
+	if m != nil {
+		return m.Warnings
+	}
+	return nil
+}
+
+type LoadPublisherRequest struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	JsonConfig           []byte   `protobuf:"bytes,2,opt,name=json_config,json=jsonConfig,proto3" json:"json_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadPublisherRequest) Reset()         { *m = LoadPublisherRequest{} }
+func (m *LoadPublisherRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadPublisherRequest) ProtoMessage()    {}
+func (*LoadPublisherRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{23}
+}
+func (m *LoadPublisherRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadPublisherRequest.Unmarshal(m, b)
+}
+func (m *LoadPublisherRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadPublisherRequest.Marshal(b, m, deterministic)
+}
+func (dst *LoadPublisherRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadPublisherRequest.Merge(dst, src)
+}
+func (m *LoadPublisherRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadPublisherRequest.Size(m)
+}
+func (m *LoadPublisherRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadPublisherRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadPublisherRequest proto.InternalMessageInfo
+
+func (m *LoadPublisherRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *LoadPublisherRequest) GetJsonConfig() []byte {
+	if m != nil {
+		return m.JsonConfig
+	}
+	return nil
+}
+
+type LoadPublisherResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LoadPublisherResponse) Reset()         { *m = LoadPublisherResponse{} }
+func (m *LoadPublisherResponse) String() string { return proto.CompactTextString(m) }
+func (*LoadPublisherResponse) ProtoMessage()    {}
+func (*LoadPublisherResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{24}
+}
+func (m *LoadPublisherResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadPublisherResponse.Unmarshal(m, b)
+}
+func (m *LoadPublisherResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadPublisherResponse.Marshal(b, m, deterministic)
+}
+func (dst *LoadPublisherResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadPublisherResponse.Merge(dst, src)
+}
+func (m *LoadPublisherResponse) XXX_Size() int {
+	return xxx_messageInfo_LoadPublisherResponse.Size(m)
+}
+func (m *LoadPublisherResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadPublisherResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LoadPublisherResponse proto.InternalMessageInfo
+
+type UnloadPublisherRequest struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnloadPublisherRequest) Reset()         { *m = UnloadPublisherRequest{} }
+func (m *UnloadPublisherRequest) String() string { return proto.CompactTextString(m) }
+func (*UnloadPublisherRequest) ProtoMessage()    {}
+func (*UnloadPublisherRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{25}
+}
+func (m *UnloadPublisherRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnloadPublisherRequest.Unmarshal(m, b)
+}
+func (m *UnloadPublisherRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnloadPublisherRequest.Marshal(b, m, deterministic)
+}
+func (dst *UnloadPublisherRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnloadPublisherRequest.Merge(dst, src)
+}
+func (m *UnloadPublisherRequest) XXX_Size() int {
+	return xxx_messageInfo_UnloadPublisherRequest.Size(m)
+}
+func (m *UnloadPublisherRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnloadPublisherRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnloadPublisherRequest proto.InternalMessageInfo
+
+func (m *UnloadPublisherRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+type UnloadPublisherResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnloadPublisherResponse) Reset()         { *m = UnloadPublisherResponse{} }
+func (m *UnloadPublisherResponse) String() string { return proto.CompactTextString(m) }
+func (*UnloadPublisherResponse) ProtoMessage()    {}
+func (*UnloadPublisherResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{26}
+}
+func (m *UnloadPublisherResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnloadPublisherResponse.Unmarshal(m, b)
+}
+func (m *UnloadPublisherResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnloadPublisherResponse.Marshal(b, m, deterministic)
+}
+func (dst *UnloadPublisherResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnloadPublisherResponse.Merge(dst, src)
+}
+func (m *UnloadPublisherResponse) XXX_Size() int {
+	return xxx_messageInfo_UnloadPublisherResponse.Size(m)
+}
+func (m *UnloadPublisherResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnloadPublisherResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnloadPublisherResponse proto.InternalMessageInfo
+
+type ReconfigurePublisherRequest struct {
+	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	JsonConfig           []byte   `protobuf:"bytes,2,opt,name=json_config,json=jsonConfig,proto3" json:"json_config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconfigurePublisherRequest) Reset()         { *m = ReconfigurePublisherRequest{} }
+func (m *ReconfigurePublisherRequest) String() string { return proto.CompactTextString(m) }
+func (*ReconfigurePublisherRequest) ProtoMessage()    {}
+func (*ReconfigurePublisherRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{27}
+}
+func (m *ReconfigurePublisherRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconfigurePublisherRequest.Unmarshal(m, b)
+}
+func (m *ReconfigurePublisherRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconfigurePublisherRequest.Marshal(b, m, deterministic)
+}
+func (dst *ReconfigurePublisherRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconfigurePublisherRequest.Merge(dst, src)
+}
+func (m *ReconfigurePublisherRequest) XXX_Size() int {
+	return xxx_messageInfo_ReconfigurePublisherRequest.Size(m)
+}
+func (m *ReconfigurePublisherRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconfigurePublisherRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconfigurePublisherRequest proto.InternalMessageInfo
+
+func (m *ReconfigurePublisherRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
 }
 
-func (m *InfoResponse) GetInfo() []byte {
+func (m *ReconfigurePublisherRequest) GetJsonConfig() []byte {
 	if m != nil {
-		return m.Info
+		return m.JsonConfig
 	}
 	return nil
 }
 
-type PublishRequest struct {
+type ReconfigurePublisherResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconfigurePublisherResponse) Reset()         { *m = ReconfigurePublisherResponse{} }
+func (m *ReconfigurePublisherResponse) String() string { return proto.CompactTextString(m) }
+func (*ReconfigurePublisherResponse) ProtoMessage()    {}
+func (*ReconfigurePublisherResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{28}
+}
+func (m *ReconfigurePublisherResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconfigurePublisherResponse.Unmarshal(m, b)
+}
+func (m *ReconfigurePublisherResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconfigurePublisherResponse.Marshal(b, m, deterministic)
+}
+func (dst *ReconfigurePublisherResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconfigurePublisherResponse.Merge(dst, src)
+}
+func (m *ReconfigurePublisherResponse) XXX_Size() int {
+	return xxx_messageInfo_ReconfigurePublisherResponse.Size(m)
+}
+func (m *ReconfigurePublisherResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconfigurePublisherResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconfigurePublisherResponse proto.InternalMessageInfo
+
+type ProcessRequest struct {
 	TaskId               string    `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	MetricSet            []*Metric `protobuf:"bytes,2,rep,name=metric_set,json=metricSet,proto3" json:"metric_set,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
@@ -491,83 +1333,91 @@ type PublishRequest struct {
 	XXX_sizecache        int32     `json:"-"`
 }
 
-func (m *PublishRequest) Reset()         { *m = PublishRequest{} }
-func (m *PublishRequest) String() string { return proto.CompactTextString(m) }
-func (*PublishRequest) ProtoMessage()    {}
-func (*PublishRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{12}
+func (m *ProcessRequest) Reset()         { *m = ProcessRequest{} }
+func (m *ProcessRequest) String() string { return proto.CompactTextString(m) }
+func (*ProcessRequest) ProtoMessage()    {}
+func (*ProcessRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{29}
 }
-func (m *PublishRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PublishRequest.Unmarshal(m, b)
+func (m *ProcessRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessRequest.Unmarshal(m, b)
 }
-func (m *PublishRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PublishRequest.Marshal(b, m, deterministic)
+func (m *ProcessRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessRequest.Marshal(b, m, deterministic)
 }
-func (dst *PublishRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PublishRequest.Merge(dst, src)
+func (dst *ProcessRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessRequest.Merge(dst, src)
 }
-func (m *PublishRequest) XXX_Size() int {
-	return xxx_messageInfo_PublishRequest.Size(m)
+func (m *ProcessRequest) XXX_Size() int {
+	return xxx_messageInfo_ProcessRequest.Size(m)
 }
-func (m *PublishRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PublishRequest.DiscardUnknown(m)
+func (m *ProcessRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PublishRequest proto.InternalMessageInfo
+var xxx_messageInfo_ProcessRequest proto.InternalMessageInfo
 
-func (m *PublishRequest) GetTaskId() string {
+func (m *ProcessRequest) GetTaskId() string {
 	if m != nil {
 		return m.TaskId
 	}
 	return ""
 }
 
-func (m *PublishRequest) GetMetricSet() []*Metric {
+func (m *ProcessRequest) GetMetricSet() []*Metric {
 	if m != nil {
 		return m.MetricSet
 	}
 	return nil
 }
 
-type PublishResponse struct {
-	Warnings             []*Warning `protobuf:"bytes,1,rep,name=warnings,proto3" json:"warnings,omitempty"`
+type ProcessResponse struct {
+	MetricSet            []*Metric  `protobuf:"bytes,1,rep,name=metric_set,json=metricSet,proto3" json:"metric_set,omitempty"`
+	Warnings             []*Warning `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
 }
 
-func (m *PublishResponse) Reset()         { *m = PublishResponse{} }
-func (m *PublishResponse) String() string { return proto.CompactTextString(m) }
-func (*PublishResponse) ProtoMessage()    {}
-func (*PublishResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{13}
+func (m *ProcessResponse) Reset()         { *m = ProcessResponse{} }
+func (m *ProcessResponse) String() string { return proto.CompactTextString(m) }
+func (*ProcessResponse) ProtoMessage()    {}
+func (*ProcessResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{30}
 }
-func (m *PublishResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PublishResponse.Unmarshal(m, b)
+func (m *ProcessResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProcessResponse.Unmarshal(m, b)
 }
-func (m *PublishResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PublishResponse.Marshal(b, m, deterministic)
+func (m *ProcessResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProcessResponse.Marshal(b, m, deterministic)
 }
-func (dst *PublishResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PublishResponse.Merge(dst, src)
+func (dst *ProcessResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProcessResponse.Merge(dst, src)
 }
-func (m *PublishResponse) XXX_Size() int {
-	return xxx_messageInfo_PublishResponse.Size(m)
+func (m *ProcessResponse) XXX_Size() int {
+	return xxx_messageInfo_ProcessResponse.Size(m)
 }
-func (m *PublishResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PublishResponse.DiscardUnknown(m)
+func (m *ProcessResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProcessResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PublishResponse proto.InternalMessageInfo
+var xxx_messageInfo_ProcessResponse proto.InternalMessageInfo
 
-func (m *PublishResponse) GetWarnings() []*Warning {
+func (m *ProcessResponse) GetMetricSet() []*Metric {
+	if m != nil {
+		return m.MetricSet
+	}
+	return nil
+}
+
+func (m *ProcessResponse) GetWarnings() []*Warning {
 	if m != nil {
 		return m.Warnings
 	}
 	return nil
 }
 
-type LoadPublisherRequest struct {
+type LoadProcessorRequest struct {
 	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	JsonConfig           []byte   `protobuf:"bytes,2,opt,name=json_config,json=jsonConfig,proto3" json:"json_config,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -575,141 +1425,141 @@ type LoadPublisherRequest struct {
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LoadPublisherRequest) Reset()         { *m = LoadPublisherRequest{} }
-func (m *LoadPublisherRequest) String() string { return proto.CompactTextString(m) }
-func (*LoadPublisherRequest) ProtoMessage()    {}
-func (*LoadPublisherRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{14}
+func (m *LoadProcessorRequest) Reset()         { *m = LoadProcessorRequest{} }
+func (m *LoadProcessorRequest) String() string { return proto.CompactTextString(m) }
+func (*LoadProcessorRequest) ProtoMessage()    {}
+func (*LoadProcessorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{31}
 }
-func (m *LoadPublisherRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LoadPublisherRequest.Unmarshal(m, b)
+func (m *LoadProcessorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadProcessorRequest.Unmarshal(m, b)
 }
-func (m *LoadPublisherRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LoadPublisherRequest.Marshal(b, m, deterministic)
+func (m *LoadProcessorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadProcessorRequest.Marshal(b, m, deterministic)
 }
-func (dst *LoadPublisherRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LoadPublisherRequest.Merge(dst, src)
+func (dst *LoadProcessorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadProcessorRequest.Merge(dst, src)
 }
-func (m *LoadPublisherRequest) XXX_Size() int {
-	return xxx_messageInfo_LoadPublisherRequest.Size(m)
+func (m *LoadProcessorRequest) XXX_Size() int {
+	return xxx_messageInfo_LoadProcessorRequest.Size(m)
 }
-func (m *LoadPublisherRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LoadPublisherRequest.DiscardUnknown(m)
+func (m *LoadProcessorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadProcessorRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LoadPublisherRequest proto.InternalMessageInfo
+var xxx_messageInfo_LoadProcessorRequest proto.InternalMessageInfo
 
-func (m *LoadPublisherRequest) GetTaskId() string {
+func (m *LoadProcessorRequest) GetTaskId() string {
 	if m != nil {
 		return m.TaskId
 	}
 	return ""
 }
 
-func (m *LoadPublisherRequest) GetJsonConfig() []byte {
+func (m *LoadProcessorRequest) GetJsonConfig() []byte {
 	if m != nil {
 		return m.JsonConfig
 	}
 	return nil
 }
 
-type LoadPublisherResponse struct {
+type LoadProcessorResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LoadPublisherResponse) Reset()         { *m = LoadPublisherResponse{} }
-func (m *LoadPublisherResponse) String() string { return proto.CompactTextString(m) }
-func (*LoadPublisherResponse) ProtoMessage()    {}
-func (*LoadPublisherResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{15}
+func (m *LoadProcessorResponse) Reset()         { *m = LoadProcessorResponse{} }
+func (m *LoadProcessorResponse) String() string { return proto.CompactTextString(m) }
+func (*LoadProcessorResponse) ProtoMessage()    {}
+func (*LoadProcessorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{32}
 }
-func (m *LoadPublisherResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LoadPublisherResponse.Unmarshal(m, b)
+func (m *LoadProcessorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LoadProcessorResponse.Unmarshal(m, b)
 }
-func (m *LoadPublisherResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LoadPublisherResponse.Marshal(b, m, deterministic)
+func (m *LoadProcessorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LoadProcessorResponse.Marshal(b, m, deterministic)
 }
-func (dst *LoadPublisherResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LoadPublisherResponse.Merge(dst, src)
+func (dst *LoadProcessorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LoadProcessorResponse.Merge(dst, src)
 }
-func (m *LoadPublisherResponse) XXX_Size() int {
-	return xxx_messageInfo_LoadPublisherResponse.Size(m)
+func (m *LoadProcessorResponse) XXX_Size() int {
+	return xxx_messageInfo_LoadProcessorResponse.Size(m)
 }
-func (m *LoadPublisherResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LoadPublisherResponse.DiscardUnknown(m)
+func (m *LoadProcessorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LoadProcessorResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LoadPublisherResponse proto.InternalMessageInfo
+var xxx_messageInfo_LoadProcessorResponse proto.InternalMessageInfo
 
-type UnloadPublisherRequest struct {
+type UnloadProcessorRequest struct {
 	TaskId               string   `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnloadPublisherRequest) Reset()         { *m = UnloadPublisherRequest{} }
-func (m *UnloadPublisherRequest) String() string { return proto.CompactTextString(m) }
-func (*UnloadPublisherRequest) ProtoMessage()    {}
-func (*UnloadPublisherRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{16}
+func (m *UnloadProcessorRequest) Reset()         { *m = UnloadProcessorRequest{} }
+func (m *UnloadProcessorRequest) String() string { return proto.CompactTextString(m) }
+func (*UnloadProcessorRequest) ProtoMessage()    {}
+func (*UnloadProcessorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{33}
 }
-func (m *UnloadPublisherRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnloadPublisherRequest.Unmarshal(m, b)
+func (m *UnloadProcessorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnloadProcessorRequest.Unmarshal(m, b)
 }
-func (m *UnloadPublisherRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnloadPublisherRequest.Marshal(b, m, deterministic)
+func (m *UnloadProcessorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnloadProcessorRequest.Marshal(b, m, deterministic)
 }
-func (dst *UnloadPublisherRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnloadPublisherRequest.Merge(dst, src)
+func (dst *UnloadProcessorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnloadProcessorRequest.Merge(dst, src)
 }
-func (m *UnloadPublisherRequest) XXX_Size() int {
-	return xxx_messageInfo_UnloadPublisherRequest.Size(m)
+func (m *UnloadProcessorRequest) XXX_Size() int {
+	return xxx_messageInfo_UnloadProcessorRequest.Size(m)
 }
-func (m *UnloadPublisherRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnloadPublisherRequest.DiscardUnknown(m)
+func (m *UnloadProcessorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnloadProcessorRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnloadPublisherRequest proto.InternalMessageInfo
+var xxx_messageInfo_UnloadProcessorRequest proto.InternalMessageInfo
 
-func (m *UnloadPublisherRequest) GetTaskId() string {
+func (m *UnloadProcessorRequest) GetTaskId() string {
 	if m != nil {
 		return m.TaskId
 	}
 	return ""
 }
 
-type UnloadPublisherResponse struct {
+type UnloadProcessorResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *UnloadPublisherResponse) Reset()         { *m = UnloadPublisherResponse{} }
-func (m *UnloadPublisherResponse) String() string { return proto.CompactTextString(m) }
-func (*UnloadPublisherResponse) ProtoMessage()    {}
-func (*UnloadPublisherResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{17}
+func (m *UnloadProcessorResponse) Reset()         { *m = UnloadProcessorResponse{} }
+func (m *UnloadProcessorResponse) String() string { return proto.CompactTextString(m) }
+func (*UnloadProcessorResponse) ProtoMessage()    {}
+func (*UnloadProcessorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{34}
 }
-func (m *UnloadPublisherResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_UnloadPublisherResponse.Unmarshal(m, b)
+func (m *UnloadProcessorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnloadProcessorResponse.Unmarshal(m, b)
 }
-func (m *UnloadPublisherResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_UnloadPublisherResponse.Marshal(b, m, deterministic)
+func (m *UnloadProcessorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnloadProcessorResponse.Marshal(b, m, deterministic)
 }
-func (dst *UnloadPublisherResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UnloadPublisherResponse.Merge(dst, src)
+func (dst *UnloadProcessorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnloadProcessorResponse.Merge(dst, src)
 }
-func (m *UnloadPublisherResponse) XXX_Size() int {
-	return xxx_messageInfo_UnloadPublisherResponse.Size(m)
+func (m *UnloadProcessorResponse) XXX_Size() int {
+	return xxx_messageInfo_UnloadProcessorResponse.Size(m)
 }
-func (m *UnloadPublisherResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UnloadPublisherResponse.DiscardUnknown(m)
+func (m *UnloadProcessorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnloadProcessorResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UnloadPublisherResponse proto.InternalMessageInfo
+var xxx_messageInfo_UnloadProcessorResponse proto.InternalMessageInfo
 
 type Metric struct {
 	Namespace            []*Namespace      `protobuf:"bytes,1,rep,name=namespace,proto3" json:"namespace,omitempty"`
@@ -718,6 +1568,7 @@ type Metric struct {
 	Timestamp            *Time             `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Description          string            `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	Unit                 string            `protobuf:"bytes,6,opt,name=unit,proto3" json:"unit,omitempty"`
+	Kind                 MetricKind        `protobuf:"varint,7,opt,name=kind,proto3,enum=pluginrpc.MetricKind" json:"kind,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -727,7 +1578,7 @@ func (m *Metric) Reset()         { *m = Metric{} }
 func (m *Metric) String() string { return proto.CompactTextString(m) }
 func (*Metric) ProtoMessage()    {}
 func (*Metric) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{18}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{35}
 }
 func (m *Metric) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metric.Unmarshal(m, b)
@@ -789,6 +1640,13 @@ func (m *Metric) GetUnit() string {
 	return ""
 }
 
+func (m *Metric) GetKind() MetricKind {
+	if m != nil {
+		return m.Kind
+	}
+	return MetricKind_UNSPECIFIED
+}
+
 type Namespace struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
@@ -802,7 +1660,7 @@ func (m *Namespace) Reset()         { *m = Namespace{} }
 func (m *Namespace) String() string { return proto.CompactTextString(m) }
 func (*Namespace) ProtoMessage()    {}
 func (*Namespace) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{19}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{36}
 }
 func (m *Namespace) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Namespace.Unmarshal(m, b)
@@ -856,6 +1714,8 @@ type MetricValue struct {
 	//	*MetricValue_VString
 	//	*MetricValue_VInt16
 	//	*MetricValue_VUint16
+	//	*MetricValue_VHistogram
+	//	*MetricValue_VSummary
 	DataVariant          isMetricValue_DataVariant `protobuf_oneof:"data_variant"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
 	XXX_unrecognized     []byte                    `json:"-"`
@@ -866,7 +1726,7 @@ func (m *MetricValue) Reset()         { *m = MetricValue{} }
 func (m *MetricValue) String() string { return proto.CompactTextString(m) }
 func (*MetricValue) ProtoMessage()    {}
 func (*MetricValue) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{20}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{37}
 }
 func (m *MetricValue) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MetricValue.Unmarshal(m, b)
@@ -934,6 +1794,14 @@ type MetricValue_VUint16 struct {
 	VUint16 uint32 `protobuf:"varint,11,opt,name=v_uint16,json=vUint16,proto3,oneof"`
 }
 
+type MetricValue_VHistogram struct {
+	VHistogram *Histogram `protobuf:"bytes,12,opt,name=v_histogram,json=vHistogram,proto3,oneof"`
+}
+
+type MetricValue_VSummary struct {
+	VSummary *Summary `protobuf:"bytes,13,opt,name=v_summary,json=vSummary,proto3,oneof"`
+}
+
 func (*MetricValue_VFloat) isMetricValue_DataVariant() {}
 
 func (*MetricValue_VDouble) isMetricValue_DataVariant() {}
@@ -956,6 +1824,10 @@ func (*MetricValue_VInt16) isMetricValue_DataVariant() {}
 
 func (*MetricValue_VUint16) isMetricValue_DataVariant() {}
 
+func (*MetricValue_VHistogram) isMetricValue_DataVariant() {}
+
+func (*MetricValue_VSummary) isMetricValue_DataVariant() {}
+
 func (m *MetricValue) GetDataVariant() isMetricValue_DataVariant {
 	if m != nil {
 		return m.DataVariant
@@ -1040,6 +1912,20 @@ func (m *MetricValue) GetVUint16() uint32 {
 	return 0
 }
 
+func (m *MetricValue) GetVHistogram() *Histogram {
+	if x, ok := m.GetDataVariant().(*MetricValue_VHistogram); ok {
+		return x.VHistogram
+	}
+	return nil
+}
+
+func (m *MetricValue) GetVSummary() *Summary {
+	if x, ok := m.GetDataVariant().(*MetricValue_VSummary); ok {
+		return x.VSummary
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*MetricValue) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _MetricValue_OneofMarshaler, _MetricValue_OneofUnmarshaler, _MetricValue_OneofSizer, []interface{}{
@@ -1054,6 +1940,8 @@ func (*MetricValue) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) e
 		(*MetricValue_VString)(nil),
 		(*MetricValue_VInt16)(nil),
 		(*MetricValue_VUint16)(nil),
+		(*MetricValue_VHistogram)(nil),
+		(*MetricValue_VSummary)(nil),
 	}
 }
 
@@ -1098,6 +1986,16 @@ func _MetricValue_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 	case *MetricValue_VUint16:
 		b.EncodeVarint(11<<3 | proto.WireVarint)
 		b.EncodeVarint(uint64(x.VUint16))
+	case *MetricValue_VHistogram:
+		b.EncodeVarint(12<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VHistogram); err != nil {
+			return err
+		}
+	case *MetricValue_VSummary:
+		b.EncodeVarint(13<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.VSummary); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("MetricValue.DataVariant has unexpected type %T", x)
@@ -1185,6 +2083,22 @@ func _MetricValue_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Bu
 		x, err := b.DecodeVarint()
 		m.DataVariant = &MetricValue_VUint16{uint32(x)}
 		return true, err
+	case 12: // data_variant.v_histogram
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Histogram)
+		err := b.DecodeMessage(msg)
+		m.DataVariant = &MetricValue_VHistogram{msg}
+		return true, err
+	case 13: // data_variant.v_summary
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Summary)
+		err := b.DecodeMessage(msg)
+		m.DataVariant = &MetricValue_VSummary{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -1229,6 +2143,16 @@ func _MetricValue_OneofSizer(msg proto.Message) (n int) {
 	case *MetricValue_VUint16:
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(x.VUint16))
+	case *MetricValue_VHistogram:
+		s := proto.Size(x.VHistogram)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *MetricValue_VSummary:
+		s := proto.Size(x.VSummary)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -1236,6 +2160,206 @@ func _MetricValue_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+type Histogram struct {
+	Buckets              []*HistogramBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Sum                  float64            `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Count                uint64             `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Histogram) Reset()         { *m = Histogram{} }
+func (m *Histogram) String() string { return proto.CompactTextString(m) }
+func (*Histogram) ProtoMessage()    {}
+func (*Histogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{38}
+}
+func (m *Histogram) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Histogram.Unmarshal(m, b)
+}
+func (m *Histogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Histogram.Marshal(b, m, deterministic)
+}
+func (dst *Histogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Histogram.Merge(dst, src)
+}
+func (m *Histogram) XXX_Size() int {
+	return xxx_messageInfo_Histogram.Size(m)
+}
+func (m *Histogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_Histogram.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Histogram proto.InternalMessageInfo
+
+func (m *Histogram) GetBuckets() []*HistogramBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *Histogram) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *Histogram) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type HistogramBucket struct {
+	UpperBound           float64  `protobuf:"fixed64,1,opt,name=upper_bound,json=upperBound,proto3" json:"upper_bound,omitempty"`
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistogramBucket) Reset()         { *m = HistogramBucket{} }
+func (m *HistogramBucket) String() string { return proto.CompactTextString(m) }
+func (*HistogramBucket) ProtoMessage()    {}
+func (*HistogramBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{39}
+}
+func (m *HistogramBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistogramBucket.Unmarshal(m, b)
+}
+func (m *HistogramBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistogramBucket.Marshal(b, m, deterministic)
+}
+func (dst *HistogramBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistogramBucket.Merge(dst, src)
+}
+func (m *HistogramBucket) XXX_Size() int {
+	return xxx_messageInfo_HistogramBucket.Size(m)
+}
+func (m *HistogramBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistogramBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistogramBucket proto.InternalMessageInfo
+
+func (m *HistogramBucket) GetUpperBound() float64 {
+	if m != nil {
+		return m.UpperBound
+	}
+	return 0
+}
+
+func (m *HistogramBucket) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type Summary struct {
+	Quantiles            []*SummaryQuantile `protobuf:"bytes,1,rep,name=quantiles,proto3" json:"quantiles,omitempty"`
+	Sum                  float64            `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Count                uint64             `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *Summary) Reset()         { *m = Summary{} }
+func (m *Summary) String() string { return proto.CompactTextString(m) }
+func (*Summary) ProtoMessage()    {}
+func (*Summary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{40}
+}
+func (m *Summary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Summary.Unmarshal(m, b)
+}
+func (m *Summary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Summary.Marshal(b, m, deterministic)
+}
+func (dst *Summary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Summary.Merge(dst, src)
+}
+func (m *Summary) XXX_Size() int {
+	return xxx_messageInfo_Summary.Size(m)
+}
+func (m *Summary) XXX_DiscardUnknown() {
+	xxx_messageInfo_Summary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Summary proto.InternalMessageInfo
+
+func (m *Summary) GetQuantiles() []*SummaryQuantile {
+	if m != nil {
+		return m.Quantiles
+	}
+	return nil
+}
+
+func (m *Summary) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *Summary) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type SummaryQuantile struct {
+	Quantile             float64  `protobuf:"fixed64,1,opt,name=quantile,proto3" json:"quantile,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SummaryQuantile) Reset()         { *m = SummaryQuantile{} }
+func (m *SummaryQuantile) String() string { return proto.CompactTextString(m) }
+func (*SummaryQuantile) ProtoMessage()    {}
+func (*SummaryQuantile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{41}
+}
+func (m *SummaryQuantile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SummaryQuantile.Unmarshal(m, b)
+}
+func (m *SummaryQuantile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SummaryQuantile.Marshal(b, m, deterministic)
+}
+func (dst *SummaryQuantile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SummaryQuantile.Merge(dst, src)
+}
+func (m *SummaryQuantile) XXX_Size() int {
+	return xxx_messageInfo_SummaryQuantile.Size(m)
+}
+func (m *SummaryQuantile) XXX_DiscardUnknown() {
+	xxx_messageInfo_SummaryQuantile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SummaryQuantile proto.InternalMessageInfo
+
+func (m *SummaryQuantile) GetQuantile() float64 {
+	if m != nil {
+		return m.Quantile
+	}
+	return 0
+}
+
+func (m *SummaryQuantile) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
 type Time struct {
 	Sec                  int64    `protobuf:"varint,1,opt,name=sec,proto3" json:"sec,omitempty"`
 	Nsec                 int64    `protobuf:"varint,2,opt,name=nsec,proto3" json:"nsec,omitempty"`
@@ -1248,7 +2372,7 @@ func (m *Time) Reset()         { *m = Time{} }
 func (m *Time) String() string { return proto.CompactTextString(m) }
 func (*Time) ProtoMessage()    {}
 func (*Time) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{21}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{42}
 }
 func (m *Time) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Time.Unmarshal(m, b)
@@ -1294,7 +2418,7 @@ func (m *Warning) Reset()         { *m = Warning{} }
 func (m *Warning) String() string { return proto.CompactTextString(m) }
 func (*Warning) ProtoMessage()    {}
 func (*Warning) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{22}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{43}
 }
 func (m *Warning) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Warning.Unmarshal(m, b)
@@ -1328,6 +2452,76 @@ func (m *Warning) GetTimestamp() *Time {
 	return nil
 }
 
+type Event struct {
+	Title                string            `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Text                 string            `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Severity             EventSeverity     `protobuf:"varint,3,opt,name=severity,proto3,enum=pluginrpc.EventSeverity" json:"severity,omitempty"`
+	Tags                 map[string]string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timestamp            *Time             `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{44}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (dst *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(dst, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetTitle() string {
+	if m != nil {
+		return m.Title
+	}
+	return ""
+}
+
+func (m *Event) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
+func (m *Event) GetSeverity() EventSeverity {
+	if m != nil {
+		return m.Severity
+	}
+	return EventSeverity_INFO
+}
+
+func (m *Event) GetTags() map[string]string {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+func (m *Event) GetTimestamp() *Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
 type XLegacyInfo struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1338,7 +2532,7 @@ func (m *XLegacyInfo) Reset()         { *m = XLegacyInfo{} }
 func (m *XLegacyInfo) String() string { return proto.CompactTextString(m) }
 func (*XLegacyInfo) ProtoMessage()    {}
 func (*XLegacyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_plugin_v2_9115f353f46e4cca, []int{23}
+	return fileDescriptor_plugin_v2_4289b8631e13b053, []int{45}
 }
 func (m *XLegacyInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_XLegacyInfo.Unmarshal(m, b)
@@ -1363,12 +2557,21 @@ func init() {
 	proto.RegisterType((*PingResponse)(nil), "pluginrpc.PingResponse")
 	proto.RegisterType((*KillRequest)(nil), "pluginrpc.KillRequest")
 	proto.RegisterType((*KillResponse)(nil), "pluginrpc.KillResponse")
+	proto.RegisterType((*HealthRequest)(nil), "pluginrpc.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "pluginrpc.HealthResponse")
+	proto.RegisterType((*TaskHealth)(nil), "pluginrpc.TaskHealth")
 	proto.RegisterType((*CollectRequest)(nil), "pluginrpc.CollectRequest")
 	proto.RegisterType((*CollectResponse)(nil), "pluginrpc.CollectResponse")
 	proto.RegisterType((*LoadCollectorRequest)(nil), "pluginrpc.LoadCollectorRequest")
 	proto.RegisterType((*LoadCollectorResponse)(nil), "pluginrpc.LoadCollectorResponse")
 	proto.RegisterType((*UnloadCollectorRequest)(nil), "pluginrpc.UnloadCollectorRequest")
 	proto.RegisterType((*UnloadCollectorResponse)(nil), "pluginrpc.UnloadCollectorResponse")
+	proto.RegisterType((*ReconfigureCollectorRequest)(nil), "pluginrpc.ReconfigureCollectorRequest")
+	proto.RegisterType((*ReconfigureCollectorResponse)(nil), "pluginrpc.ReconfigureCollectorResponse")
+	proto.RegisterType((*DescribeRequest)(nil), "pluginrpc.DescribeRequest")
+	proto.RegisterType((*DescribeResponse)(nil), "pluginrpc.DescribeResponse")
+	proto.RegisterType((*MetricDefinition)(nil), "pluginrpc.MetricDefinition")
+	proto.RegisterType((*MetricGroup)(nil), "pluginrpc.MetricGroup")
 	proto.RegisterType((*InfoRequest)(nil), "pluginrpc.InfoRequest")
 	proto.RegisterType((*InfoResponse)(nil), "pluginrpc.InfoResponse")
 	proto.RegisterType((*PublishRequest)(nil), "pluginrpc.PublishRequest")
@@ -1377,13 +2580,30 @@ func init() {
 	proto.RegisterType((*LoadPublisherResponse)(nil), "pluginrpc.LoadPublisherResponse")
 	proto.RegisterType((*UnloadPublisherRequest)(nil), "pluginrpc.UnloadPublisherRequest")
 	proto.RegisterType((*UnloadPublisherResponse)(nil), "pluginrpc.UnloadPublisherResponse")
+	proto.RegisterType((*ReconfigurePublisherRequest)(nil), "pluginrpc.ReconfigurePublisherRequest")
+	proto.RegisterType((*ReconfigurePublisherResponse)(nil), "pluginrpc.ReconfigurePublisherResponse")
+	proto.RegisterType((*ProcessRequest)(nil), "pluginrpc.ProcessRequest")
+	proto.RegisterType((*ProcessResponse)(nil), "pluginrpc.ProcessResponse")
+	proto.RegisterType((*LoadProcessorRequest)(nil), "pluginrpc.LoadProcessorRequest")
+	proto.RegisterType((*LoadProcessorResponse)(nil), "pluginrpc.LoadProcessorResponse")
+	proto.RegisterType((*UnloadProcessorRequest)(nil), "pluginrpc.UnloadProcessorRequest")
+	proto.RegisterType((*UnloadProcessorResponse)(nil), "pluginrpc.UnloadProcessorResponse")
 	proto.RegisterType((*Metric)(nil), "pluginrpc.Metric")
 	proto.RegisterMapType((map[string]string)(nil), "pluginrpc.Metric.TagsEntry")
 	proto.RegisterType((*Namespace)(nil), "pluginrpc.Namespace")
 	proto.RegisterType((*MetricValue)(nil), "pluginrpc.MetricValue")
+	proto.RegisterType((*Histogram)(nil), "pluginrpc.Histogram")
+	proto.RegisterType((*HistogramBucket)(nil), "pluginrpc.HistogramBucket")
+	proto.RegisterType((*Summary)(nil), "pluginrpc.Summary")
+	proto.RegisterType((*SummaryQuantile)(nil), "pluginrpc.SummaryQuantile")
 	proto.RegisterType((*Time)(nil), "pluginrpc.Time")
 	proto.RegisterType((*Warning)(nil), "pluginrpc.Warning")
+	proto.RegisterType((*Event)(nil), "pluginrpc.Event")
+	proto.RegisterMapType((map[string]string)(nil), "pluginrpc.Event.TagsEntry")
 	proto.RegisterType((*XLegacyInfo)(nil), "pluginrpc._legacy_info")
+	proto.RegisterEnum("pluginrpc.HealthStatus", HealthStatus_name, HealthStatus_value)
+	proto.RegisterEnum("pluginrpc.MetricKind", MetricKind_name, MetricKind_value)
+	proto.RegisterEnum("pluginrpc.EventSeverity", EventSeverity_name, EventSeverity_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1400,6 +2620,7 @@ const _ = grpc.SupportPackageIsVersion4
 type ControllerClient interface {
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 	Kill(ctx context.Context, in *KillRequest, opts ...grpc.CallOption) (*KillResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
 }
 
 type controllerClient struct {
@@ -1428,10 +2649,20 @@ func (c *controllerClient) Kill(ctx context.Context, in *KillRequest, opts ...gr
 	return out, nil
 }
 
+func (c *controllerClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Controller/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ControllerServer is the server API for Controller service.
 type ControllerServer interface {
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	Kill(context.Context, *KillRequest) (*KillResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
 }
 
 func RegisterControllerServer(s *grpc.Server, srv ControllerServer) {
@@ -1474,6 +2705,24 @@ func _Controller_Kill_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Controller_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControllerServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Controller/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControllerServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Controller_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pluginrpc.Controller",
 	HandlerType: (*ControllerServer)(nil),
@@ -1486,6 +2735,10 @@ var _Controller_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Kill",
 			Handler:    _Controller_Kill_Handler,
 		},
+		{
+			MethodName: "Health",
+			Handler:    _Controller_Health_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "plugin_v2.proto",
@@ -1498,7 +2751,9 @@ type CollectorClient interface {
 	Collect(ctx context.Context, in *CollectRequest, opts ...grpc.CallOption) (Collector_CollectClient, error)
 	Load(ctx context.Context, in *LoadCollectorRequest, opts ...grpc.CallOption) (*LoadCollectorResponse, error)
 	Unload(ctx context.Context, in *UnloadCollectorRequest, opts ...grpc.CallOption) (*UnloadCollectorResponse, error)
+	Reconfigure(ctx context.Context, in *ReconfigureCollectorRequest, opts ...grpc.CallOption) (*ReconfigureCollectorResponse, error)
 	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+	Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error)
 }
 
 type collectorClient struct {
@@ -1559,6 +2814,15 @@ func (c *collectorClient) Unload(ctx context.Context, in *UnloadCollectorRequest
 	return out, nil
 }
 
+func (c *collectorClient) Reconfigure(ctx context.Context, in *ReconfigureCollectorRequest, opts ...grpc.CallOption) (*ReconfigureCollectorResponse, error) {
+	out := new(ReconfigureCollectorResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Collector/Reconfigure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *collectorClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
 	out := new(InfoResponse)
 	err := c.cc.Invoke(ctx, "/pluginrpc.Collector/Info", in, out, opts...)
@@ -1568,12 +2832,23 @@ func (c *collectorClient) Info(ctx context.Context, in *InfoRequest, opts ...grp
 	return out, nil
 }
 
+func (c *collectorClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Collector/Describe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CollectorServer is the server API for Collector service.
 type CollectorServer interface {
 	Collect(*CollectRequest, Collector_CollectServer) error
 	Load(context.Context, *LoadCollectorRequest) (*LoadCollectorResponse, error)
 	Unload(context.Context, *UnloadCollectorRequest) (*UnloadCollectorResponse, error)
+	Reconfigure(context.Context, *ReconfigureCollectorRequest) (*ReconfigureCollectorResponse, error)
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+	Describe(context.Context, *DescribeRequest) (*DescribeResponse, error)
 }
 
 func RegisterCollectorServer(s *grpc.Server, srv CollectorServer) {
@@ -1637,6 +2912,24 @@ func _Collector_Unload_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Collector_Reconfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconfigureCollectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectorServer).Reconfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Collector/Reconfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectorServer).Reconfigure(ctx, req.(*ReconfigureCollectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Collector_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InfoRequest)
 	if err := dec(in); err != nil {
@@ -1655,6 +2948,24 @@ func _Collector_Info_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Collector_Describe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CollectorServer).Describe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Collector/Describe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CollectorServer).Describe(ctx, req.(*DescribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Collector_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pluginrpc.Collector",
 	HandlerType: (*CollectorServer)(nil),
@@ -1667,10 +2978,18 @@ var _Collector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Unload",
 			Handler:    _Collector_Unload_Handler,
 		},
+		{
+			MethodName: "Reconfigure",
+			Handler:    _Collector_Reconfigure_Handler,
+		},
 		{
 			MethodName: "Info",
 			Handler:    _Collector_Info_Handler,
 		},
+		{
+			MethodName: "Describe",
+			Handler:    _Collector_Describe_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1689,6 +3008,7 @@ type PublisherClient interface {
 	Publish(ctx context.Context, opts ...grpc.CallOption) (Publisher_PublishClient, error)
 	Load(ctx context.Context, in *LoadPublisherRequest, opts ...grpc.CallOption) (*LoadPublisherResponse, error)
 	Unload(ctx context.Context, in *UnloadPublisherRequest, opts ...grpc.CallOption) (*UnloadPublisherResponse, error)
+	Reconfigure(ctx context.Context, in *ReconfigurePublisherRequest, opts ...grpc.CallOption) (*ReconfigurePublisherResponse, error)
 	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
 }
 
@@ -1752,6 +3072,15 @@ func (c *publisherClient) Unload(ctx context.Context, in *UnloadPublisherRequest
 	return out, nil
 }
 
+func (c *publisherClient) Reconfigure(ctx context.Context, in *ReconfigurePublisherRequest, opts ...grpc.CallOption) (*ReconfigurePublisherResponse, error) {
+	out := new(ReconfigurePublisherResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Publisher/Reconfigure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *publisherClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
 	out := new(InfoResponse)
 	err := c.cc.Invoke(ctx, "/pluginrpc.Publisher/Info", in, out, opts...)
@@ -1766,6 +3095,7 @@ type PublisherServer interface {
 	Publish(Publisher_PublishServer) error
 	Load(context.Context, *LoadPublisherRequest) (*LoadPublisherResponse, error)
 	Unload(context.Context, *UnloadPublisherRequest) (*UnloadPublisherResponse, error)
+	Reconfigure(context.Context, *ReconfigurePublisherRequest) (*ReconfigurePublisherResponse, error)
 	Info(context.Context, *InfoRequest) (*InfoResponse, error)
 }
 
@@ -1835,6 +3165,24 @@ func _Publisher_Unload_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Publisher_Reconfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconfigurePublisherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublisherServer).Reconfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Publisher/Reconfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublisherServer).Reconfigure(ctx, req.(*ReconfigurePublisherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Publisher_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InfoRequest)
 	if err := dec(in); err != nil {
@@ -1865,6 +3213,10 @@ var _Publisher_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Unload",
 			Handler:    _Publisher_Unload_Handler,
 		},
+		{
+			MethodName: "Reconfigure",
+			Handler:    _Publisher_Reconfigure_Handler,
+		},
 		{
 			MethodName: "Info",
 			Handler:    _Publisher_Info_Handler,
@@ -1880,66 +3232,312 @@ var _Publisher_serviceDesc = grpc.ServiceDesc{
 	Metadata: "plugin_v2.proto",
 }
 
-func init() { proto.RegisterFile("plugin_v2.proto", fileDescriptor_plugin_v2_9115f353f46e4cca) }
-
-var fileDescriptor_plugin_v2_9115f353f46e4cca = []byte{
-	// 926 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5f, 0x73, 0xdb, 0x44,
-	0x10, 0x8f, 0x64, 0xc7, 0x8e, 0x56, 0x6e, 0x5c, 0x6e, 0x42, 0xad, 0xb8, 0x0f, 0x35, 0x7a, 0x60,
-	0xdc, 0x99, 0x62, 0x6a, 0x35, 0xe3, 0x00, 0x6f, 0x24, 0xc0, 0x24, 0x43, 0x81, 0x8c, 0xda, 0xd2,
-	0x07, 0x86, 0xd1, 0xc8, 0xf6, 0x45, 0x88, 0xca, 0x77, 0x46, 0x77, 0x16, 0x93, 0x61, 0x86, 0x4f,
-	0xc3, 0x97, 0xe0, 0x4b, 0xf0, 0x99, 0x98, 0xfb, 0x63, 0xf9, 0x64, 0xa5, 0x71, 0x3b, 0x7d, 0xbb,
-	0xdd, 0xfd, 0xed, 0x9f, 0xfb, 0xdd, 0x6a, 0x57, 0xd0, 0x5d, 0x66, 0xab, 0x24, 0x25, 0x51, 0x11,
-	0x8c, 0x96, 0x39, 0xe5, 0x14, 0x39, 0x4a, 0x91, 0x2f, 0x67, 0xfe, 0x3d, 0x70, 0xaf, 0x52, 0x92,
-	0x84, 0xf8, 0x8f, 0x15, 0x66, 0xdc, 0x3f, 0x84, 0x8e, 0x12, 0xd9, 0x92, 0x12, 0x86, 0x85, 0xf9,
-	0xfb, 0x34, 0xcb, 0x0c, 0xb3, 0x12, 0xb5, 0xf9, 0x31, 0x1c, 0x9e, 0xd3, 0x2c, 0xc3, 0x33, 0xae,
-	0x11, 0xa8, 0x07, 0x6d, 0x1e, 0xb3, 0x37, 0x51, 0x3a, 0xf7, 0xac, 0x81, 0x35, 0x74, 0xc2, 0x96,
-	0x10, 0x2f, 0xe7, 0x3e, 0x83, 0x6e, 0x09, 0x55, 0xde, 0xe8, 0x29, 0xc0, 0x02, 0xf3, 0x3c, 0x9d,
-	0x45, 0x0c, 0x73, 0xcf, 0x1a, 0x34, 0x86, 0x6e, 0xf0, 0xd1, 0xa8, 0xac, 0x6d, 0xf4, 0x83, 0x34,
-	0x86, 0x8e, 0x02, 0xbd, 0xc0, 0x1c, 0x8d, 0xe0, 0xe0, 0xcf, 0x38, 0x27, 0x29, 0x49, 0x98, 0x67,
-	0x4b, 0x3c, 0x32, 0xf0, 0xaf, 0x95, 0x29, 0x2c, 0x31, 0xfe, 0x5f, 0x70, 0xf4, 0x9c, 0xc6, 0x73,
-	0x9d, 0x98, 0xe6, 0xbb, 0xaa, 0x44, 0x8f, 0xc0, 0xfd, 0x9d, 0x51, 0x12, 0xcd, 0x28, 0xb9, 0x4e,
-	0x13, 0xcf, 0x1e, 0x58, 0xc3, 0x4e, 0x08, 0x42, 0x75, 0x2e, 0x35, 0xe8, 0x31, 0xdc, 0x2f, 0x6b,
-	0x56, 0x31, 0x99, 0xd7, 0x18, 0x34, 0x86, 0x4e, 0xd8, 0x5d, 0x97, 0xa9, 0xd5, 0x7e, 0x0f, 0x3e,
-	0xde, 0x4a, 0xae, 0x59, 0x1b, 0xc3, 0x83, 0x57, 0x24, 0x7b, 0x9f, 0xba, 0xfc, 0x63, 0xe8, 0xd5,
-	0x5c, 0x74, 0xb4, 0x4f, 0xc1, 0xbd, 0x24, 0xd7, 0x74, 0x67, 0x88, 0x5f, 0xa1, 0xa3, 0x70, 0x9a,
-	0xfd, 0x2f, 0xa1, 0x13, 0x65, 0x38, 0x89, 0x67, 0x37, 0x51, 0x4a, 0xae, 0xa9, 0x44, 0xbb, 0x41,
-	0xcf, 0xe0, 0xd3, 0x34, 0x87, 0xf0, 0x5c, 0x0a, 0x22, 0x04, 0x42, 0xd0, 0x94, 0x2e, 0x8a, 0x1e,
-	0x79, 0xf6, 0x7f, 0x81, 0xc3, 0xab, 0xd5, 0x34, 0x4b, 0xd9, 0x6f, 0x3b, 0x49, 0xae, 0xbe, 0xbb,
-	0xbd, 0xfb, 0xdd, 0xfd, 0xaf, 0xa1, 0x5b, 0x06, 0xd7, 0xe5, 0x9b, 0xad, 0x60, 0xbd, 0x43, 0x2b,
-	0x5c, 0xa9, 0x56, 0xd0, 0x61, 0xf0, 0x87, 0xb7, 0xc2, 0xfa, 0x7d, 0x8d, 0x88, 0xdb, 0xef, 0xfb,
-	0xce, 0xc9, 0x36, 0xef, 0x5b, 0x8f, 0xf6, 0xaf, 0x0d, 0x2d, 0xc5, 0x08, 0x0a, 0xc0, 0x21, 0xf1,
-	0x02, 0xb3, 0x65, 0x3c, 0xc3, 0xfa, 0xd2, 0x47, 0xc6, 0xa5, 0x7f, 0x5c, 0xdb, 0xc2, 0x0d, 0x0c,
-	0x3d, 0x81, 0xfd, 0x22, 0xce, 0x56, 0x58, 0x5e, 0xc0, 0x0d, 0x1e, 0xd4, 0x78, 0xfe, 0x59, 0x58,
-	0x43, 0x05, 0x42, 0x9f, 0x43, 0x93, 0xc7, 0x89, 0x6a, 0x69, 0x37, 0x78, 0x58, 0x03, 0x8f, 0x5e,
-	0xc6, 0x09, 0xfb, 0x96, 0xf0, 0xfc, 0x26, 0x94, 0x40, 0xf4, 0x19, 0x38, 0x3c, 0x5d, 0x60, 0xc6,
-	0xe3, 0xc5, 0xd2, 0x6b, 0xca, 0x14, 0x5d, 0xc3, 0xeb, 0x65, 0xba, 0xc0, 0xe1, 0x06, 0x81, 0x06,
-	0xe0, 0xce, 0x31, 0x9b, 0xe5, 0xe9, 0x92, 0xa7, 0x94, 0x78, 0xfb, 0x92, 0x04, 0x53, 0x25, 0x7a,
-	0x6b, 0x45, 0x52, 0xee, 0xb5, 0xa4, 0x49, 0x9e, 0xfb, 0xa7, 0xe0, 0x94, 0x79, 0xd1, 0x7d, 0x68,
-	0xbc, 0xc1, 0x37, 0x9a, 0x3f, 0x71, 0x44, 0x47, 0xe6, 0x15, 0x1d, 0x7d, 0x95, 0xaf, 0xec, 0x2f,
-	0x2c, 0xff, 0x35, 0x38, 0x25, 0x29, 0x22, 0xb2, 0xa0, 0x45, 0x7b, 0xca, 0xf3, 0xed, 0xae, 0xdb,
-	0x55, 0x36, 0x6a, 0x55, 0xfa, 0xff, 0xd9, 0xe0, 0x1a, 0xf4, 0xa1, 0x63, 0x68, 0x17, 0xd1, 0x75,
-	0x46, 0x63, 0x2e, 0xc3, 0xdb, 0x17, 0x7b, 0x61, 0xab, 0xf8, 0x4e, 0xc8, 0xe8, 0x21, 0x1c, 0x14,
-	0xd1, 0x9c, 0xae, 0xa6, 0x99, 0xca, 0x62, 0x5d, 0xec, 0x85, 0xed, 0xe2, 0x1b, 0xa9, 0x50, 0x7e,
-	0x29, 0xe1, 0xcf, 0x02, 0x99, 0x65, 0x5f, 0xfa, 0x5d, 0x0a, 0xb9, 0x34, 0x4d, 0x4e, 0x24, 0xaf,
-	0x8d, 0xb5, 0x69, 0x72, 0xa2, 0x42, 0xae, 0x94, 0x9b, 0xa0, 0xf0, 0x9e, 0x0c, 0xf9, 0x4a, 0x2a,
-	0x36, 0xc6, 0xc9, 0x89, 0x24, 0xb1, 0x59, 0x1a, 0x27, 0x27, 0xa8, 0x07, 0xad, 0x22, 0x9a, 0x52,
-	0x9a, 0x79, 0xed, 0x81, 0x35, 0x3c, 0xb8, 0xd8, 0x0b, 0xf7, 0x8b, 0x33, 0x4a, 0x33, 0x95, 0x6d,
-	0x7a, 0xc3, 0x31, 0xf3, 0x0e, 0x44, 0xa7, 0xcb, 0x6c, 0x67, 0x42, 0x56, 0x01, 0x19, 0xcf, 0x53,
-	0x92, 0x78, 0x8e, 0xa0, 0x42, 0x06, 0x7c, 0x21, 0x15, 0x65, 0x95, 0xe3, 0x89, 0x07, 0xe6, 0x05,
-	0xc6, 0x93, 0x4d, 0x21, 0xe3, 0x89, 0xe7, 0x56, 0xaa, 0x1c, 0x4f, 0xce, 0x0e, 0xa1, 0x33, 0x8f,
-	0x79, 0x1c, 0x15, 0x71, 0x9e, 0xc6, 0x84, 0xfb, 0x4f, 0xa0, 0x29, 0x7a, 0x45, 0xbc, 0x2e, 0xc3,
-	0x33, 0x49, 0x62, 0x23, 0x14, 0x47, 0xf9, 0x6c, 0x42, 0x65, 0x4b, 0x95, 0x3c, 0xfb, 0x21, 0xb4,
-	0xf5, 0x17, 0x8e, 0x3c, 0x68, 0x2f, 0x30, 0x63, 0x71, 0xb2, 0x7e, 0xd8, 0xb5, 0x58, 0x6d, 0x4d,
-	0x7b, 0x57, 0x6b, 0x8a, 0xdd, 0x66, 0x0e, 0xbc, 0xe0, 0x6f, 0x80, 0x73, 0x4a, 0x78, 0x2e, 0x06,
-	0x6e, 0x8e, 0x4e, 0xa1, 0x29, 0x16, 0x23, 0x32, 0xbf, 0x1f, 0x63, 0x71, 0xf6, 0x7b, 0x35, 0xbd,
-	0x9e, 0x53, 0xa7, 0xd0, 0x14, 0x2b, 0xb3, 0xe2, 0x68, 0xac, 0xd4, 0x8a, 0xa3, 0xb9, 0x5b, 0x83,
-	0x7f, 0x6c, 0x70, 0xca, 0x69, 0x8f, 0xce, 0xa0, 0xad, 0x05, 0x74, 0x6c, 0x78, 0x54, 0xb7, 0x6f,
-	0xbf, 0x7f, 0x9b, 0x49, 0xc5, 0x7b, 0x6a, 0xa1, 0x4b, 0x68, 0x8a, 0x81, 0x85, 0x1e, 0x19, 0xa8,
-	0xdb, 0xd6, 0x63, 0x7f, 0xf0, 0x76, 0x80, 0xbe, 0xd5, 0x4f, 0xd0, 0x52, 0xf3, 0x0a, 0x7d, 0x62,
-	0x60, 0x6f, 0xdf, 0x6a, 0x7d, 0xff, 0x2e, 0xc8, 0x86, 0x26, 0xb9, 0x5a, 0x4c, 0x9a, 0x8c, 0xb5,
-	0x56, 0xa1, 0xc9, 0x5c, 0x63, 0x92, 0xa6, 0x72, 0x68, 0x0a, 0x9a, 0xb4, 0x50, 0xa1, 0xa9, 0xba,
-	0x99, 0x2a, 0x34, 0x6d, 0xed, 0x95, 0xe1, 0xdb, 0x69, 0xda, 0x9e, 0xe6, 0x35, 0x9a, 0x6a, 0xb3,
-	0xfb, 0x4e, 0x9a, 0x6a, 0xe1, 0xfc, 0xbb, 0x20, 0x1f, 0x48, 0xd3, 0xb4, 0x25, 0xff, 0xfc, 0x9e,
-	0xfd, 0x1f, 0x00, 0x00, 0xff, 0xff, 0x12, 0x6e, 0xfb, 0xc3, 0x0c, 0x0a, 0x00, 0x00,
+// ProcessorClient is the client API for Processor service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ProcessorClient interface {
+	Process(ctx context.Context, opts ...grpc.CallOption) (Processor_ProcessClient, error)
+	Load(ctx context.Context, in *LoadProcessorRequest, opts ...grpc.CallOption) (*LoadProcessorResponse, error)
+	Unload(ctx context.Context, in *UnloadProcessorRequest, opts ...grpc.CallOption) (*UnloadProcessorResponse, error)
+	Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error)
+}
+
+type processorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewProcessorClient(cc *grpc.ClientConn) ProcessorClient {
+	return &processorClient{cc}
+}
+
+func (c *processorClient) Process(ctx context.Context, opts ...grpc.CallOption) (Processor_ProcessClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Processor_serviceDesc.Streams[0], "/pluginrpc.Processor/Process", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &processorProcessClient{stream}
+	return x, nil
+}
+
+type Processor_ProcessClient interface {
+	Send(*ProcessRequest) error
+	Recv() (*ProcessResponse, error)
+	grpc.ClientStream
+}
+
+type processorProcessClient struct {
+	grpc.ClientStream
+}
+
+func (x *processorProcessClient) Send(m *ProcessRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *processorProcessClient) Recv() (*ProcessResponse, error) {
+	m := new(ProcessResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *processorClient) Load(ctx context.Context, in *LoadProcessorRequest, opts ...grpc.CallOption) (*LoadProcessorResponse, error) {
+	out := new(LoadProcessorResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Processor/Load", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processorClient) Unload(ctx context.Context, in *UnloadProcessorRequest, opts ...grpc.CallOption) (*UnloadProcessorResponse, error) {
+	out := new(UnloadProcessorResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Processor/Unload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processorClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.cc.Invoke(ctx, "/pluginrpc.Processor/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProcessorServer is the server API for Processor service.
+type ProcessorServer interface {
+	Process(Processor_ProcessServer) error
+	Load(context.Context, *LoadProcessorRequest) (*LoadProcessorResponse, error)
+	Unload(context.Context, *UnloadProcessorRequest) (*UnloadProcessorResponse, error)
+	Info(context.Context, *InfoRequest) (*InfoResponse, error)
+}
+
+func RegisterProcessorServer(s *grpc.Server, srv ProcessorServer) {
+	s.RegisterService(&_Processor_serviceDesc, srv)
+}
+
+func _Processor_Process_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProcessorServer).Process(&processorProcessServer{stream})
+}
+
+type Processor_ProcessServer interface {
+	Send(*ProcessResponse) error
+	Recv() (*ProcessRequest, error)
+	grpc.ServerStream
+}
+
+type processorProcessServer struct {
+	grpc.ServerStream
+}
+
+func (x *processorProcessServer) Send(m *ProcessResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *processorProcessServer) Recv() (*ProcessRequest, error) {
+	m := new(ProcessRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Processor_Load_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoadProcessorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessorServer).Load(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Processor/Load",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessorServer).Load(ctx, req.(*LoadProcessorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Processor_Unload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnloadProcessorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessorServer).Unload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Processor/Unload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessorServer).Unload(ctx, req.(*UnloadProcessorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Processor_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessorServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pluginrpc.Processor/Info",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessorServer).Info(ctx, req.(*InfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Processor_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pluginrpc.Processor",
+	HandlerType: (*ProcessorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Load",
+			Handler:    _Processor_Load_Handler,
+		},
+		{
+			MethodName: "Unload",
+			Handler:    _Processor_Unload_Handler,
+		},
+		{
+			MethodName: "Info",
+			Handler:    _Processor_Info_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Process",
+			Handler:       _Processor_Process_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "plugin_v2.proto",
+}
+
+func init() { proto.RegisterFile("plugin_v2.proto", fileDescriptor_plugin_v2_4289b8631e13b053) }
+
+var fileDescriptor_plugin_v2_4289b8631e13b053 = []byte{
+	// 1728 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x5f, 0x73, 0xe3, 0x48,
+	0x11, 0x8f, 0xfc, 0x5f, 0x6d, 0xc7, 0xf6, 0x4d, 0xed, 0x5e, 0xb4, 0xda, 0x83, 0x33, 0x7a, 0xb8,
+	0xcb, 0x2e, 0x47, 0xee, 0xe2, 0x0b, 0xde, 0x85, 0x82, 0x82, 0xc4, 0xf6, 0xc6, 0xae, 0x0b, 0xd9,
+	0x30, 0x49, 0xd8, 0xa2, 0x28, 0x70, 0xc9, 0xf6, 0xc4, 0x27, 0x22, 0x4b, 0x3e, 0x69, 0x64, 0x48,
+	0xf1, 0x46, 0xc1, 0xc7, 0xe0, 0x95, 0x0f, 0x41, 0x15, 0xaf, 0x54, 0xf1, 0x2d, 0x78, 0xe0, 0x7b,
+	0x40, 0xcd, 0x1f, 0xc9, 0x63, 0xcb, 0x89, 0x7d, 0xec, 0x16, 0xf7, 0x36, 0xdd, 0xfd, 0xeb, 0x9e,
+	0xee, 0xe9, 0x99, 0x9f, 0x66, 0x04, 0xb5, 0x99, 0x1b, 0x4d, 0x1c, 0x6f, 0x30, 0x6f, 0x1e, 0xcc,
+	0x02, 0x9f, 0xfa, 0x48, 0x17, 0x8a, 0x60, 0x36, 0xb2, 0x76, 0xa1, 0x7c, 0xe1, 0x78, 0x13, 0x4c,
+	0xbe, 0x8a, 0x48, 0x48, 0xad, 0x2a, 0x54, 0x84, 0x18, 0xce, 0x7c, 0x2f, 0x24, 0xcc, 0xfc, 0x85,
+	0xe3, 0xba, 0x8a, 0x59, 0x88, 0xd2, 0xbc, 0x0f, 0xbb, 0x3d, 0x62, 0xbb, 0xf4, 0x4b, 0x09, 0x40,
+	0x7b, 0x50, 0xa4, 0x76, 0x78, 0x3b, 0x70, 0xc6, 0x86, 0xd6, 0xd0, 0xf6, 0x75, 0x5c, 0x60, 0x62,
+	0x7f, 0x6c, 0xfd, 0x43, 0x83, 0x6a, 0x0c, 0x15, 0xce, 0xe8, 0x53, 0x28, 0x84, 0xd4, 0xa6, 0x51,
+	0xc8, 0xa1, 0xd5, 0xe6, 0xde, 0x41, 0x92, 0xd6, 0x81, 0x80, 0x5e, 0x72, 0x33, 0x96, 0x30, 0xf4,
+	0x23, 0xd8, 0x95, 0x95, 0x48, 0xbf, 0xcc, 0xc3, 0x7e, 0x15, 0xa1, 0x17, 0x12, 0x32, 0xa0, 0x38,
+	0x26, 0xd4, 0x76, 0xdc, 0xd0, 0xc8, 0xf2, 0xd4, 0x62, 0x11, 0x7d, 0x17, 0xf2, 0x2c, 0xcb, 0xd0,
+	0xc8, 0x35, 0xb2, 0xfb, 0xe5, 0xe6, 0x63, 0x25, 0xde, 0x95, 0x1d, 0xde, 0xca, 0xb4, 0x05, 0xc6,
+	0x9a, 0x01, 0x2c, 0x94, 0xf7, 0xd6, 0xab, 0x14, 0x97, 0xd9, 0xae, 0xb8, 0x7b, 0xd3, 0xb3, 0x9e,
+	0x41, 0xb5, 0xed, 0xbb, 0x2e, 0x19, 0xd1, 0x8d, 0xab, 0xfc, 0x17, 0x0d, 0x6a, 0x09, 0x56, 0x2e,
+	0xf3, 0x67, 0x00, 0x53, 0x42, 0x03, 0x67, 0x34, 0x08, 0x09, 0x35, 0x34, 0x5e, 0xe2, 0x7b, 0x4a,
+	0x36, 0x3f, 0xe3, 0x46, 0xac, 0x0b, 0xd0, 0x25, 0xa1, 0xe8, 0x00, 0x4a, 0xbf, 0xb3, 0x03, 0xcf,
+	0xf1, 0x26, 0x2c, 0x7b, 0x86, 0x47, 0x0a, 0xfe, 0x8d, 0x30, 0xe1, 0x04, 0x83, 0xf6, 0xa1, 0x40,
+	0xe6, 0xc4, 0xa3, 0x2c, 0x73, 0x86, 0xae, 0x2b, 0xe8, 0x2e, 0x33, 0x60, 0x69, 0xb7, 0xfe, 0x00,
+	0x8f, 0xce, 0x7c, 0x7b, 0x2c, 0x53, 0xf4, 0x83, 0x4d, 0x05, 0xa1, 0x0f, 0xa1, 0xfc, 0xdb, 0xd0,
+	0xf7, 0x06, 0x23, 0xdf, 0xbb, 0x71, 0x26, 0x7c, 0x2d, 0x2b, 0x18, 0x98, 0xaa, 0xcd, 0x35, 0xe8,
+	0x19, 0xd4, 0x93, 0xea, 0x44, 0x4c, 0x91, 0x85, 0x8e, 0x6b, 0x71, 0x41, 0x52, 0x6d, 0xed, 0xc1,
+	0xe3, 0x95, 0xc9, 0xe5, 0x2e, 0x3e, 0x84, 0xf7, 0xaf, 0x3d, 0xf7, 0xeb, 0xe4, 0x65, 0x3d, 0x81,
+	0xbd, 0x94, 0x8b, 0x8c, 0xf6, 0x47, 0x0d, 0x9e, 0x62, 0x22, 0x12, 0x8e, 0x02, 0xf2, 0xcd, 0xd4,
+	0xfa, 0x6d, 0xf8, 0x60, 0x7d, 0x0e, 0x32, 0xc9, 0xf7, 0xa0, 0xd6, 0x21, 0xe1, 0x28, 0x70, 0x86,
+	0x24, 0x3e, 0xdb, 0x7d, 0xa8, 0x2f, 0x54, 0x72, 0xef, 0x7c, 0x1f, 0x8a, 0x22, 0x72, 0x28, 0x37,
+	0xce, 0xd3, 0xd4, 0xc6, 0xe9, 0x90, 0x1b, 0xc7, 0x73, 0xa8, 0xe3, 0x7b, 0x38, 0xc6, 0x5a, 0xff,
+	0xd2, 0xa0, 0xbe, 0x6a, 0x45, 0x1f, 0x80, 0xee, 0xd9, 0x53, 0x12, 0xce, 0xec, 0x11, 0x91, 0x95,
+	0x2f, 0x14, 0xe8, 0x00, 0x0a, 0x93, 0xc0, 0x8f, 0x66, 0xf1, 0x8e, 0x7b, 0x3f, 0x35, 0xd1, 0x29,
+	0x33, 0x63, 0x89, 0x42, 0x0d, 0x28, 0x8f, 0x79, 0xb6, 0x33, 0x16, 0x5c, 0x1e, 0x19, 0x55, 0x85,
+	0x10, 0xe4, 0x22, 0xcf, 0xa1, 0x46, 0x8e, 0x9b, 0xf8, 0x18, 0x7d, 0x0b, 0xc0, 0x09, 0x07, 0x63,
+	0x72, 0x63, 0x47, 0x2e, 0x35, 0xf2, 0x0d, 0x6d, 0xbf, 0x84, 0x75, 0x27, 0xec, 0x08, 0x05, 0x7a,
+	0x06, 0xb9, 0x5b, 0xc7, 0x1b, 0x1b, 0x05, 0x7e, 0x64, 0x1f, 0xa7, 0x52, 0xf8, 0xc2, 0xf1, 0xc6,
+	0x98, 0x43, 0xac, 0x01, 0x94, 0x95, 0xb4, 0xd8, 0x64, 0xac, 0x16, 0x59, 0x17, 0x1f, 0xaf, 0xa6,
+	0x98, 0x49, 0xa7, 0x68, 0x42, 0x69, 0xe6, 0x87, 0x4e, 0x52, 0x41, 0x1e, 0x27, 0xb2, 0xf5, 0x11,
+	0x94, 0xfb, 0xde, 0x8d, 0xbf, 0x71, 0x27, 0xfe, 0x1a, 0x2a, 0x02, 0x27, 0x5b, 0xf6, 0x03, 0xa8,
+	0x0c, 0x5c, 0x32, 0xb1, 0x47, 0x77, 0x03, 0xc7, 0xbb, 0xf1, 0x39, 0xba, 0xbc, 0x44, 0x3f, 0xaa,
+	0x19, 0xc3, 0x19, 0x17, 0x58, 0x08, 0x56, 0x04, 0x77, 0x11, 0x3b, 0x8f, 0x8f, 0xad, 0x3f, 0x6b,
+	0x50, 0xbd, 0x88, 0x86, 0xae, 0x13, 0x6e, 0xe4, 0xf8, 0x15, 0xa6, 0xc9, 0x6c, 0xc1, 0x34, 0xdb,
+	0x33, 0xc7, 0x31, 0xd4, 0x92, 0x34, 0x64, 0xa5, 0x2a, 0x4d, 0x69, 0x9b, 0x69, 0xca, 0xba, 0x10,
+	0xe4, 0x23, 0xc3, 0x90, 0xb7, 0x3f, 0x90, 0x31, 0xa3, 0x28, 0x11, 0x57, 0x19, 0x65, 0xeb, 0xc9,
+	0x16, 0x8c, 0x92, 0x8e, 0xf6, 0x66, 0x89, 0x50, 0xde, 0x61, 0xfe, 0xcb, 0x2c, 0x91, 0x9e, 0xf8,
+	0x57, 0x50, 0xbd, 0x08, 0xfc, 0x11, 0x09, 0xc3, 0x77, 0xdf, 0x7b, 0x2b, 0x84, 0x5a, 0x12, 0xfc,
+	0xff, 0xf5, 0xa9, 0x4a, 0xf6, 0x80, 0x98, 0xd8, 0x7f, 0x87, 0x7b, 0x60, 0x11, 0x31, 0xb5, 0x07,
+	0xb6, 0x9d, 0x4c, 0xd9, 0x03, 0xa9, 0x68, 0xff, 0xce, 0x40, 0x41, 0x94, 0x8f, 0x9a, 0xcb, 0x44,
+	0xca, 0x8a, 0x7e, 0xa4, 0x14, 0x7d, 0x1e, 0xdb, 0x54, 0x7a, 0xfd, 0x04, 0xf2, 0x73, 0xdb, 0x8d,
+	0x08, 0x2f, 0x60, 0x1d, 0xbb, 0xfe, 0x82, 0x59, 0xb1, 0x00, 0xa1, 0x4f, 0x21, 0x47, 0xed, 0x49,
+	0x7c, 0x28, 0xd3, 0x9c, 0x7f, 0x70, 0x65, 0x4f, 0xc2, 0xae, 0x47, 0x83, 0x3b, 0xcc, 0x81, 0xe8,
+	0x7b, 0xa0, 0x53, 0x67, 0x4a, 0x42, 0x6a, 0x4f, 0x67, 0x9c, 0x70, 0xcb, 0xcd, 0x9a, 0x7a, 0x8b,
+	0x72, 0xa6, 0x04, 0x2f, 0x10, 0xab, 0xcc, 0x98, 0xbf, 0x9f, 0xbc, 0x0b, 0x0a, 0x79, 0xc7, 0xec,
+	0x5c, 0xdc, 0xc8, 0xce, 0xe6, 0x0b, 0xd0, 0x93, 0x14, 0x51, 0x1d, 0xb2, 0xb7, 0xe4, 0x4e, 0x2e,
+	0x35, 0x1b, 0xa2, 0x47, 0xea, 0x6a, 0xe8, 0xb2, 0xea, 0x1f, 0x66, 0x5e, 0x6a, 0xd6, 0x1b, 0xd0,
+	0x93, 0xf5, 0x5b, 0x4b, 0xea, 0x6b, 0x5d, 0x37, 0x7f, 0x8d, 0xac, 0xbf, 0x65, 0xa1, 0xac, 0xac,
+	0x34, 0x7a, 0x02, 0xc5, 0xf9, 0xe0, 0xc6, 0xf5, 0x6d, 0xca, 0xc3, 0x67, 0x7a, 0x3b, 0xb8, 0x30,
+	0x7f, 0xc5, 0x64, 0xf4, 0x14, 0x4a, 0xf3, 0xc1, 0xd8, 0x8f, 0x86, 0xae, 0x98, 0x45, 0xeb, 0xed,
+	0xe0, 0xe2, 0xbc, 0xc3, 0x15, 0xc2, 0xcf, 0xf1, 0xe8, 0xe7, 0x4d, 0xf1, 0xc5, 0xe0, 0x7e, 0x7d,
+	0x26, 0x27, 0xa6, 0xd6, 0x11, 0x6f, 0x41, 0x36, 0x36, 0xb5, 0x8e, 0x44, 0xc8, 0x48, 0xb8, 0xb1,
+	0xd5, 0xde, 0xe5, 0x21, 0xaf, 0xb9, 0x62, 0x61, 0x6c, 0x1d, 0xf1, 0xf5, 0xce, 0x25, 0xc6, 0xd6,
+	0x11, 0xda, 0x83, 0xc2, 0x7c, 0x30, 0xf4, 0x7d, 0x97, 0x2f, 0x7b, 0xa9, 0xb7, 0x83, 0xf3, 0xf3,
+	0x13, 0xdf, 0x77, 0xc5, 0x6c, 0xc3, 0x3b, 0x4a, 0x42, 0xa3, 0xc4, 0x0e, 0x05, 0x9f, 0xed, 0x84,
+	0xc9, 0x22, 0x60, 0x48, 0x03, 0xc7, 0x9b, 0x18, 0x3a, 0x5b, 0x0a, 0x1e, 0xf0, 0x92, 0x2b, 0x92,
+	0x2c, 0x0f, 0x5b, 0x06, 0xa8, 0x05, 0x1c, 0xb6, 0x16, 0x89, 0x1c, 0xb6, 0x8c, 0xf2, 0x52, 0x96,
+	0x87, 0x2d, 0xf4, 0x02, 0xca, 0xf3, 0xc1, 0x97, 0x4e, 0x48, 0xfd, 0x49, 0x60, 0x4f, 0x8d, 0x4a,
+	0x43, 0x5b, 0xd9, 0xf7, 0xbd, 0xd8, 0xd6, 0xdb, 0xc1, 0x30, 0x4f, 0x24, 0x74, 0x08, 0xfa, 0x7c,
+	0x10, 0x46, 0xd3, 0xa9, 0x1d, 0xdc, 0x19, 0xbb, 0x0d, 0x6d, 0x85, 0x23, 0x2e, 0x85, 0xa5, 0xb7,
+	0x83, 0x4b, 0x73, 0x39, 0x3e, 0xa9, 0x42, 0x65, 0x6c, 0x53, 0x7b, 0x30, 0xb7, 0x03, 0xc7, 0xf6,
+	0xa8, 0xe5, 0x80, 0xbe, 0x88, 0x77, 0x04, 0xc5, 0x61, 0x34, 0xba, 0x25, 0x34, 0xfe, 0xea, 0x98,
+	0xeb, 0x92, 0x38, 0xe1, 0x10, 0x1c, 0x43, 0xd9, 0x26, 0x0c, 0xa3, 0xa9, 0xe8, 0x27, 0x66, 0x43,
+	0xb6, 0x93, 0x46, 0x7e, 0xe4, 0x51, 0xde, 0xc7, 0x1c, 0x16, 0x82, 0xd5, 0x83, 0xda, 0x4a, 0x0c,
+	0x46, 0x41, 0xd1, 0x6c, 0x46, 0x82, 0xc1, 0xd0, 0x8f, 0x3c, 0x41, 0x19, 0x1a, 0x06, 0xae, 0x3a,
+	0x61, 0x9a, 0x45, 0xa4, 0x8c, 0x1a, 0xe9, 0x16, 0x8a, 0xb2, 0x1e, 0xf4, 0x12, 0xf4, 0xaf, 0x22,
+	0xdb, 0xa3, 0x8e, 0x4b, 0xd6, 0x25, 0x2d, 0x61, 0x3f, 0x97, 0x10, 0xbc, 0x00, 0x6f, 0x9d, 0x76,
+	0x1b, 0x6a, 0x2b, 0x51, 0xd8, 0xe5, 0x26, 0x8e, 0x23, 0x73, 0x4e, 0xe4, 0xe5, 0x53, 0xa4, 0xc9,
+	0x53, 0x64, 0x7d, 0x02, 0x39, 0xc6, 0x14, 0x7c, 0x52, 0x32, 0xe2, 0x4e, 0x59, 0xcc, 0x86, 0xfc,
+	0x24, 0x32, 0x55, 0x86, 0xab, 0xf8, 0xd8, 0xc2, 0x50, 0x94, 0xfc, 0xce, 0xde, 0x4e, 0x53, 0x12,
+	0x86, 0xf6, 0x24, 0x3e, 0xab, 0xb1, 0xb8, 0x4c, 0x4c, 0x99, 0x4d, 0xc4, 0x64, 0xfd, 0x47, 0x83,
+	0x3c, 0xbf, 0x77, 0xb0, 0x0c, 0xa9, 0x43, 0xdd, 0x38, 0xa0, 0x10, 0x58, 0x1e, 0x94, 0xfc, 0x9e,
+	0xca, 0xc3, 0xcf, 0xc7, 0xe8, 0x08, 0x4a, 0x21, 0x99, 0x93, 0xc0, 0xa1, 0x77, 0x7c, 0x4d, 0xaa,
+	0x4d, 0x63, 0xf5, 0x16, 0x73, 0x29, 0xed, 0x38, 0x41, 0xa2, 0x03, 0x49, 0xb1, 0xb9, 0x54, 0x37,
+	0xb8, 0xc7, 0xc3, 0x0c, 0x9b, 0xdf, 0x54, 0xc8, 0xff, 0x4e, 0x80, 0xd5, 0xe5, 0xeb, 0xe3, 0xf3,
+	0x97, 0x50, 0x51, 0x9f, 0xab, 0xa8, 0x0c, 0xc5, 0x5e, 0xf7, 0xf8, 0xec, 0xaa, 0xf7, 0xcb, 0xfa,
+	0x0e, 0xaa, 0x40, 0xa9, 0xd3, 0x3d, 0xc5, 0xc7, 0x9d, 0x6e, 0xa7, 0xae, 0xa1, 0x5d, 0xd0, 0xaf,
+	0xcf, 0x63, 0x63, 0xe6, 0xf9, 0x4f, 0x01, 0x16, 0xbc, 0x8c, 0x6a, 0x50, 0xbe, 0x3e, 0xbf, 0xbc,
+	0xe8, 0xb6, 0xfb, 0xaf, 0xfa, 0xdd, 0x4e, 0x7d, 0x07, 0xe9, 0x90, 0x3f, 0x3d, 0xbe, 0x3e, 0xed,
+	0xd6, 0x35, 0x16, 0xb3, 0xfd, 0xfa, 0xfa, 0xfc, 0xaa, 0x8b, 0xeb, 0x19, 0xa6, 0xef, 0x74, 0xcf,
+	0xae, 0x8e, 0xeb, 0xd9, 0xe7, 0x3f, 0x81, 0xdd, 0xa5, 0xe5, 0x43, 0x25, 0xc8, 0xf5, 0xcf, 0x5f,
+	0xbd, 0xae, 0xef, 0x30, 0x97, 0x37, 0xc7, 0xf8, 0xbc, 0x7f, 0x7e, 0x5a, 0xd7, 0x98, 0x4b, 0x17,
+	0xe3, 0xd7, 0xcc, 0xbb, 0x02, 0xa5, 0x36, 0xee, 0x5f, 0xf5, 0xdb, 0xc7, 0x67, 0xf5, 0x6c, 0xf3,
+	0xef, 0x1a, 0x40, 0xdb, 0xf7, 0x68, 0xc0, 0xde, 0x3f, 0x01, 0x7a, 0x01, 0x39, 0xf6, 0x73, 0x03,
+	0xa9, 0x5f, 0x3f, 0xe5, 0xe7, 0x87, 0xb9, 0x97, 0xd2, 0xcb, 0x7b, 0xc9, 0x0b, 0xc8, 0xb1, 0xdf,
+	0x1e, 0x4b, 0x8e, 0xca, 0x6f, 0x11, 0x73, 0x2f, 0xa5, 0x97, 0x8e, 0x3f, 0x86, 0x82, 0xfc, 0x51,
+	0x60, 0xa4, 0xde, 0xff, 0xb1, 0xf3, 0x93, 0x35, 0x16, 0xe1, 0xde, 0xfc, 0x67, 0x16, 0xf4, 0xe4,
+	0xed, 0x86, 0x4e, 0xa0, 0x28, 0x05, 0xa4, 0xfa, 0x2c, 0xff, 0x1b, 0x30, 0xcd, 0x75, 0x26, 0x11,
+	0xef, 0x33, 0x0d, 0xf5, 0x21, 0xc7, 0x6e, 0x2b, 0xe8, 0x43, 0x05, 0xb5, 0xee, 0x45, 0x6e, 0x36,
+	0xee, 0x07, 0xc8, 0xda, 0x5e, 0x43, 0x41, 0x5c, 0x56, 0xd0, 0x77, 0x14, 0xec, 0xfa, 0x87, 0xb4,
+	0x69, 0x3d, 0x04, 0x91, 0x01, 0x7f, 0x03, 0x65, 0xe5, 0x36, 0x8a, 0x3e, 0x52, 0x5c, 0x1e, 0x78,
+	0x4f, 0x9b, 0x1f, 0x6f, 0xc4, 0x2d, 0xba, 0xc8, 0x9f, 0x39, 0x6a, 0x17, 0x95, 0x27, 0x96, 0xb9,
+	0x97, 0xd2, 0x4b, 0xc7, 0x36, 0x94, 0xe2, 0x97, 0x31, 0x52, 0x97, 0x77, 0xe5, 0x05, 0x6d, 0x3e,
+	0x5d, 0x6b, 0x93, 0xbd, 0xfc, 0x53, 0x16, 0xf4, 0xe4, 0x86, 0xcd, 0x7a, 0x29, 0x85, 0xa5, 0x5e,
+	0x2e, 0xbf, 0xb4, 0x4c, 0x73, 0x9d, 0x49, 0xc4, 0xdb, 0xbf, 0xbf, 0x97, 0xab, 0x0f, 0x04, 0xb3,
+	0x71, 0x3f, 0x60, 0x8b, 0x5e, 0xa6, 0xc2, 0x59, 0x0f, 0x41, 0xbe, 0x56, 0x2f, 0x53, 0xa1, 0x3f,
+	0xde, 0x88, 0x7b, 0xcb, 0x5e, 0x36, 0xff, 0x9a, 0x01, 0x3d, 0xb9, 0x5d, 0xa3, 0x0e, 0x14, 0xa5,
+	0xb0, 0xdc, 0x86, 0xa5, 0x47, 0x8f, 0x69, 0xae, 0x33, 0xc5, 0x6d, 0x78, 0xe0, 0x50, 0xad, 0x5e,
+	0xfc, 0xcd, 0xc6, 0xfd, 0x80, 0x6d, 0x1a, 0xb1, 0x1a, 0xce, 0x7a, 0x08, 0xf2, 0x96, 0x0b, 0x35,
+	0x2c, 0xf0, 0x5f, 0xc5, 0x9f, 0xff, 0x77, 0x00, 0x10, 0xfd, 0xcc, 0x58, 0x3d, 0x16, 0x00, 0x00,
 }
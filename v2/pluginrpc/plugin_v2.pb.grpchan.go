@@ -55,6 +55,15 @@ func (c *controllerChannelClient) Kill(ctx context.Context, in *KillRequest, opt
 	return out, nil
 }
 
+func (c *controllerChannelClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Controller/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func RegisterHandlerCollector(reg grpchan.ServiceRegistry, srv CollectorServer) {
 	reg.RegisterService(&_Collector_serviceDesc, srv)
 }
@@ -100,6 +109,15 @@ func (c *collectorChannelClient) Unload(ctx context.Context, in *UnloadCollector
 	return out, nil
 }
 
+func (c *collectorChannelClient) Reconfigure(ctx context.Context, in *ReconfigureCollectorRequest, opts ...grpc.CallOption) (*ReconfigureCollectorResponse, error) {
+	out := new(ReconfigureCollectorResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Collector/Reconfigure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *collectorChannelClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
 	out := new(InfoResponse)
 	err := c.ch.Invoke(ctx, "/pluginrpc.Collector/Info", in, out, opts...)
@@ -109,6 +127,15 @@ func (c *collectorChannelClient) Info(ctx context.Context, in *InfoRequest, opts
 	return out, nil
 }
 
+func (c *collectorChannelClient) Describe(ctx context.Context, in *DescribeRequest, opts ...grpc.CallOption) (*DescribeResponse, error) {
+	out := new(DescribeResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Collector/Describe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func RegisterHandlerPublisher(reg grpchan.ServiceRegistry, srv PublisherServer) {
 	reg.RegisterService(&_Publisher_serviceDesc, srv)
 }
@@ -148,6 +175,15 @@ func (c *publisherChannelClient) Unload(ctx context.Context, in *UnloadPublisher
 	return out, nil
 }
 
+func (c *publisherChannelClient) Reconfigure(ctx context.Context, in *ReconfigurePublisherRequest, opts ...grpc.CallOption) (*ReconfigurePublisherResponse, error) {
+	out := new(ReconfigurePublisherResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Publisher/Reconfigure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *publisherChannelClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
 	out := new(InfoResponse)
 	err := c.ch.Invoke(ctx, "/pluginrpc.Publisher/Info", in, out, opts...)
@@ -156,3 +192,51 @@ func (c *publisherChannelClient) Info(ctx context.Context, in *InfoRequest, opts
 	}
 	return out, nil
 }
+
+func RegisterHandlerProcessor(reg grpchan.ServiceRegistry, srv ProcessorServer) {
+	reg.RegisterService(&_Processor_serviceDesc, srv)
+}
+
+type processorChannelClient struct {
+	ch grpchan.Channel
+}
+
+func NewProcessorChannelClient(ch grpchan.Channel) ProcessorClient {
+	return &processorChannelClient{ch: ch}
+}
+
+func (c *processorChannelClient) Process(ctx context.Context, opts ...grpc.CallOption) (Processor_ProcessClient, error) {
+	stream, err := c.ch.NewStream(ctx, &_Processor_serviceDesc.Streams[0], "/pluginrpc.Processor/Process", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &processorProcessClient{stream}
+	return x, nil
+}
+
+func (c *processorChannelClient) Load(ctx context.Context, in *LoadProcessorRequest, opts ...grpc.CallOption) (*LoadProcessorResponse, error) {
+	out := new(LoadProcessorResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Processor/Load", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processorChannelClient) Unload(ctx context.Context, in *UnloadProcessorRequest, opts ...grpc.CallOption) (*UnloadProcessorResponse, error) {
+	out := new(UnloadProcessorResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Processor/Unload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processorChannelClient) Info(ctx context.Context, in *InfoRequest, opts ...grpc.CallOption) (*InfoResponse, error) {
+	out := new(InfoResponse)
+	err := c.ch.Invoke(ctx, "/pluginrpc.Processor/Info", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
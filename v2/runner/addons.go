@@ -26,7 +26,11 @@ import (
 	"time"
 
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/service"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
 
 const (
@@ -66,7 +70,7 @@ func startPprofServer(ctx context.Context, ln net.Listener) {
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func startStatsServer(ctx context.Context, ln net.Listener, stats stats.Controller) {
+func startStatsServer(ctx context.Context, ln net.Listener, stats stats.Controller, health service.HealthChecker) {
 	logF := log.WithCtx(ctx).WithFields(moduleFields)
 	logF.Infof("Running stats server on address %s", ln.Addr())
 
@@ -75,6 +79,12 @@ func startStatsServer(ctx context.Context, ln net.Listener, stats stats.Controll
 	h.HandleFunc("/stats", func(w http.ResponseWriter, r *http.Request) {
 		statsHandler(ctx, w, r, stats)
 	})
+	h.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		healthHandler(ctx, w, r, health, false)
+	})
+	h.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		healthHandler(ctx, w, r, health, true)
+	})
 
 	go func() {
 		err := http.Serve(ln, h)
@@ -100,7 +110,7 @@ func statsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, s
 		}
 
 		w.WriteHeader(http.StatusOK)
-		_, err = w.Write(jsonStats)
+		_, err = w.Write([]byte(secrets.Redact(string(jsonStats))))
 		if err != nil {
 			logF.WithError(err).Error("error occurred when serving statistics request")
 		}
@@ -110,3 +120,80 @@ func statsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, s
 		w.WriteHeader(http.StatusRequestTimeout)
 	}
 }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type taskHealthJSON struct {
+	TaskID  string `json:"Task id"`
+	Status  string `json:"Status"`
+	Details string `json:"Details,omitempty"`
+}
+
+type healthJSON struct {
+	Status       string           `json:"Status"`
+	PluginStatus string           `json:"Plugin status"`
+	Details      string           `json:"Details,omitempty"`
+	Tasks        []taskHealthJSON `json:"Tasks"`
+}
+
+func toHealthJSON(report types.HealthReport) healthJSON {
+	hj := healthJSON{
+		Status:       report.Status.String(),
+		PluginStatus: report.PluginStatus.String(),
+		Details:      report.Details,
+		Tasks:        []taskHealthJSON{},
+	}
+
+	for _, th := range report.Tasks {
+		hj.Tasks = append(hj.Tasks, taskHealthJSON{
+			TaskID:  th.TaskID,
+			Status:  th.Status.String(),
+			Details: th.Details,
+		})
+	}
+
+	return hj
+}
+
+// Serve health of plugin: liveness (/healthz) depends only on plugin-wide check, readiness (/readyz) on tasks as well
+func healthHandler(ctx context.Context, w http.ResponseWriter, r *http.Request, health service.HealthChecker, withTasks bool) {
+	logF := log.WithCtx(ctx).WithFields(moduleFields)
+	logF.WithField("URI", r.RequestURI).Trace("Handling health request")
+
+	var report types.HealthReport
+	var err error
+
+	if withTasks {
+		report, err = health.CheckHealth(r.Context(), "")
+		if err != nil {
+			logF.WithError(err).Error("error when checking health")
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	} else {
+		report = health.CheckPluginHealth(r.Context()) // checks of tasks aren't run at all
+	}
+
+	jsonHealth, err := json.MarshalIndent(toHealthJSON(report), "", jsonIndentString)
+	if err != nil {
+		logF.WithError(err).Error("error when marshaling health report")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	status := report.PluginStatus
+	if withTasks {
+		status = report.Status
+	}
+
+	if status == plugin.HealthStatusUnhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_, err = w.Write(jsonHealth)
+	if err != nil {
+		logF.WithError(err).Error("error occurred when serving health request")
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	catalogFormatJSON     = "json"
+	catalogFormatYAML     = "yaml"
+	catalogFormatMarkdown = "markdown"
+)
+
+var catalogFormats = []string{catalogFormatJSON, catalogFormatYAML, catalogFormatMarkdown}
+
+type metricsCatalog struct {
+	Plugin  string          `json:"plugin" yaml:"plugin"`
+	Version string          `json:"version" yaml:"version"`
+	Metrics []catalogMetric `json:"metrics" yaml:"metrics"`
+}
+
+type catalogMetric struct {
+	Namespace   string         `json:"namespace" yaml:"namespace"`
+	Groups      []catalogGroup `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+	Unit        string         `json:"unit,omitempty" yaml:"unit,omitempty"`
+	Default     bool           `json:"default" yaml:"default"`
+	Kind        string         `json:"kind" yaml:"kind"`
+}
+
+type catalogGroup struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Position    int    `json:"position" yaml:"position"`
+}
+
+func isValidCatalogFormat(format string) bool {
+	for _, f := range catalogFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Print metrics defined by plugin in a given format (json, yaml or markdown)
+func printMetricsCatalog(pluginName string, pluginVersion string, catalog []types.MetricDefinition, format string) {
+	b, err := formatMetricsCatalog(newMetricsCatalog(pluginName, pluginVersion, catalog), format)
+	if err != nil {
+		fmt.Printf("Error: can't print metrics catalog (%v)", err)
+		return
+	}
+
+	fmt.Printf("%s\n", strings.TrimRight(string(b), "\n"))
+}
+
+func newMetricsCatalog(pluginName string, pluginVersion string, catalog []types.MetricDefinition) metricsCatalog {
+	mc := metricsCatalog{
+		Plugin:  pluginName,
+		Version: pluginVersion,
+		Metrics: make([]catalogMetric, 0, len(catalog)),
+	}
+
+	for _, mtDef := range catalog {
+		cm := catalogMetric{
+			Namespace:   mtDef.Namespace,
+			Description: mtDef.Description,
+			Unit:        mtDef.Unit,
+			Default:     mtDef.IsDefault,
+			Kind:        mtDef.Kind.String(),
+		}
+
+		for _, g := range mtDef.Groups {
+			cm.Groups = append(cm.Groups, catalogGroup{
+				Name:        g.Name,
+				Description: g.Description,
+				Position:    g.Position,
+			})
+		}
+
+		mc.Metrics = append(mc.Metrics, cm)
+	}
+
+	return mc
+}
+
+func formatMetricsCatalog(mc metricsCatalog, format string) ([]byte, error) {
+	switch format {
+	case catalogFormatJSON:
+		return json.MarshalIndent(mc, "", "  ")
+	case catalogFormatYAML:
+		return yaml.Marshal(mc)
+	case catalogFormatMarkdown:
+		return markdownMetricsCatalog(mc), nil
+	}
+
+	return nil, fmt.Errorf("invalid format (%s)", format)
+}
+
+func markdownMetricsCatalog(mc metricsCatalog) []byte {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Metrics catalog: %s (%s)\n\n", mc.Plugin, mc.Version))
+	sb.WriteString("Namespace | Description | Unit | Default | Kind\n")
+	sb.WriteString("----------|-------------|------|---------|-----\n")
+
+	groups := map[string]string{}
+	for _, mt := range mc.Metrics {
+		isDefault := "No"
+		if mt.Default {
+			isDefault = "Yes"
+		}
+
+		sb.WriteString(fmt.Sprintf("`%s` | %s | %s | %s | %s\n", mt.Namespace, markdownEscape(mt.Description), markdownEscape(mt.Unit), isDefault, mt.Kind))
+
+		for _, g := range mt.Groups {
+			if groups[g.Name] == "" {
+				groups[g.Name] = g.Description
+			}
+		}
+	}
+
+	if len(groups) == 0 {
+		return []byte(sb.String())
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sb.WriteString("\n## Groups\n\n")
+	sb.WriteString("Group | Description\n")
+	sb.WriteString("------|------------\n")
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("`%s` | %s\n", name, markdownEscape(groups[name])))
+	}
+
+	return []byte(sb.String())
+}
+
+// Escape characters breaking markdown table
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,96 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+	"gopkg.in/yaml.v3"
+)
+
+var catalogDefinitions = []types.MetricDefinition{
+	{
+		Namespace:   "/example/[disk]/io_time",
+		Groups:      []types.MetricGroup{{Name: "disk", Description: "Disk name", Position: 1}},
+		Description: "Time spent on I/O | in total",
+		Unit:        "ms",
+		IsDefault:   true,
+		Kind:        plugin.MetricKindCounter,
+	},
+	{
+		Namespace: "/example/uptime",
+		Unit:      "s",
+	},
+}
+
+func TestFormatMetricsCatalog(t *testing.T) {
+	Convey("Validate that metrics catalog can be printed in supported formats", t, func() {
+		mc := newMetricsCatalog("example", "1.0.0", catalogDefinitions)
+
+		Convey("JSON", func() {
+			// Act
+			b, err := formatMetricsCatalog(mc, "json")
+
+			// Assert
+			So(err, ShouldBeNil)
+
+			decoded := metricsCatalog{}
+			So(json.Unmarshal(b, &decoded), ShouldBeNil)
+			So(decoded, ShouldResemble, mc)
+			So(decoded.Metrics[0].Kind, ShouldEqual, "counter")
+			So(decoded.Metrics[0].Groups[0].Name, ShouldEqual, "disk")
+		})
+
+		Convey("YAML", func() {
+			// Act
+			b, err := formatMetricsCatalog(mc, "yaml")
+
+			// Assert
+			So(err, ShouldBeNil)
+
+			decoded := metricsCatalog{}
+			So(yaml.Unmarshal(b, &decoded), ShouldBeNil)
+			So(decoded, ShouldResemble, mc)
+		})
+
+		Convey("Markdown", func() {
+			// Act
+			b, err := formatMetricsCatalog(mc, "markdown")
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(string(b), ShouldContainSubstring, "# Metrics catalog: example (1.0.0)")
+			So(string(b), ShouldContainSubstring, "`/example/[disk]/io_time` | Time spent on I/O \\| in total | ms | Yes | counter")
+			So(string(b), ShouldContainSubstring, "`/example/uptime` |  | s | No | ")
+			So(string(b), ShouldContainSubstring, "`disk` | Disk name")
+		})
+
+		Convey("Unknown format", func() {
+			// Act
+			_, err := formatMetricsCatalog(mc, "xml")
+
+			// Assert
+			So(err, ShouldBeError)
+		})
+	})
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/service"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/statestore"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
@@ -79,6 +81,7 @@ func startCollector(ctx context.Context, collector types.Collector) {
 	}
 
 	logF := logger(ctx).WithField("service", "collector")
+	secrets.AddLogHook(log.WithCtx(ctx)) // redact secret values (task configuration) from logs
 
 	if opt == nil {
 		opt, err = ParseCmdLineOptions(os.Args[0], types.PluginTypeCollector, os.Args[1:])
@@ -102,6 +105,14 @@ func startCollector(ctx context.Context, collector types.Collector) {
 	defer statsController.Close()
 
 	ctxMan := proxy.NewContextManager(ctx, collector, statsController)
+	statsController.RedactPluginInfo(ctxMan.IsSecretPath) // secret fields are declared in plugin definition
+	ctxMan.CollectTimeout = opt.CollectTimeout
+	ctxMan.SeriesLimits = proxy.SeriesLimits{
+		PerCollect: opt.MaxSeriesPerCollect,
+		PerWindow:  opt.MaxSeriesPerWindow,
+		Window:     opt.SeriesWindow,
+	}
+	ctxMan.StateStore = statestore.NewStore(opt.StateDir, collector.Name())
 
 	logrus.SetLevel(opt.LogLevel)
 
@@ -111,10 +122,26 @@ func startCollector(ctx context.Context, collector types.Collector) {
 	}
 
 	if opt.PrintExampleTask {
-		printExampleTask(ctxMan.ExampleConfig, collector.Name(), collector.Type())
+		printExampleTask(ctxMan.ExampleConfig, ctxMan.ConfigSchema, collector.Name(), collector.Type())
+		os.Exit(normalExitStatus)
+	}
+
+	if opt.PrintConfigSchema {
+		printConfigSchema(ctxMan.ConfigSchema)
 		os.Exit(normalExitStatus)
 	}
 
+	if opt.PrintMetricsCatalog != "" {
+		printMetricsCatalog(collector.Name(), collector.Version(), ctxMan.MetricsCatalog(), opt.PrintMetricsCatalog)
+		os.Exit(normalExitStatus)
+	}
+
+	err = ctxMan.Init(ctx)
+	if err != nil {
+		logF.WithError(err).Error("Can't initialize plugin")
+		os.Exit(errorExitStatus)
+	}
+
 	r, err := acquireResources(opt)
 	if err != nil {
 		logF.WithError(err).Error("Can't acquire resources for plugin services")
@@ -127,12 +154,12 @@ func startCollector(ctx context.Context, collector types.Collector) {
 	}
 
 	if opt.EnableStatsServer {
-		startStatsServer(ctx, r.statsListener, statsController)
+		startStatsServer(ctx, r.statsListener, statsController, ctxMan)
 		defer r.statsListener.Close() // close stats service when GRPC service has been shut down
 	}
 
 	if opt.DebugMode {
-		startCollectorInDebugMode(ctxMan, opt)
+		startCollectorInDebugMode(ctxMan, opt, collector.Name())
 	} else {
 		jsonMeta := metaInformation(ctx, collector.Name(), collector.Version(), collector.Type(), opt, r, ctxMan.TasksLimit, ctxMan.InstancesLimit)
 		if inProc {
@@ -152,48 +179,50 @@ func startCollector(ctx context.Context, collector types.Collector) {
 		}
 
 		// main blocking operation
-		service.StartCollectorGRPC(ctx, srv, ctxMan, r.grpcListener, opt.GRPCPingTimeout, opt.GRPCPingMaxMissed)
+		service.StartCollectorGRPC(ctx, srv, ctxMan, r.grpcListener, opt.GRPCPingTimeout, opt.GRPCPingMaxMissed, opt.ShutdownTimeout)
 	}
 }
 
-func startCollectorInDebugMode(ctxManager *proxy.ContextManager, opt *plugin.Options) {
-	const debugModeTaskID = "task-1"
-
-	// Load task based on command line options
-	var filter []string
-	if opt.PluginFilter != defaultFilter {
-		filter = strings.Split(opt.PluginFilter, filterSeparator)
+func startCollectorInDebugMode(ctxManager *proxy.ContextManager, opt *plugin.Options, pluginName string) {
+	tasks, collectInterval, err := debugModeTasks(opt, pluginName)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Couldn't prepare a task in a standalone mode (reason: %v)\n", err)
+		os.Exit(errorExitStatus)
 	}
 
-	errLoad := ctxManager.LoadTask(debugModeTaskID, []byte(opt.PluginConfig), filter)
-	if errLoad != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Couldn't load a task in a standalone mode (reason: %v)\n", errLoad)
+	output, errOutput := openDebugOutput(opt.DebugOutputFormat, opt.DebugOutput)
+	if errOutput != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Couldn't open output in a standalone mode (reason: %v)\n", errOutput)
 		os.Exit(errorExitStatus)
 	}
+	defer output.Close()
 
-	for runCount := 0; ; {
-		// Request metrics collection
-		chunkCh := ctxManager.RequestCollect(debugModeTaskID)
+	for _, task := range tasks {
+		errLoad := ctxManager.LoadTask(task.id, task.config, task.filter)
+		if errLoad != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Couldn't load a task %s in a standalone mode (reason: %v)\n", task.id, errLoad)
+			os.Exit(errorExitStatus)
+		}
+	}
 
-		for chunk := range chunkCh {
-			if chunk.Err != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "Error occurred during metrics collection in a standalone mode (reason: %v)\n", chunk.Err)
-				os.Exit(errorExitStatus)
+	for runCount := 0; ; {
+		for _, task := range tasks {
+			if len(tasks) > 1 {
+				output.SetTask(task.id)
 			}
 
-			// Print out metrics
-			fmt.Printf("Gathered metrics (length=%d): \n", len(chunk.Metrics))
-			for _, mt := range chunk.Metrics {
-				fmt.Printf("%s\n", mt)
-			}
-			fmt.Printf("\n")
+			// Request metrics collection
+			chunkCh := ctxManager.RequestCollect(context.Background(), task.id)
 
-			if len(chunk.Warnings) != 0 {
-				fmt.Printf("Gathered warnings (length=%d): \n", len(chunk.Warnings))
-				for _, w := range chunk.Warnings {
-					fmt.Printf("%s\n", w)
+			for chunk := range chunkCh {
+				if chunk.Err != nil {
+					output.WriteError(chunk.Err)
+					_ = output.Close()
+					os.Exit(errorExitStatus)
 				}
-				fmt.Printf("\n")
+
+				// Print out metrics
+				output.WriteChunk(chunk)
 			}
 		}
 
@@ -205,12 +234,49 @@ func startCollectorInDebugMode(ctxManager *proxy.ContextManager, opt *plugin.Opt
 			}
 		}
 
-		time.Sleep(opt.DebugCollectInterval)
+		time.Sleep(collectInterval)
 	}
 
-	errUnload := ctxManager.UnloadTask(debugModeTaskID)
-	if errUnload != nil {
-		_, _ = fmt.Fprintf(os.Stderr, "Couldn't unload a task in a standalone mode (reason: %v)\n", errUnload)
-		os.Exit(errorExitStatus)
+	for _, task := range tasks {
+		errUnload := ctxManager.UnloadTask(task.id)
+		if errUnload != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Couldn't unload a task %s in a standalone mode (reason: %v)\n", task.id, errUnload)
+			os.Exit(errorExitStatus)
+		}
+	}
+
+	shutdownCtx, cancelFn := context.WithTimeout(context.Background(), opt.ShutdownTimeout)
+	defer cancelFn()
+	ctxManager.Shutdown(shutdownCtx) // no tasks left, only plugin shutdown hook is called
+}
+
+// Tasks executed in debug mode are defined by task file or command line options (single task).
+// Interval from task schedule is used unless -debug-collect-interval is provided.
+func debugModeTasks(opt *plugin.Options, pluginName string) ([]debugTask, time.Duration, error) {
+	if opt.TaskFile != "" {
+		tf, err := loadTaskFile(opt.TaskFile, pluginName)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		interval := opt.DebugCollectInterval
+		if tf.interval != 0 && opt.DebugCollectInterval == defaultCollectInterval {
+			interval = tf.interval
+		}
+
+		return tf.tasks, interval, nil
 	}
+
+	var filter []string
+	if opt.PluginFilter != defaultFilter {
+		filter = strings.Split(opt.PluginFilter, filterSeparator)
+	}
+
+	task := debugTask{
+		id:     "task-1",
+		config: []byte(opt.PluginConfig),
+		filter: filter,
+	}
+
+	return []debugTask{task}, opt.DebugCollectInterval, nil
 }
@@ -5,6 +5,7 @@ Benchmark test mainly measures:
 - the time needed to create validation tree (1-33 metrics)
 - the time needed to validate metrics based only on definition tree (100-10000)
 - the time needed to validate metrics based on definition and filtering tree
+- the same metrics added with namespace handles (ctx.Namespace) instead of AddMetric
 */
 
 /*
@@ -26,15 +27,21 @@ Benchmark test mainly measures:
 package runner
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/collector/proxy"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
 
-const collectTimeout = 10 * time.Second
+const (
+	collectTimeout = 5 * time.Minute
+	flushThreshold = 10000 // metrics are sent in chunks, so memory usage doesn't affect results
+)
 
 var metricDefinition = []string{
 	"/kubernetes/pod/[node]/[namespace]/[pod]/status/phase/Pending",
@@ -111,6 +118,13 @@ var contDef = []string{ // len = 40
 
 var metricsToValidateAll []string
 
+type handleMetric struct {
+	template    string
+	groupValues []string
+}
+
+var handleMetricsAll []handleMetric // the same metrics as metricsToValidateAll (template + group values)
+
 func init() {
 	// create any possible metric combination
 	for _, mt := range metricDefinition {
@@ -121,6 +135,13 @@ func init() {
 	metricsToValidateAll = generateCombination(metricsToValidateAll, "[namespace]", nsDef)
 	metricsToValidateAll = generateCombination(metricsToValidateAll, "[pod]", podDef)
 	metricsToValidateAll = generateCombination(metricsToValidateAll, "[container]", contDef)
+
+	groupChoices := map[string][]string{"[node]": nodesDef, "[namespace]": nsDef, "[pod]": podDef, "[container]": contDef}
+	for _, mt := range metricDefinition {
+		if strings.Count(mt, "[") == len(groupChoices) { // the same metrics are kept by generateCombination
+			handleMetricsAll = append(handleMetricsAll, generateHandleCombination(mt, groupChoices)...)
+		}
+	}
 }
 
 func generateCombination(mts []string, s string, choices []string) []string {
@@ -135,22 +156,48 @@ func generateCombination(mts []string, s string, choices []string) []string {
 	return resMt
 }
 
+// generate every combination of group values for a template (values are ordered as groups in template)
+func generateHandleCombination(template string, groupChoices map[string][]string) []handleMetric {
+	resMt := []handleMetric{{template: template}}
+	for _, el := range strings.Split(template, "/") {
+		choices, ok := groupChoices[el]
+		if !ok {
+			continue
+		}
+
+		nextMt := []handleMetric{}
+		for _, mt := range resMt {
+			for _, ch := range choices {
+				values := append(append([]string{}, mt.groupValues...), ch)
+				nextMt = append(nextMt, handleMetric{template: template, groupValues: values})
+			}
+		}
+		resMt = nextMt
+	}
+	return resMt
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type benchCollector struct {
-	metricDefined  int // numbers of metrics to define
-	addMetricRatio int // every n metric will be taken to result
+	metricDefined  int  // numbers of metrics to define
+	addMetricRatio int  // every n metric will be taken to result
+	useHandles     bool // add metrics with namespace handles instead of AddMetric
 }
 
-func (bc *benchCollector) DefineMetrics(colDef plugin.CollectorDefinition) error {
+func (bc *benchCollector) PluginDefinition(colDef plugin.CollectorDefinition) error {
 	for i := 0; i < bc.metricDefined; i++ {
 		colDef.DefineMetric(metricDefinition[i], "", true, "")
 	}
 
-	return nil
+	return colDef.DefineFlushThreshold(flushThreshold)
 }
 
 func (bc *benchCollector) Collect(ctx plugin.CollectContext) error {
+	if bc.useHandles {
+		return bc.collectWithHandles(ctx)
+	}
+
 	for i := 0; i < len(metricsToValidateAll); i += bc.addMetricRatio {
 		err := ctx.AddMetric(metricsToValidateAll[i], i)
 		if err != nil {
@@ -161,6 +208,19 @@ func (bc *benchCollector) Collect(ctx plugin.CollectContext) error {
 	return nil
 }
 
+// Add the same metrics as Collect, but with namespace templates compiled once per definition
+func (bc *benchCollector) collectWithHandles(ctx plugin.CollectContext) error {
+	for i := 0; i < len(handleMetricsAll); i += bc.addMetricRatio {
+		m := handleMetricsAll[i]
+		err := ctx.Namespace(m.template).Add(i, m.groupValues...)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 func genParseDefinitionN(n int, b *testing.B) {
@@ -169,7 +229,7 @@ func genParseDefinitionN(n int, b *testing.B) {
 			metricDefined:  n,
 			addMetricRatio: 1,
 		}
-		proxy.NewContextManager(benchColl, "benchmark collector", "0.0.1") // build metrics definition tree
+		proxy.NewContextManager(context.Background(), types.NewCollector("benchmark collector", "0.0.1", benchColl), &stats.EmptyController{}) // build metrics definition tree
 	}
 }
 
@@ -181,38 +241,50 @@ func BenchmarkParseDefinition_4Groups_33Metrics(b *testing.B) { genParseDefiniti
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func genMetricAddition(addRatio int, b *testing.B) {
-	const taskId = 1
+func genMetricAddition(addRatio int, useHandles bool, b *testing.B) {
+	const taskId = "task-1"
 
-	for i := 0; i < b.N; i++ {
-		benchColl := &benchCollector{
-			metricDefined:  33,
-			addMetricRatio: addRatio,
-		}
-		ctxMan := proxy.NewContextManager(benchColl, "benchmark collector", "0.0.2")
-		err := ctxMan.LoadTask(taskId, []byte("{}"), []string{})
-		if err != nil {
-			panic(err)
-		}
-
-		b.ResetTimer()
-		chunkCh = ctxMan.RequestCollect(taskId)
+	benchColl := &benchCollector{
+		metricDefined:  33,
+		addMetricRatio: addRatio,
+		useHandles:     useHandles,
+	}
+	ctxMan := proxy.NewContextManager(context.Background(), types.NewCollector("benchmark collector", "0.0.2", benchColl), &stats.EmptyController{})
+	err := ctxMan.LoadTask(taskId, []byte("{}"), []string{})
+	if err != nil {
+		panic(err)
+	}
 
-		select {
-		case <-chunkCh:
-		// ok
-		case <-time.After(collectTimeout):
-			panic("timeout occurred")
-		}
+	b.ReportAllocs()
+	b.ResetTimer()
 
-		if err != nil {
-			panic(err)
+	for i := 0; i < b.N; i++ {
+		chunkCh := ctxMan.RequestCollect(context.Background(), taskId)
+		timeoutCh := time.After(collectTimeout)
+
+	collectLoop:
+		for {
+			select {
+			case chunk, ok := <-chunkCh:
+				if !ok {
+					break collectLoop
+				}
+				if chunk.Err != nil {
+					panic(chunk.Err)
+				}
+			case <-timeoutCh:
+				panic("timeout occurred")
+			}
 		}
 	}
 }
 
-func BenchmarkFilterMetrics_All_25p(b *testing.B)  { genMetricAddition(4, b) }
-func BenchmarkFilterMetrics_All_50p(b *testing.B)  { genMetricAddition(2, b) }
-func BenchmarkFilterMetrics_All_100p(b *testing.B) { genMetricAddition(1, b) }
+func BenchmarkFilterMetrics_All_25p(b *testing.B)  { genMetricAddition(4, false, b) }
+func BenchmarkFilterMetrics_All_50p(b *testing.B)  { genMetricAddition(2, false, b) }
+func BenchmarkFilterMetrics_All_100p(b *testing.B) { genMetricAddition(1, false, b) }
+
+func BenchmarkNamespaceHandle_All_25p(b *testing.B)  { genMetricAddition(4, true, b) }
+func BenchmarkNamespaceHandle_All_50p(b *testing.B)  { genMetricAddition(2, true, b) }
+func BenchmarkNamespaceHandle_All_100p(b *testing.B) { genMetricAddition(1, true, b) }
 
 ///////////////////////////////////////////////////////////////////////////////
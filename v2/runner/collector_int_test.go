@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -36,6 +37,8 @@ import (
 	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 /*****************************************************************************/
@@ -82,7 +85,7 @@ func (s *SuiteT) startCollector(collector plugin.Collector) net.Listener {
 	go func() {
 		statsController, _ := stats.NewEmptyController()
 		contextManager := proxy.NewContextManager(context.Background(), types.NewCollector("test-collector", "1.0.0", collector), statsController)
-		service.StartCollectorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0)
+		service.StartCollectorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0, 0)
 		s.endCh <- true
 	}()
 
@@ -98,7 +101,7 @@ func (s *SuiteT) startStreamingCollector(collector plugin.StreamingCollector) ne
 	go func() {
 		statsController, _ := stats.NewEmptyController()
 		contextManager := proxy.NewContextManager(context.Background(), types.NewStreamingCollector("test-collector", "1.0.0", collector), statsController)
-		service.StartCollectorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0)
+		service.StartCollectorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0, 0)
 		s.endCh <- true
 	}()
 
@@ -997,3 +1000,160 @@ func (s *SuiteT) TestCollectorWithAlwaysApply() {
 		}
 	})
 }
+
+/*****************************************************************************/
+
+type flushingCollector struct {
+	firstChunkReceived chan struct{}
+	flushErr           error
+	chunkDelivered     bool
+}
+
+func (c *flushingCollector) PluginDefinition(def plugin.CollectorDefinition) error {
+	return def.DefineFlushThreshold(50)
+}
+
+func (c *flushingCollector) Collect(ctx plugin.CollectContext) error {
+	for i := 0; i < 120; i++ {
+		_ = ctx.AddMetric(fmt.Sprintf("/coll/group1/metric%d", i), i)
+
+		if i == 29 {
+			c.flushErr = ctx.Flush()
+
+			// first chunk should be delivered while collection is still in progress
+			select {
+			case <-c.firstChunkReceived:
+				c.chunkDelivered = true
+			case <-time.After(expectedUnloadTimeout):
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *SuiteT) TestFlushingCollector() {
+	// Arrange
+	jsonConfig := []byte(`{}`)
+	mtsSelector := []string{}
+
+	collector := &flushingCollector{firstChunkReceived: make(chan struct{})}
+	ln := s.startCollector(collector)
+	s.startClient(ln.Addr().String())
+
+	Convey("Validate collector can send metrics before collection is completed", s.T(), func() {
+		_, _ = s.sendLoad("task-1", jsonConfig, mtsSelector)
+
+		stream, err := s.collectorClient.Collect(context.Background(), &pluginrpc.CollectRequest{
+			TaskId: "task-1",
+		})
+		So(err, ShouldBeNil)
+
+		var chunkSizes []int
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			So(err, ShouldBeNil)
+
+			if len(chunkSizes) == 0 {
+				close(collector.firstChunkReceived)
+			}
+			chunkSizes = append(chunkSizes, len(resp.MetricSet))
+		}
+
+		So(collector.flushErr, ShouldBeNil)
+		So(collector.chunkDelivered, ShouldBeTrue)
+		So(chunkSizes, ShouldResemble, []int{30, 50, 40}) // explicit flush, threshold, rest of metrics
+	})
+}
+
+/*****************************************************************************/
+
+type timingOutCollector struct {
+	canceledCalls int32
+}
+
+func (c *timingOutCollector) Collect(ctx plugin.CollectContext) error {
+	for i := 0; i < 10; i++ {
+		_ = ctx.AddMetric(fmt.Sprintf("/coll/group1/metric%d", i), i)
+	}
+
+	// wait (much longer than timeout) until collection is canceled
+	select {
+	case <-ctx.Done():
+		atomic.AddInt32(&c.canceledCalls, 1)
+	case <-time.After(3 * expectedUnloadTimeout):
+	}
+
+	return nil
+}
+
+func (s *SuiteT) TestTimingOutCollector() {
+	// Arrange
+	mtsSelector := []string{}
+
+	collector := &timingOutCollector{}
+	ln := s.startCollector(collector)
+	s.startClient(ln.Addr().String())
+
+	collectPartial := func(ctx context.Context, taskID string) (int, error) {
+		stream, err := s.collectorClient.Collect(ctx, &pluginrpc.CollectRequest{
+			TaskId: taskID,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		mtsCount := 0
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return mtsCount, nil
+			}
+			if err != nil {
+				return mtsCount, err
+			}
+
+			mtsCount += len(resp.MetricSet)
+		}
+	}
+
+	Convey("Validate collection is canceled when it exceeds timeout", s.T(), func() {
+		Convey("Timeout is taken from task configuration", func() {
+			_, err := s.sendLoad("task-1", []byte(`{"__collectTimeout": "500ms"}`), mtsSelector)
+			So(err, ShouldBeNil)
+
+			for i := 0; i < 2; i++ { // task may be collected again after timeout
+				startTime := time.Now()
+				mtsCount, err := collectPartial(context.Background(), "task-1")
+
+				So(time.Since(startTime), ShouldBeLessThan, expectedUnloadTimeout)
+				So(mtsCount, ShouldEqual, 10)
+				So(status.Code(err), ShouldEqual, codes.DeadlineExceeded)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			So(atomic.LoadInt32(&collector.canceledCalls), ShouldEqual, 2)
+		})
+
+		Convey("Timeout is taken from request deadline", func() {
+			_, err := s.sendLoad("task-2", []byte(`{}`), mtsSelector)
+			So(err, ShouldBeNil)
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancelFn()
+
+			mtsCount, err := collectPartial(ctx, "task-2")
+
+			So(mtsCount, ShouldEqual, 10)
+			So(status.Code(err), ShouldEqual, codes.DeadlineExceeded)
+		})
+
+		Convey("Invalid timeout in task configuration is rejected", func() {
+			_, err := s.sendLoad("task-3", []byte(`{"__collectTimeout": "often"}`), mtsSelector)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
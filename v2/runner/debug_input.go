@@ -0,0 +1,461 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	debugInputFormatJSONL  = "jsonl"
+	debugInputFormatInflux = "influx"
+
+	debugInputStdin = "-"
+
+	maxDebugInputLineLength = 1024 * 1024
+)
+
+var debugInputFormats = []string{debugInputFormatJSONL, debugInputFormatInflux}
+
+func isValidDebugInputFormat(format string) bool {
+	for _, f := range debugInputFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// debugInput reads metrics (and events) published in debug mode, line by line.
+// Accepted formats are JSON lines (as printed by collectors with -debug-output-format=jsonl) and influx line protocol.
+type debugInput struct {
+	format  string
+	scanner *bufio.Scanner
+	lineNo  int
+
+	closeFn func() error
+}
+
+// Open input for debug mode (empty path or "-" means stdin)
+func openDebugInput(format string, path string) (*debugInput, error) {
+	if path == "" || path == debugInputStdin {
+		return newDebugInput(format, os.Stdin), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't open debug input file: %w", err)
+	}
+
+	d := newDebugInput(format, f)
+	d.closeFn = f.Close
+	return d, nil
+}
+
+func newDebugInput(format string, r io.Reader) *debugInput {
+	if format == "" {
+		format = debugInputFormatJSONL
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxDebugInputLineLength)
+
+	return &debugInput{
+		format:  format,
+		scanner: scanner,
+	}
+}
+
+func (d *debugInput) Close() error {
+	if d.closeFn != nil {
+		return d.closeFn()
+	}
+
+	return nil
+}
+
+// Read up to batchSize metrics (with events encountered on the way). io.EOF is returned with the last batch.
+func (d *debugInput) ReadBatch(batchSize int) ([]*types.Metric, []*types.Event, error) {
+	var mts []*types.Metric
+	var events []*types.Event
+
+	for len(mts) < batchSize {
+		if !d.scanner.Scan() {
+			if err := d.scanner.Err(); err != nil {
+				return mts, events, fmt.Errorf("can't read input: %w", err)
+			}
+			return mts, events, io.EOF
+		}
+		d.lineNo++
+
+		line := strings.TrimSpace(d.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var err error
+		var lineMts []*types.Metric
+		var ev *types.Event
+
+		switch d.format {
+		case debugInputFormatJSONL:
+			lineMts, ev, err = parseJSONLine(line)
+		case debugInputFormatInflux:
+			lineMts, err = parseInfluxLine(line)
+		default:
+			err = fmt.Errorf("unsupported format (%s)", d.format)
+		}
+		if err != nil {
+			return mts, events, fmt.Errorf("invalid input (line %d): %w", d.lineNo, err)
+		}
+
+		mts = append(mts, lineMts...)
+		if ev != nil {
+			events = append(events, ev)
+		}
+	}
+
+	return mts, events, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// json lines
+
+type debugInputRecord struct {
+	Type        string            `json:"type"`
+	Namespace   string            `json:"namespace"`
+	Value       json.RawMessage   `json:"value"`
+	Tags        map[string]string `json:"tags"`
+	Unit        string            `json:"unit"`
+	Description string            `json:"description"`
+	Kind        string            `json:"kind"`
+	Timestamp   time.Time         `json:"timestamp"`
+
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	Severity string `json:"severity"`
+}
+
+func parseJSONLine(line string) ([]*types.Metric, *types.Event, error) {
+	record := debugInputRecord{}
+
+	err := json.Unmarshal([]byte(line), &record)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timestamp := record.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	switch record.Type {
+	case debugRecordEvent:
+		return nil, &types.Event{
+			Title_:     record.Title,
+			Text_:      record.Text,
+			Severity_:  parseEventSeverity(record.Severity),
+			Tags_:      record.Tags,
+			Timestamp_: timestamp,
+		}, nil
+	case debugRecordMetric, "":
+	default:
+		return nil, nil, fmt.Errorf("unsupported type of record (%s)", record.Type)
+	}
+
+	ns, err := parseDebugNamespace(record.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	value, err := parseJSONValue(record.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []*types.Metric{{
+		Namespace_:   ns,
+		Value_:       value,
+		Tags_:        record.Tags,
+		Unit_:        record.Unit,
+		Description_: record.Description,
+		Kind_:        parseMetricKind(record.Kind),
+		Timestamp_:   timestamp,
+	}}, nil, nil
+}
+
+func parseJSONValue(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+
+	switch value := v.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i, nil
+		}
+		return value.Float64()
+	case map[string]interface{}:
+		if _, ok := value["buckets"]; ok {
+			h := debugHistogram{}
+			err = json.Unmarshal(raw, &h)
+			return h.toHistogram(), err
+		}
+		if _, ok := value["quantiles"]; ok {
+			s := debugSummary{}
+			err = json.Unmarshal(raw, &s)
+			return s.toSummary(), err
+		}
+		return nil, fmt.Errorf("unsupported value: %s", string(raw))
+	case []interface{}:
+		return nil, fmt.Errorf("unsupported value: %s", string(raw))
+	}
+
+	return v, nil
+}
+
+func (h debugHistogram) toHistogram() plugin.Histogram {
+	hist := plugin.Histogram{Sum: h.Sum, Count: h.Count}
+	for le, count := range h.Buckets {
+		bound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			continue
+		}
+		hist.Buckets = append(hist.Buckets, plugin.HistogramBucket{UpperBound: bound, Count: count})
+	}
+
+	sort.Slice(hist.Buckets, func(i, j int) bool {
+		return hist.Buckets[i].UpperBound < hist.Buckets[j].UpperBound
+	})
+
+	return hist
+}
+
+func (s debugSummary) toSummary() plugin.Summary {
+	summary := plugin.Summary{Sum: s.Sum, Count: s.Count}
+	for q, value := range s.Quantiles {
+		quantile, err := strconv.ParseFloat(q, 64)
+		if err != nil {
+			continue
+		}
+		summary.Quantiles = append(summary.Quantiles, plugin.SummaryQuantile{Quantile: quantile, Value: value})
+	}
+
+	sort.Slice(summary.Quantiles, func(i, j int) bool {
+		return summary.Quantiles[i].Quantile < summary.Quantiles[j].Quantile
+	})
+
+	return summary
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// influx line protocol
+
+var influxUnescaper = strings.NewReplacer(`\,`, ",", `\ `, " ", `\=`, "=", `\"`, `"`, `\\`, `\`)
+
+// Parse single line of influx line protocol. Measurement elements (separated by '.') become namespace elements.
+// Field "value" is a value of metric named after measurement, other fields are values of metrics <measurement>/<field>.
+func parseInfluxLine(line string) ([]*types.Metric, error) {
+	parts := splitInflux(line, ' ', 3)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("line should contain measurement and fields")
+	}
+
+	timestamp := time.Now()
+	if len(parts) == 3 && parts[2] != "" {
+		ns, err := strconv.ParseInt(parts[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp (%s): %w", parts[2], err)
+		}
+		timestamp = time.Unix(0, ns)
+	}
+
+	series := splitInflux(parts[0], ',', 0)
+	measurement := influxUnescaper.Replace(series[0])
+	if measurement == "" {
+		return nil, fmt.Errorf("measurement can't be empty")
+	}
+
+	var tags map[string]string
+	for _, tag := range series[1:] {
+		kv := splitInflux(tag, '=', 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid tag (%s)", tag)
+		}
+		if tags == nil {
+			tags = map[string]string{}
+		}
+		tags[influxUnescaper.Replace(kv[0])] = influxUnescaper.Replace(kv[1])
+	}
+
+	var mts []*types.Metric
+	for _, field := range splitInflux(parts[1], ',', 0) {
+		kv := splitInflux(field, '=', 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid field (%s)", field)
+		}
+
+		value, err := parseInfluxValue(kv[1])
+		if err != nil {
+			return nil, err
+		}
+
+		name := strings.Split(measurement, ".")
+		if key := influxUnescaper.Replace(kv[0]); key != "value" {
+			name = append(name, key)
+		}
+
+		ns := make([]types.NamespaceElement, 0, len(name))
+		for _, el := range name {
+			ns = append(ns, types.NamespaceElement{Value_: el})
+		}
+
+		mts = append(mts, &types.Metric{
+			Namespace_: ns,
+			Value_:     value,
+			Tags_:      copyTags(tags),
+			Timestamp_: timestamp,
+		})
+	}
+
+	return mts, nil
+}
+
+func parseInfluxValue(s string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) && len(s) >= 2:
+		return influxUnescaper.Replace(s[1 : len(s)-1]), nil
+	case strings.HasSuffix(s, "i"):
+		return strconv.ParseInt(strings.TrimSuffix(s, "i"), 10, 64)
+	case strings.HasSuffix(s, "u"):
+		return strconv.ParseUint(strings.TrimSuffix(s, "u"), 10, 64)
+	}
+
+	switch s {
+	case "t", "T", "true", "True", "TRUE":
+		return true, nil
+	case "f", "F", "false", "False", "FALSE":
+		return false, nil
+	}
+
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field value (%s)", s)
+	}
+
+	return v, nil
+}
+
+// Split by separator which is not escaped and not enclosed in quotes (up to n parts, n <= 0 means no limit)
+func splitInflux(s string, sep byte, n int) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\':
+			i++
+		case s[i] == '"':
+			inQuotes = !inQuotes
+		case s[i] == sep && !inQuotes && (n <= 0 || len(parts) < n-1):
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// helpers
+
+// Convert namespace like /plugin/[grp=id]/m1 into namespace elements
+func parseDebugNamespace(s string) ([]types.NamespaceElement, error) {
+	if !strings.HasPrefix(s, "/") || len(s) < 2 {
+		return nil, fmt.Errorf("invalid namespace (%s)", s)
+	}
+
+	var ns []types.NamespaceElement
+	for _, el := range strings.Split(s[1:], "/") {
+		if strings.HasPrefix(el, "[") && strings.HasSuffix(el, "]") {
+			if eqIndex := strings.Index(el, "="); eqIndex != -1 {
+				ns = append(ns, types.NamespaceElement{Name_: el[1:eqIndex], Value_: el[eqIndex+1 : len(el)-1]})
+				continue
+			}
+		}
+
+		ns = append(ns, types.NamespaceElement{Value_: el})
+	}
+
+	return ns, nil
+}
+
+func parseMetricKind(s string) plugin.MetricKind {
+	for _, kind := range []plugin.MetricKind{plugin.MetricKindGauge, plugin.MetricKindCounter, plugin.MetricKindDelta} {
+		if kind.String() == s {
+			return kind
+		}
+	}
+
+	return plugin.MetricKindUnspecified
+}
+
+func parseEventSeverity(s string) plugin.EventSeverity {
+	for _, severity := range []plugin.EventSeverity{plugin.EventSeverityWarning, plugin.EventSeverityError, plugin.EventSeverityCritical} {
+		if severity.String() == s {
+			return severity
+		}
+	}
+
+	return plugin.EventSeverityInfo
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	if tags == nil {
+		return nil
+	}
+
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+
+	return c
+}
@@ -0,0 +1,122 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+func TestDebugInput(t *testing.T) {
+	Convey("Validate that metrics published in debug mode can be read from supported formats", t, func() {
+		Convey("jsonl (as printed by collector in debug mode)", func() {
+			// Arrange
+			buf := &bytes.Buffer{}
+			newDebugOutput(debugFormatJSONL, buf, &bytes.Buffer{}).WriteChunk(debugChunk())
+
+			// Act
+			mts, events, err := newDebugInput(debugInputFormatJSONL, buf).ReadBatch(10)
+
+			// Assert
+			So(err, ShouldEqual, io.EOF)
+			So(mts, ShouldHaveLength, 3)
+			So(events, ShouldHaveLength, 1)
+
+			So(mts[0].Namespace().String(), ShouldEqual, "/example/[disk=sda]/io_time")
+			So(mts[0].Namespace().At(1).IsDynamic(), ShouldBeTrue)
+			So(mts[0].Value(), ShouldEqual, 10.5)
+			So(mts[0].Tags(), ShouldResemble, map[string]string{"host": "srv 1"})
+			So(mts[0].Unit(), ShouldEqual, "ms")
+			So(mts[0].Kind(), ShouldEqual, plugin.MetricKindCounter)
+			So(mts[0].Timestamp().Equal(debugTimestamp), ShouldBeTrue)
+			So(mts[1].Value(), ShouldEqual, "text")
+			So(mts[2].Value(), ShouldResemble, plugin.Histogram{
+				Buckets: []plugin.HistogramBucket{{UpperBound: 0.5, Count: 1}, {UpperBound: 1, Count: 3}},
+				Sum:     2.5,
+				Count:   4,
+			})
+
+			So(events[0].Title(), ShouldEqual, "restart")
+			So(events[0].Severity(), ShouldEqual, plugin.EventSeverityWarning)
+		})
+
+		Convey("influx line protocol", func() {
+			// Arrange
+			input := strings.Join([]string{
+				"# comment",
+				`example.disk.io_time,disk=sda,host=srv\ 1 value=10.5 1600000000000000000`,
+				`example.name value="quoted \"text\", with comma" 1600000000000000000`,
+				"example.ops count=4i,ok=t",
+			}, "\n")
+
+			// Act
+			mts, events, err := newDebugInput(debugInputFormatInflux, strings.NewReader(input)).ReadBatch(10)
+
+			// Assert
+			So(err, ShouldEqual, io.EOF)
+			So(events, ShouldBeEmpty)
+			So(mts, ShouldHaveLength, 4)
+
+			So(mts[0].Namespace().String(), ShouldEqual, "/example/disk/io_time")
+			So(mts[0].Tags(), ShouldResemble, map[string]string{"disk": "sda", "host": "srv 1"})
+			So(mts[0].Value(), ShouldEqual, 10.5)
+			So(mts[0].Timestamp().Equal(debugTimestamp), ShouldBeTrue)
+			So(mts[1].Value(), ShouldEqual, `quoted "text", with comma`)
+			So(mts[2].Namespace().String(), ShouldEqual, "/example/ops/count")
+			So(mts[2].Value(), ShouldEqual, int64(4))
+			So(mts[3].Namespace().String(), ShouldEqual, "/example/ops/ok")
+			So(mts[3].Value(), ShouldEqual, true)
+		})
+
+		Convey("Metrics are read in batches", func() {
+			// Arrange
+			input := newDebugInput(debugInputFormatInflux, strings.NewReader("a.m1 value=1\na.m2 value=2\na.m3 value=3\n"))
+
+			// Act & Assert
+			mts, _, err := input.ReadBatch(2)
+			So(err, ShouldBeNil)
+			So(mts, ShouldHaveLength, 2)
+
+			mts, _, err = input.ReadBatch(2)
+			So(err, ShouldEqual, io.EOF)
+			So(mts, ShouldHaveLength, 1)
+		})
+
+		Convey("Invalid lines are reported", func() {
+			invalidInputs := map[string]string{
+				debugInputFormatJSONL:  `{"type":"metric","namespace":"no-separator","value":1}`,
+				debugInputFormatInflux: "example.metric value=abc",
+			}
+
+			for format, line := range invalidInputs {
+				// Act
+				_, _, err := newDebugInput(format, strings.NewReader(line)).ReadBatch(10)
+
+				// Assert
+				So(err, ShouldBeError)
+				So(err.Error(), ShouldContainSubstring, "line 1")
+			}
+		})
+	})
+}
@@ -0,0 +1,629 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const (
+	debugFormatText       = "text"
+	debugFormatJSON       = "json" // alias of jsonl
+	debugFormatJSONL      = "jsonl"
+	debugFormatInflux     = "influx"
+	debugFormatPrometheus = "prometheus"
+	debugFormatCSV        = "csv"
+)
+
+var debugOutputFormats = []string{debugFormatText, debugFormatJSON, debugFormatJSONL, debugFormatInflux, debugFormatPrometheus, debugFormatCSV}
+
+const (
+	debugRecordMetric  = "metric"
+	debugRecordEvent   = "event"
+	debugRecordWarning = "warning"
+	debugRecordError   = "error"
+)
+
+var csvHeader = []string{"timestamp", "namespace", "value", "unit", "kind", "tags", "groups", "description"}
+
+func isValidDebugOutputFormat(format string) bool {
+	for _, f := range debugOutputFormats {
+		if f == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// debugOutput prints results of collections requested in debug mode.
+// In structured formats metrics are written to out (file or stdout) while warnings, errors (and events which
+// can't be represented in a given format) are written to diag (stderr) as JSON lines.
+type debugOutput struct {
+	format string
+	out    io.Writer
+	diag   io.Writer
+	task   string // ID of task which results are printed (set only when several tasks are run)
+
+	csvHeaderWritten bool
+	closeFn          func() error
+}
+
+// Open output for debug mode (empty path means stdout)
+func openDebugOutput(format string, path string) (*debugOutput, error) {
+	if path == "" {
+		return newDebugOutput(format, os.Stdout, os.Stderr), nil
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't create debug output file: %w", err)
+	}
+
+	d := newDebugOutput(format, f, os.Stderr)
+	d.closeFn = f.Close
+	return d, nil
+}
+
+func newDebugOutput(format string, out io.Writer, diag io.Writer) *debugOutput {
+	if format == "" {
+		format = debugFormatText
+	}
+
+	return &debugOutput{
+		format: format,
+		out:    out,
+		diag:   diag,
+	}
+}
+
+// Mark subsequent results as coming from a given task
+func (d *debugOutput) SetTask(taskID string) {
+	d.task = taskID
+}
+
+func (d *debugOutput) Close() error {
+	if d.closeFn != nil {
+		return d.closeFn()
+	}
+
+	return nil
+}
+
+func (d *debugOutput) WriteChunk(chunk types.CollectChunk) {
+	switch d.format {
+	case debugFormatText:
+		d.writeText(chunk)
+		return
+	case debugFormatJSON, debugFormatJSONL:
+		for _, mt := range chunk.Metrics {
+			d.writeRecord(d.out, newDebugMetricRecord(mt, d.task))
+		}
+		for _, ev := range chunk.Events {
+			d.writeRecord(d.out, newDebugEventRecord(ev, d.task))
+		}
+	case debugFormatInflux:
+		for _, mt := range chunk.Metrics {
+			d.writeInflux(mt)
+		}
+	case debugFormatPrometheus:
+		d.writePrometheus(chunk.Metrics)
+	case debugFormatCSV:
+		d.writeCSV(chunk.Metrics)
+	}
+
+	if d.format != debugFormatJSON && d.format != debugFormatJSONL {
+		for _, ev := range chunk.Events {
+			d.writeRecord(d.diag, newDebugEventRecord(ev, d.task))
+		}
+	}
+
+	for _, w := range chunk.Warnings {
+		d.writeRecord(d.diag, debugDiagRecord{Type: debugRecordWarning, Task: d.task, Message: w.Message, Timestamp: w.Timestamp})
+	}
+}
+
+func (d *debugOutput) WriteError(err error) {
+	if d.format == debugFormatText {
+		if d.task != "" {
+			_, _ = fmt.Fprintf(d.diag, "Task %s: ", d.task)
+		}
+		_, _ = fmt.Fprintf(d.diag, "Error occurred during metrics collection in a standalone mode (reason: %v)\n", secrets.RedactError(err))
+		return
+	}
+
+	d.writeRecord(d.diag, debugDiagRecord{Type: debugRecordError, Task: d.task, Message: err.Error(), Timestamp: time.Now()})
+}
+
+func (d *debugOutput) writeLine(w io.Writer, line string) {
+	_, _ = fmt.Fprintf(w, "%s\n", secrets.Redact(line))
+}
+
+func (d *debugOutput) writeRecord(w io.Writer, record interface{}) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		b, _ = json.Marshal(debugDiagRecord{Type: debugRecordError, Message: fmt.Sprintf("can't encode record: %v", err), Timestamp: time.Now()})
+	}
+
+	d.writeLine(w, string(b))
+}
+
+func (d *debugOutput) warn(format string, args ...interface{}) {
+	d.writeRecord(d.diag, debugDiagRecord{Type: debugRecordWarning, Task: d.task, Message: fmt.Sprintf(format, args...), Timestamp: time.Now()})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// text
+
+func (d *debugOutput) writeText(chunk types.CollectChunk) {
+	if d.task != "" {
+		_, _ = fmt.Fprintf(d.out, "Task %s:\n", d.task)
+	}
+
+	_, _ = fmt.Fprintf(d.out, "Gathered metrics (length=%d): \n", len(chunk.Metrics))
+	for _, mt := range chunk.Metrics {
+		d.writeLine(d.out, mt.String())
+	}
+	_, _ = fmt.Fprintf(d.out, "\n")
+
+	if len(chunk.Warnings) != 0 {
+		_, _ = fmt.Fprintf(d.out, "Gathered warnings (length=%d): \n", len(chunk.Warnings))
+		for _, w := range chunk.Warnings {
+			d.writeLine(d.out, fmt.Sprintf("%s", w))
+		}
+		_, _ = fmt.Fprintf(d.out, "\n")
+	}
+
+	if len(chunk.Events) != 0 {
+		_, _ = fmt.Fprintf(d.out, "Gathered events (length=%d): \n", len(chunk.Events))
+		for _, ev := range chunk.Events {
+			d.writeLine(d.out, ev.String())
+		}
+		_, _ = fmt.Fprintf(d.out, "\n")
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// json lines
+
+type debugMetricRecord struct {
+	Type        string            `json:"type"`
+	Task        string            `json:"task,omitempty"`
+	Namespace   string            `json:"namespace"`
+	Value       interface{}       `json:"value"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Groups      map[string]string `json:"groups,omitempty"`
+	Unit        string            `json:"unit,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Kind        string            `json:"kind,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+type debugEventRecord struct {
+	Type      string            `json:"type"`
+	Task      string            `json:"task,omitempty"`
+	Title     string            `json:"title"`
+	Text      string            `json:"text,omitempty"`
+	Severity  string            `json:"severity"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+type debugDiagRecord struct {
+	Type      string    `json:"type"`
+	Task      string    `json:"task,omitempty"`
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type debugHistogram struct {
+	Buckets map[string]uint64 `json:"buckets"` // upper bound -> cumulative count
+	Sum     float64           `json:"sum"`
+	Count   uint64            `json:"count"`
+}
+
+type debugSummary struct {
+	Quantiles map[string]float64 `json:"quantiles"` // quantile -> value
+	Sum       float64            `json:"sum"`
+	Count     uint64             `json:"count"`
+}
+
+func newDebugMetricRecord(mt *types.Metric, taskID string) debugMetricRecord {
+	r := debugMetricRecord{
+		Type:        debugRecordMetric,
+		Task:        taskID,
+		Namespace:   mt.Namespace().String(),
+		Value:       jsonValue(mt.Value()),
+		Tags:        mt.Tags(),
+		Groups:      metricGroups(mt),
+		Unit:        mt.Unit(),
+		Description: mt.Description(),
+		Timestamp:   mt.Timestamp(),
+	}
+
+	if mt.Kind() != plugin.MetricKindUnspecified {
+		r.Kind = mt.Kind().String()
+	}
+
+	return r
+}
+
+func newDebugEventRecord(ev *types.Event, taskID string) debugEventRecord {
+	return debugEventRecord{
+		Type:      debugRecordEvent,
+		Task:      taskID,
+		Title:     ev.Title(),
+		Text:      ev.Text(),
+		Severity:  ev.Severity().String(),
+		Tags:      ev.Tags(),
+		Timestamp: ev.Timestamp(),
+	}
+}
+
+// Convert value to form which can be encoded with JSON (NaN and infinities are not supported by encoder)
+func jsonValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case float64:
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return formatFloat(value)
+		}
+	case float32:
+		return jsonValue(float64(value))
+	case []byte:
+		return string(value)
+	case *plugin.Histogram:
+		return jsonValue(*value)
+	case plugin.Histogram:
+		h := debugHistogram{Buckets: map[string]uint64{}, Sum: value.Sum, Count: value.Count}
+		for _, b := range value.Buckets {
+			h.Buckets[formatFloat(b.UpperBound)] = b.Count
+		}
+		return h
+	case *plugin.Summary:
+		return jsonValue(*value)
+	case plugin.Summary:
+		s := debugSummary{Quantiles: map[string]float64{}, Sum: value.Sum, Count: value.Count}
+		for _, q := range value.Quantiles {
+			s.Quantiles[formatFloat(q.Quantile)] = q.Value
+		}
+		return s
+	}
+
+	return v
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// influx line protocol
+
+var (
+	influxMeasurementEscaper = strings.NewReplacer(",", `\,`, " ", `\ `)
+	influxKeyEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	influxStringEscaper      = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+)
+
+func (d *debugOutput) writeInflux(mt *types.Metric) {
+	fields := influxFields(mt.Value())
+	if len(fields) == 0 {
+		d.warn("metric %s can't be represented in influx line protocol (value: %v)", mt.Namespace().String(), mt.Value())
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(influxMeasurementEscaper.Replace(strings.Join(metricNameElements(mt), ".")))
+
+	labels := metricLabels(mt)
+	for _, k := range sortedKeys(labels) {
+		if k == "" || labels[k] == "" { // empty tag values are not allowed
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(",%s=%s", influxKeyEscaper.Replace(k), influxKeyEscaper.Replace(labels[k])))
+	}
+
+	sb.WriteString(" ")
+	sb.WriteString(strings.Join(fields, ","))
+	sb.WriteString(fmt.Sprintf(" %d", mt.Timestamp().UnixNano()))
+
+	d.writeLine(d.out, sb.String())
+}
+
+func influxFields(v interface{}) []string {
+	switch value := v.(type) {
+	case float64:
+		return []string{"value=" + formatFloat(value)}
+	case float32:
+		return []string{"value=" + formatFloat(float64(value))}
+	case int, int16, int32, int64:
+		return []string{fmt.Sprintf("value=%di", value)}
+	case uint, uint16, uint32, uint64:
+		return []string{fmt.Sprintf("value=%du", value)}
+	case bool:
+		return []string{fmt.Sprintf("value=%t", value)}
+	case string:
+		return []string{fmt.Sprintf(`value="%s"`, influxStringEscaper.Replace(value))}
+	case []byte:
+		return []string{fmt.Sprintf(`value="%s"`, influxStringEscaper.Replace(string(value)))}
+	case *plugin.Histogram:
+		return influxFields(*value)
+	case plugin.Histogram:
+		fields := []string{fmt.Sprintf("count=%di", value.Count), "sum=" + formatFloat(value.Sum)}
+		for _, b := range value.Buckets {
+			fields = append(fields, fmt.Sprintf("%s=%di", influxKeyEscaper.Replace("le_"+formatFloat(b.UpperBound)), b.Count))
+		}
+		return fields
+	case *plugin.Summary:
+		return influxFields(*value)
+	case plugin.Summary:
+		fields := []string{fmt.Sprintf("count=%di", value.Count), "sum=" + formatFloat(value.Sum)}
+		for _, q := range value.Quantiles {
+			fields = append(fields, fmt.Sprintf("%s=%s", influxKeyEscaper.Replace("q_"+formatFloat(q.Quantile)), formatFloat(q.Value)))
+		}
+		return fields
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// prometheus exposition format
+
+var prometheusHelpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func (d *debugOutput) writePrometheus(mts []*types.Metric) {
+	described := map[string]bool{}
+
+	for _, mt := range mts {
+		name := prometheusName(strings.Join(metricNameElements(mt), "_"))
+		ts := mt.Timestamp().UnixNano() / int64(time.Millisecond)
+
+		labels := map[string]string{}
+		for k, v := range metricLabels(mt) {
+			labels[prometheusName(k)] = v
+		}
+
+		var samples []string
+		var typ string
+
+		switch value := derefValue(mt.Value()).(type) {
+		case plugin.Histogram:
+			typ = "histogram"
+			for _, b := range value.Buckets {
+				samples = append(samples, prometheusSample(name+"_bucket", labels, "le", formatFloat(b.UpperBound), strconv.FormatUint(b.Count, 10), ts))
+			}
+			if n := len(value.Buckets); n == 0 || !math.IsInf(value.Buckets[n-1].UpperBound, 1) {
+				samples = append(samples, prometheusSample(name+"_bucket", labels, "le", "+Inf", strconv.FormatUint(value.Count, 10), ts))
+			}
+			samples = append(samples,
+				prometheusSample(name+"_sum", labels, "", "", formatFloat(value.Sum), ts),
+				prometheusSample(name+"_count", labels, "", "", strconv.FormatUint(value.Count, 10), ts))
+		case plugin.Summary:
+			typ = "summary"
+			for _, q := range value.Quantiles {
+				samples = append(samples, prometheusSample(name, labels, "quantile", formatFloat(q.Quantile), formatFloat(q.Value), ts))
+			}
+			samples = append(samples,
+				prometheusSample(name+"_sum", labels, "", "", formatFloat(value.Sum), ts),
+				prometheusSample(name+"_count", labels, "", "", strconv.FormatUint(value.Count, 10), ts))
+		default:
+			v, ok := prometheusValue(value)
+			if !ok {
+				d.warn("metric %s can't be represented in prometheus exposition format (value: %v)", mt.Namespace().String(), mt.Value())
+				continue
+			}
+
+			typ = prometheusType(mt.Kind())
+			samples = append(samples, prometheusSample(name, labels, "", "", v, ts))
+		}
+
+		if !described[name] {
+			if mt.Description() != "" {
+				d.writeLine(d.out, fmt.Sprintf("# HELP %s %s", name, prometheusHelpEscaper.Replace(mt.Description())))
+			}
+			d.writeLine(d.out, fmt.Sprintf("# TYPE %s %s", name, typ))
+			described[name] = true
+		}
+
+		for _, s := range samples {
+			d.writeLine(d.out, s)
+		}
+	}
+}
+
+func prometheusSample(name string, labels map[string]string, extraKey, extraValue string, value string, ts int64) string {
+	elems := make([]string, 0, len(labels)+1)
+	for _, k := range sortedKeys(labels) {
+		elems = append(elems, fmt.Sprintf(`%s="%s"`, k, prometheusLabelEscaper.Replace(labels[k])))
+	}
+	if extraKey != "" {
+		elems = append(elems, fmt.Sprintf(`%s="%s"`, extraKey, extraValue))
+	}
+
+	if len(elems) == 0 {
+		return fmt.Sprintf("%s %s %d", name, value, ts)
+	}
+
+	return fmt.Sprintf("%s{%s} %s %d", name, strings.Join(elems, ","), value, ts)
+}
+
+func prometheusValue(v interface{}) (string, bool) {
+	switch value := v.(type) {
+	case float64:
+		return formatFloat(value), true
+	case float32:
+		return formatFloat(float64(value)), true
+	case int, int16, int32, int64, uint, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", value), true
+	case bool:
+		if value {
+			return "1", true
+		}
+		return "0", true
+	}
+
+	return "", false
+}
+
+func prometheusType(kind plugin.MetricKind) string {
+	switch kind {
+	case plugin.MetricKindCounter:
+		return "counter"
+	case plugin.MetricKindGauge, plugin.MetricKindDelta:
+		return "gauge"
+	}
+
+	return "untyped"
+}
+
+// Replace characters which are not allowed in metric and label names
+func prometheusName(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c == ':'
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			b[i] = '_'
+		}
+	}
+
+	return string(b)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// csv
+
+func (d *debugOutput) writeCSV(mts []*types.Metric) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	if !d.csvHeaderWritten {
+		_ = w.Write(csvHeader)
+		d.csvHeaderWritten = true
+	}
+
+	for _, mt := range mts {
+		_ = w.Write([]string{
+			mt.Timestamp().Format(time.RFC3339Nano),
+			mt.Namespace().String(),
+			fmt.Sprintf("%v", derefValue(mt.Value())),
+			mt.Unit(),
+			mt.Kind().String(),
+			joinKeyValues(mt.Tags()),
+			joinKeyValues(metricGroups(mt)),
+			mt.Description(),
+		})
+	}
+
+	w.Flush()
+	if sb.Len() > 0 {
+		d.writeLine(d.out, strings.TrimSuffix(sb.String(), "\n"))
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// helpers
+
+// Values of dynamic elements of metric namespace (group name -> value)
+func metricGroups(mt *types.Metric) map[string]string {
+	var groups map[string]string
+
+	for _, el := range mt.Namespace_ {
+		if el.IsDynamic() {
+			if groups == nil {
+				groups = map[string]string{}
+			}
+			groups[el.Name()] = el.Value()
+		}
+	}
+
+	return groups
+}
+
+// Elements of metric name where dynamic elements are replaced with their names
+func metricNameElements(mt *types.Metric) []string {
+	elems := make([]string, 0, len(mt.Namespace_))
+
+	for _, el := range mt.Namespace_ {
+		if el.IsDynamic() {
+			elems = append(elems, el.Name())
+			continue
+		}
+		elems = append(elems, el.Value())
+	}
+
+	return elems
+}
+
+// Tags and values of dynamic elements (tags take precedence)
+func metricLabels(mt *types.Metric) map[string]string {
+	labels := map[string]string{}
+
+	for k, v := range metricGroups(mt) {
+		labels[k] = v
+	}
+	for k, v := range mt.Tags() {
+		labels[k] = v
+	}
+
+	return labels
+}
+
+func derefValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case *plugin.Histogram:
+		return *value
+	case *plugin.Summary:
+		return *value
+	}
+
+	return v
+}
+
+func joinKeyValues(m map[string]string) string {
+	elems := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		elems = append(elems, k+"="+m[k])
+	}
+
+	return strings.Join(elems, ";")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,183 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+var debugTimestamp = time.Unix(1600000000, 0).UTC()
+
+func debugChunk() types.CollectChunk {
+	return types.CollectChunk{
+		Metrics: []*types.Metric{
+			{
+				Namespace_: []types.NamespaceElement{
+					{Value_: "example"},
+					{Name_: "disk", Value_: "sda"},
+					{Value_: "io_time"},
+				},
+				Value_:       10.5,
+				Tags_:        map[string]string{"host": "srv 1"},
+				Unit_:        "ms",
+				Description_: "Time spent doing I/O",
+				Kind_:        plugin.MetricKindCounter,
+				Timestamp_:   debugTimestamp,
+			},
+			{
+				Namespace_: []types.NamespaceElement{{Value_: "example"}, {Value_: "name"}},
+				Value_:     "text",
+				Timestamp_: debugTimestamp,
+			},
+			{
+				Namespace_: []types.NamespaceElement{{Value_: "example"}, {Value_: "latency"}},
+				Value_: &plugin.Histogram{
+					Buckets: []plugin.HistogramBucket{{UpperBound: 0.5, Count: 1}, {UpperBound: 1, Count: 3}},
+					Sum:     2.5,
+					Count:   4,
+				},
+				Timestamp_: debugTimestamp,
+			},
+		},
+		Warnings: []types.Warning{{Message: "slow disk", Timestamp: debugTimestamp}},
+		Events:   []*types.Event{{Title_: "restart", Severity_: plugin.EventSeverityWarning, Timestamp_: debugTimestamp}},
+	}
+}
+
+func TestDebugOutput(t *testing.T) {
+	Convey("Validate that metrics gathered in debug mode can be printed in supported formats", t, func() {
+		out := &bytes.Buffer{}
+		diag := &bytes.Buffer{}
+
+		Convey("text", func() {
+			// Act
+			newDebugOutput(debugFormatText, out, diag).WriteChunk(debugChunk())
+
+			// Assert
+			So(out.String(), ShouldContainSubstring, "Gathered metrics (length=3)")
+			So(out.String(), ShouldContainSubstring, "/example/[disk=sda]/io_time 10.5 {map[host:srv 1]}")
+			So(out.String(), ShouldContainSubstring, "Gathered warnings (length=1)")
+			So(out.String(), ShouldContainSubstring, "Gathered events (length=1)")
+			So(diag.String(), ShouldBeEmpty)
+		})
+
+		Convey("jsonl", func() {
+			// Act
+			newDebugOutput(debugFormatJSONL, out, diag).WriteChunk(debugChunk())
+
+			// Assert
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			So(lines, ShouldHaveLength, 4)
+
+			record := map[string]interface{}{}
+			So(json.Unmarshal([]byte(lines[0]), &record), ShouldBeNil)
+			So(record["type"], ShouldEqual, "metric")
+			So(record["namespace"], ShouldEqual, "/example/[disk=sda]/io_time")
+			So(record["value"], ShouldEqual, 10.5)
+			So(record["unit"], ShouldEqual, "ms")
+			So(record["kind"], ShouldEqual, "counter")
+			So(record["groups"], ShouldResemble, map[string]interface{}{"disk": "sda"})
+			So(lines[2], ShouldContainSubstring, `"value":{"buckets":{"0.5":1,"1":3},"sum":2.5,"count":4}`)
+			So(lines[3], ShouldContainSubstring, `"type":"event"`)
+
+			So(strings.TrimSpace(diag.String()), ShouldStartWith, `{"type":"warning","message":"slow disk"`)
+		})
+
+		Convey("influx", func() {
+			// Act
+			newDebugOutput(debugFormatInflux, out, diag).WriteChunk(debugChunk())
+
+			// Assert
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			So(lines, ShouldResemble, []string{
+				`example.disk.io_time,disk=sda,host=srv\ 1 value=10.5 1600000000000000000`,
+				`example.name value="text" 1600000000000000000`,
+				`example.latency count=4i,sum=2.5,le_0.5=1i,le_1=3i 1600000000000000000`,
+			})
+			So(diag.String(), ShouldContainSubstring, `"type":"event"`)
+			So(diag.String(), ShouldContainSubstring, `"type":"warning"`)
+		})
+
+		Convey("prometheus", func() {
+			// Act
+			newDebugOutput(debugFormatPrometheus, out, diag).WriteChunk(debugChunk())
+
+			// Assert
+			So(out.String(), ShouldEqual, strings.Join([]string{
+				"# HELP example_disk_io_time Time spent doing I/O",
+				"# TYPE example_disk_io_time counter",
+				`example_disk_io_time{disk="sda",host="srv 1"} 10.5 1600000000000`,
+				"# TYPE example_latency histogram",
+				`example_latency_bucket{le="0.5"} 1 1600000000000`,
+				`example_latency_bucket{le="1"} 3 1600000000000`,
+				`example_latency_bucket{le="+Inf"} 4 1600000000000`,
+				"example_latency_sum 2.5 1600000000000",
+				"example_latency_count 4 1600000000000",
+				"",
+			}, "\n"))
+			So(diag.String(), ShouldContainSubstring, "metric /example/name can't be represented in prometheus exposition format")
+		})
+
+		Convey("csv", func() {
+			// Arrange
+			output := newDebugOutput(debugFormatCSV, out, diag)
+
+			// Act
+			output.WriteChunk(debugChunk())
+			output.WriteChunk(types.CollectChunk{})
+
+			// Assert
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			So(lines, ShouldHaveLength, 4)
+			So(lines[0], ShouldEqual, "timestamp,namespace,value,unit,kind,tags,groups,description")
+			So(lines[1], ShouldEqual, "2020-09-13T12:26:40Z,/example/[disk=sda]/io_time,10.5,ms,counter,host=srv 1,disk=sda,Time spent doing I/O")
+		})
+
+		Convey("results of several tasks", func() {
+			// Arrange
+			output := newDebugOutput(debugFormatJSONL, out, diag)
+
+			// Act
+			output.SetTask("task-2")
+			output.WriteChunk(debugChunk())
+
+			// Assert
+			So(out.String(), ShouldStartWith, `{"type":"metric","task":"task-2",`)
+			So(diag.String(), ShouldStartWith, `{"type":"warning","task":"task-2",`)
+		})
+
+		Convey("errors", func() {
+			// Act
+			newDebugOutput(debugFormatJSONL, out, diag).WriteError(errors.New("collection failed"))
+
+			// Assert
+			So(out.String(), ShouldBeEmpty)
+			So(diag.String(), ShouldStartWith, `{"type":"error","message":"collection failed"`)
+		})
+	})
+}
@@ -41,8 +41,15 @@ const (
 	defaultFilter          = ""
 	defaultCollectInterval = 5 * time.Second
 	defaultCollectCount    = 1
+	defaultCollectTimeout  = 0
+	defaultSeriesWindow    = 1 * time.Hour
+
+	defaultDebugOutputFormat = debugFormatText
+	defaultDebugInputFormat  = debugInputFormatJSONL
+	defaultPublishBatch      = 100
 
 	defaultLogLevel = logrus.WarnLevel
+	defaultStateDir = "snap-plugin-state" // relative to working directory of plugin
 
 	filterSeparator = ";"
 )
@@ -73,6 +80,10 @@ func newFlagParser(name string, pType types.PluginType, opt *plugin.Options) *fl
 		"grpc-ping-max-missed", service.DefaultMaxMissingPingCounter,
 		"Number of missed ping messages after which plugin should exit")
 
+	flagParser.DurationVar(&opt.ShutdownTimeout,
+		"shutdown-timeout", service.DefaultShutdownTimeout,
+		"Grace period for stopping plugin (completing requests in progress, unloading tasks)")
+
 	allLogLevels := strings.Replace(fmt.Sprintf("%v", logrus.AllLevels), " ", ", ", -1)
 	flagParser.Var(&logLevelHandler{opt: opt},
 		"log-level",
@@ -102,6 +113,11 @@ func newFlagParser(name string, pType types.PluginType, opt *plugin.Options) *fl
 		"plugin-api-v2", true,
 		"If a plugin supports multiple plugin API versions, set it to use v2")
 
+	flagParser.StringVar(&opt.StateDir,
+		"state-dir", defaultStateDir,
+		"Directory where persistent state of tasks is stored (relative to working directory of plugin). "+
+			"Instances of plugin with the same name have to use different directories, otherwise they overwrite each other's state")
+
 	flagParser.BoolVar(&opt.EnableTLS,
 		"tls", false,
 		"Enable secure GRPC communication")
@@ -124,11 +140,41 @@ func newFlagParser(name string, pType types.PluginType, opt *plugin.Options) *fl
 		"print-example-task", false,
 		"Print-out example task for a plugin")
 
-	if pType == types.PluginTypeCollector {
+	flagParser.BoolVar(&opt.PrintConfigSchema,
+		"print-config-schema", false,
+		"Print-out JSON Schema of configuration supported by a plugin")
+
+	if pType == types.PluginTypeCollector || pType == types.PluginTypePublisher {
 		flagParser.BoolVar(&opt.DebugMode,
 			"debug-mode", false,
 			"Run plugin in debug mode (standalone)")
 
+		flagParser.StringVar(&opt.PluginConfig,
+			"plugin-config", defaultConfig,
+			"Plugin configuration in debug mode")
+	}
+
+	if pType == types.PluginTypeCollector {
+		flagParser.StringVar(&opt.PrintMetricsCatalog,
+			"print-metrics-catalog", "",
+			fmt.Sprintf("Print-out metrics defined by a plugin (with groups, descriptions, units and kinds) in a given format (%s)", strings.Join(catalogFormats, ", ")))
+
+		flagParser.DurationVar(&opt.CollectTimeout,
+			"collect-timeout", defaultCollectTimeout,
+			fmt.Sprintf("Maximum duration of a single collect request, might be overridden by task config (%s). 0 means no limit", plugin.CollectTimeoutConfigKey))
+
+		flagParser.IntVar(&opt.MaxSeriesPerCollect,
+			"max-series-per-collect", 0,
+			fmt.Sprintf("Maximum number of distinct series (namespace + tags) emitted by a task in a single collect request, might be overridden by task config (%s). 0 means no limit", plugin.MaxSeriesPerCollectConfigKey))
+
+		flagParser.IntVar(&opt.MaxSeriesPerWindow,
+			"max-series-per-window", 0,
+			fmt.Sprintf("Maximum number of distinct series (namespace + tags) emitted by a task within rolling window, might be overridden by task config (%s). 0 means no limit", plugin.MaxSeriesPerWindowConfigKey))
+
+		flagParser.DurationVar(&opt.SeriesWindow,
+			"series-window", defaultSeriesWindow,
+			fmt.Sprintf("Duration of rolling window for -max-series-per-window, might be overridden by task config (%s)", plugin.SeriesWindowConfigKey))
+
 		flagParser.IntVar(&opt.DebugCollectCounts,
 			"debug-collect-counts", defaultCollectCount,
 			"Number of collect requests executed in debug mode (-1 for infinitely)")
@@ -137,13 +183,39 @@ func newFlagParser(name string, pType types.PluginType, opt *plugin.Options) *fl
 			"debug-collect-interval", defaultCollectInterval,
 			"Interval between consecutive collect requests")
 
-		flagParser.StringVar(&opt.PluginConfig,
-			"plugin-config", defaultConfig,
-			"Collector configuration in debug mode")
+		flagParser.StringVar(&opt.DebugOutputFormat,
+			"debug-output-format", defaultDebugOutputFormat,
+			fmt.Sprintf("Format of metrics printed in debug mode (%s). In formats other than text warnings and errors are printed to stderr as JSON lines", strings.Join(debugOutputFormats, ", ")))
+
+		flagParser.StringVar(&opt.DebugOutput,
+			"debug-output", "",
+			"File where metrics are printed in debug mode (stdout when not set)")
 
 		flagParser.StringVar(&opt.PluginFilter,
 			"plugin-filter", defaultFilter,
 			fmt.Sprintf("Default filtering definition (separated by %s)", filterSeparator))
+
+		flagParser.StringVar(&opt.TaskFile,
+			"task-file", "",
+			"Task file (version 2) providing configuration, metrics and schedule interval of tasks run in debug mode (each plugin entry is run as separate task)")
+	}
+
+	if pType == types.PluginTypePublisher {
+		flagParser.StringVar(&opt.DebugInput,
+			"debug-input", "",
+			"File with metrics published in debug mode (stdin when not set or set to '-')")
+
+		flagParser.StringVar(&opt.DebugInputFormat,
+			"debug-input-format", defaultDebugInputFormat,
+			fmt.Sprintf("Format of metrics published in debug mode (%s)", strings.Join(debugInputFormats, ", ")))
+
+		flagParser.IntVar(&opt.DebugPublishBatch,
+			"debug-publish-batch", defaultPublishBatch,
+			"Maximum number of metrics sent in a single publish request in debug mode")
+
+		flagParser.DurationVar(&opt.DebugPublishInterval,
+			"debug-publish-interval", 0,
+			"Interval between consecutive publish requests in debug mode")
 	}
 
 	return flagParser
@@ -215,6 +287,10 @@ func ValidateOptions(opt *plugin.Options) error {
 		opt.DebugCollectInterval = defaultCollectInterval
 	}
 
+	if opt.DebugPublishBatch == 0 {
+		opt.DebugPublishBatch = defaultPublishBatch
+	}
+
 	if opt.PluginConfig == "" {
 		opt.PluginConfig = defaultConfig
 	}
@@ -223,6 +299,16 @@ func ValidateOptions(opt *plugin.Options) error {
 		opt.PluginFilter = defaultFilter
 	}
 
+	if opt.StateDir == "" {
+		opt.StateDir = defaultStateDir
+	}
+
+	stateDir, err := filepath.Abs(opt.StateDir)
+	if err != nil {
+		return fmt.Errorf("invalid directory of persistent state: %v", err)
+	}
+	opt.StateDir = stateDir
+
 	grpcIp := net.ParseIP(opt.PluginIP)
 	if grpcIp == nil {
 		return fmt.Errorf("GRPC IP contains invalid address")
@@ -234,6 +320,26 @@ func ValidateOptions(opt *plugin.Options) error {
 		}
 	}
 
+	if opt.PrintMetricsCatalog != "" && !isValidCatalogFormat(opt.PrintMetricsCatalog) {
+		return fmt.Errorf("invalid format of metrics catalog (%s), allowed: %s", opt.PrintMetricsCatalog, strings.Join(catalogFormats, ", "))
+	}
+
+	if opt.ShutdownTimeout < 0 {
+		return fmt.Errorf("shutdown timeout can't be negative")
+	}
+
+	if opt.CollectTimeout < 0 {
+		return fmt.Errorf("collect timeout can't be negative")
+	}
+
+	if opt.MaxSeriesPerCollect < 0 || opt.MaxSeriesPerWindow < 0 {
+		return fmt.Errorf("series limits can't be negative")
+	}
+
+	if opt.SeriesWindow < 0 {
+		return fmt.Errorf("series window can't be negative")
+	}
+
 	if opt.PProfPort > 0 && !opt.EnableProfiling {
 		return fmt.Errorf("-enable-pprof flag should be set when configuring pprof port")
 	}
@@ -246,6 +352,22 @@ func ValidateOptions(opt *plugin.Options) error {
 		return fmt.Errorf("-enable-stats should be set when -enable-stats-server=1")
 	}
 
+	if opt.DebugOutputFormat != "" && !isValidDebugOutputFormat(opt.DebugOutputFormat) {
+		return fmt.Errorf("invalid debug output format (%s), allowed: %s", opt.DebugOutputFormat, strings.Join(debugOutputFormats, ", "))
+	}
+
+	if opt.DebugInputFormat != "" && !isValidDebugInputFormat(opt.DebugInputFormat) {
+		return fmt.Errorf("invalid debug input format (%s), allowed: %s", opt.DebugInputFormat, strings.Join(debugInputFormats, ", "))
+	}
+
+	if opt.DebugPublishBatch < 0 || opt.DebugPublishInterval < 0 {
+		return fmt.Errorf("debug publish batch and interval can't be negative")
+	}
+
+	if opt.TaskFile != "" && (opt.PluginConfig != defaultConfig || opt.PluginFilter != defaultFilter) {
+		return fmt.Errorf("-task-file can't be used together with -plugin-config or -plugin-filter")
+	}
+
 	if !opt.DebugMode && anyDebugFlagSet(opt) {
 		return fmt.Errorf("-debug-mode flag should be set when configuring debug options")
 	}
@@ -257,5 +379,12 @@ func anyDebugFlagSet(opt *plugin.Options) bool {
 	return opt.DebugCollectCounts != defaultCollectCount ||
 		opt.DebugCollectInterval != defaultCollectInterval ||
 		opt.PluginConfig != defaultConfig ||
-		opt.PluginFilter != defaultFilter
+		opt.PluginFilter != defaultFilter ||
+		(opt.DebugOutputFormat != defaultDebugOutputFormat && opt.DebugOutputFormat != "") ||
+		opt.DebugOutput != "" ||
+		opt.TaskFile != "" ||
+		opt.DebugInput != "" ||
+		(opt.DebugInputFormat != defaultDebugInputFormat && opt.DebugInputFormat != "") ||
+		opt.DebugPublishBatch != defaultPublishBatch ||
+		opt.DebugPublishInterval != 0
 }
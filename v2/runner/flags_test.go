@@ -20,6 +20,8 @@ package runner
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -101,6 +103,46 @@ var parseScenarios = []parseScenario{
 		shouldBeParsed: true,
 		shouldBeValid:  true,
 	},
+	{ // 13
+		inputCmdLine:   "--print-metrics-catalog=markdown",
+		shouldBeParsed: true,
+		shouldBeValid:  true,
+	},
+	{ // 14
+		inputCmdLine:   "--print-metrics-catalog=xml",
+		shouldBeParsed: true,
+		shouldBeValid:  false,
+	},
+	{ // 15
+		inputCmdLine:   "--debug-mode=1 --debug-output-format=prometheus --debug-output=metrics.txt",
+		shouldBeParsed: true,
+		shouldBeValid:  true,
+	},
+	{ // 16
+		inputCmdLine:   "--debug-mode=1 --debug-output-format=xml",
+		shouldBeParsed: true,
+		shouldBeValid:  false,
+	},
+	{ // 17
+		inputCmdLine:   "--debug-output-format=jsonl",
+		shouldBeParsed: true,
+		shouldBeValid:  false,
+	},
+	{ // 18
+		inputCmdLine:   "--debug-mode=1 --task-file=task.yaml",
+		shouldBeParsed: true,
+		shouldBeValid:  true,
+	},
+	{ // 19
+		inputCmdLine:   "--task-file=task.yaml",
+		shouldBeParsed: true,
+		shouldBeValid:  false,
+	},
+	{ // 20
+		inputCmdLine:   "--debug-mode=1 --task-file=task.yaml --plugin-filter=/example/*",
+		shouldBeParsed: true,
+		shouldBeValid:  false,
+	},
 }
 
 func TestParseCmdLineOptions(t *testing.T) {
@@ -136,3 +178,58 @@ func TestParseCmdLineOptions(t *testing.T) {
 		}
 	})
 }
+
+func TestStateDirOption(t *testing.T) {
+	Convey("Validate that directory of persistent state is resolved against working directory", t, func() {
+		wd, _ := os.Getwd()
+
+		Convey("Default directory is located in working directory", func() {
+			opt, err := ParseCmdLineOptions("plugin", types.PluginTypeCollector, nil)
+			So(err, ShouldBeNil)
+
+			So(ValidateOptions(opt), ShouldBeNil)
+			So(opt.StateDir, ShouldEqual, filepath.Join(wd, defaultStateDir))
+		})
+
+		Convey("Relative directory given with flag is made absolute", func() {
+			opt, err := ParseCmdLineOptions("plugin", types.PluginTypeCollector, []string{"--state-dir=state/instance-1"})
+			So(err, ShouldBeNil)
+
+			So(ValidateOptions(opt), ShouldBeNil)
+			So(opt.StateDir, ShouldEqual, filepath.Join(wd, "state", "instance-1"))
+		})
+	})
+}
+
+func TestParsePublisherCmdLineOptions(t *testing.T) {
+	Convey("Validate that publisher debug options can be parsed", t, func() {
+		scenarios := []parseScenario{
+			{inputCmdLine: "--debug-mode=1 --debug-input=metrics.jsonl --debug-publish-batch=10 --debug-publish-interval=1s", shouldBeParsed: true, shouldBeValid: true},
+			{inputCmdLine: "--debug-mode=1 --debug-input-format=influx --plugin-config={}", shouldBeParsed: true, shouldBeValid: true},
+			{inputCmdLine: "--debug-mode=1 --debug-input-format=csv", shouldBeParsed: true, shouldBeValid: false},
+			{inputCmdLine: "--debug-mode=1 --debug-publish-batch=-1", shouldBeParsed: true, shouldBeValid: false},
+			{inputCmdLine: "--debug-input=metrics.jsonl", shouldBeParsed: true, shouldBeValid: false},
+			{inputCmdLine: "--debug-mode=1 --debug-collect-counts=2", shouldBeParsed: false},
+		}
+
+		for i, testCase := range scenarios {
+			Convey(fmt.Sprintf("Scenario %d [%s]", i, testCase.inputCmdLine), func() {
+				// Act
+				opt, err := ParseCmdLineOptions("plugin", types.PluginTypePublisher, strings.Split(testCase.inputCmdLine, " "))
+
+				// Assert
+				if !testCase.shouldBeParsed {
+					So(err, ShouldBeError)
+					return
+				}
+				So(err, ShouldBeNil)
+
+				if testCase.shouldBeValid {
+					So(ValidateOptions(opt), ShouldBeNil)
+				} else {
+					So(ValidateOptions(opt), ShouldBeError)
+				}
+			})
+		}
+	})
+}
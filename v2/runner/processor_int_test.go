@@ -0,0 +1,272 @@
+// +build medium
+
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/common/stats"
+	procProxy "github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/processor/proxy"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/service"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+	"github.com/solarwinds/snap-plugin-lib/v2/pluginrpc"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+type ProcessorMediumSuite struct {
+	suite.Suite
+
+	// grpc server side (processor)
+	endProcessorCh   chan bool
+	startedProcessor plugin.Processor
+
+	// grpc client side (snap)
+	processorGRPCConnection *grpc.ClientConn
+	processorControlClient  pluginrpc.ControllerClient
+	processorClient         pluginrpc.ProcessorClient
+}
+
+func (s *ProcessorMediumSuite) SetupSuite() {
+	logrus.SetLevel(logrus.TraceLevel)
+}
+
+func (s *ProcessorMediumSuite) SetupTest() {
+	s.startedProcessor = nil
+	s.endProcessorCh = make(chan bool, 1)
+}
+
+func (s *ProcessorMediumSuite) startProcessor(processor plugin.Processor) net.Listener {
+	var ln net.Listener
+
+	s.startedProcessor = processor
+	ln, _ = net.Listen("tcp", "127.0.0.1:")
+
+	go func() {
+		statsController := &stats.EmptyController{}
+		contextManager := procProxy.NewContextManager(processor, statsController)
+		service.StartProcessorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0, 0)
+		s.endProcessorCh <- true
+	}()
+
+	return ln
+}
+
+func (s *ProcessorMediumSuite) startProcessorClient(addr string) {
+	s.processorGRPCConnection, _ = grpc.Dial(addr, grpc.WithInsecure())
+
+	s.processorClient = pluginrpc.NewProcessorClient(s.processorGRPCConnection)
+	s.processorControlClient = pluginrpc.NewControllerClient(s.processorGRPCConnection)
+}
+
+func (s *ProcessorMediumSuite) sendLoad(taskID string, configJSON []byte) (*pluginrpc.LoadProcessorResponse, error) {
+	response, err := s.processorClient.Load(context.Background(), &pluginrpc.LoadProcessorRequest{
+		TaskId:     taskID,
+		JsonConfig: configJSON,
+	})
+	return response, err
+}
+
+func (s *ProcessorMediumSuite) sendUnload(taskID string) (*pluginrpc.UnloadProcessorResponse, error) {
+	response, err := s.processorClient.Unload(context.Background(), &pluginrpc.UnloadProcessorRequest{
+		TaskId: taskID,
+	})
+	return response, err
+}
+
+func (s *ProcessorMediumSuite) requestProcess(taskID string, mts []*pluginrpc.Metric) ([]*pluginrpc.Metric, []*pluginrpc.Warning, error) {
+	stream, err := s.processorClient.Process(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// simplified streaming - only one chunk is sent
+	err = stream.Send(&pluginrpc.ProcessRequest{
+		TaskId:    taskID,
+		MetricSet: mts,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = stream.CloseSend()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var recvMts []*pluginrpc.Metric
+	var recvWarnings []*pluginrpc.Warning
+
+	for {
+		partialResponse, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return recvMts, recvWarnings, err
+		}
+
+		recvMts = append(recvMts, partialResponse.MetricSet...)
+		recvWarnings = append(recvWarnings, partialResponse.Warnings...)
+	}
+
+	return recvMts, recvWarnings, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func TestProcessorMedium(t *testing.T) {
+	suite.Run(t, new(ProcessorMediumSuite))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type relabelingProcessor struct {
+	loadCalls    int
+	unloadCalls  int
+	processCalls int
+}
+
+func (p *relabelingProcessor) Load(ctx plugin.Context) error {
+	p.loadCalls++
+
+	env, _ := ctx.ConfigValue("env")
+	ctx.Store("env", env)
+
+	return nil
+}
+
+func (p *relabelingProcessor) Unload(ctx plugin.Context) error {
+	p.unloadCalls++
+
+	return nil
+}
+
+func (p *relabelingProcessor) Process(ctx plugin.ProcessContext) error {
+	p.processCalls++
+
+	env, _ := ctx.Load("env")
+
+	for _, mt := range ctx.ListAllMetrics() {
+		if mt.Namespace().HasElement("debug") {
+			_ = ctx.DropMetric(mt)
+			continue
+		}
+
+		_ = ctx.ModifyMetric(mt, plugin.MetricTag("env", env.(string)), plugin.RemoveMetricTags([]string{"internal"}))
+	}
+
+	_ = ctx.AddMetric("/example/processor/[task=relabel]/processed", ctx.Count())
+	ctx.AddWarning("processing completed")
+
+	return nil
+}
+
+func (s *ProcessorMediumSuite) TestRelabelingProcessor() {
+	// Arrange
+	processor := &relabelingProcessor{}
+
+	ln := s.startProcessor(processor)         // processor server (plugin)
+	s.startProcessorClient(ln.Addr().String()) // processor client (snap)
+
+	inputMts := []*pluginrpc.Metric{
+		{
+			Namespace: []*pluginrpc.Namespace{{Value: "example"}, {Value: "group1"}, {Value: "metric1"}},
+			Value:     &pluginrpc.MetricValue{DataVariant: &pluginrpc.MetricValue_VInt64{VInt64: 11}},
+			Tags:      map[string]string{"k1": "v1", "internal": "true"},
+			Timestamp: &pluginrpc.Time{Sec: 1000},
+		},
+		{
+			Namespace: []*pluginrpc.Namespace{{Value: "example"}, {Value: "debug"}, {Value: "metric2"}},
+			Value:     &pluginrpc.MetricValue{DataVariant: &pluginrpc.MetricValue_VInt64{VInt64: 12}},
+			Timestamp: &pluginrpc.Time{Sec: 1000},
+		},
+		{
+			Namespace: []*pluginrpc.Namespace{{Value: "example"}, {Value: "group1"}, {Value: "metric3"}},
+			Value:     &pluginrpc.MetricValue{DataVariant: &pluginrpc.MetricValue_VInt64{VInt64: 13}},
+			Timestamp: &pluginrpc.Time{Sec: 1000},
+		},
+	}
+
+	Convey("Test that processor can list, add, modify and drop metrics", s.T(), func() {
+
+		Convey("Loading task for processor", func() {
+			_, err := s.sendLoad("task-processor-1", []byte(`{"env": "prod"}`))
+			So(err, ShouldBeNil)
+
+			So(processor.loadCalls, ShouldEqual, 1)
+			So(processor.processCalls, ShouldEqual, 0)
+		})
+
+		Convey("Processor returns modified set of metrics", func() {
+			mts, warnings, err := s.requestProcess("task-processor-1", inputMts)
+			So(err, ShouldBeNil)
+			So(processor.processCalls, ShouldEqual, 1)
+
+			So(len(mts), ShouldEqual, 3)
+
+			So(mts[0].Namespace[2].Value, ShouldEqual, "metric1")
+			So(mts[0].Value.GetVInt64(), ShouldEqual, 11)
+			So(mts[0].Tags, ShouldResemble, map[string]string{"k1": "v1", "env": "prod"})
+
+			So(mts[1].Namespace[2].Value, ShouldEqual, "metric3")
+			So(mts[1].Tags, ShouldResemble, map[string]string{"env": "prod"})
+
+			So(len(mts[2].Namespace), ShouldEqual, 4)
+			So(mts[2].Namespace[2].Name, ShouldEqual, "task")
+			So(mts[2].Namespace[2].Value, ShouldEqual, "relabel")
+			So(mts[2].Value.GetVInt64(), ShouldEqual, 2)
+
+			So(len(warnings), ShouldEqual, 1)
+			So(warnings[0].Message, ShouldEqual, "processing completed")
+		})
+
+		Convey("Processing request for unknown task ends with error", func() {
+			_, _, err := s.requestProcess("task-processor-2", inputMts)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Unloading task from processor", func() {
+			_, err := s.sendUnload("task-processor-1")
+			So(err, ShouldBeNil)
+
+			So(processor.unloadCalls, ShouldEqual, 1)
+		})
+
+		Convey("Sending kill request for processor", func() {
+			_, err := s.processorControlClient.Kill(context.Background(), &pluginrpc.KillRequest{})
+			So(err, ShouldBeNil)
+
+			select {
+			case <-s.endProcessorCh:
+			// ok
+			case <-time.After(10 * time.Second):
+				s.T().Fatal("plugin should have been ended")
+			}
+		})
+	})
+}
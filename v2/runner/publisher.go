@@ -18,7 +18,10 @@ package runner
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -26,6 +29,8 @@ import (
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/plugins/publisher/proxy"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/service"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/log"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/secrets"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/statestore"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
 )
@@ -49,6 +54,7 @@ func StartPublisherWithContext(ctx context.Context, publisher plugin.Publisher,
 	}
 
 	logF := logger(ctx).WithField("service", "publisher")
+	secrets.AddLogHook(log.WithCtx(ctx)) // redact secret values (task configuration) from logs
 
 	if opt == nil {
 		opt, err = ParseCmdLineOptions(os.Args[0], types.PluginTypePublisher, os.Args[1:])
@@ -72,6 +78,8 @@ func StartPublisherWithContext(ctx context.Context, publisher plugin.Publisher,
 	defer statsController.Close()
 
 	ctxMan := proxy.NewContextManager(publisher, statsController)
+	statsController.RedactPluginInfo(ctxMan.IsSecretPath) // secret fields are declared in plugin definition
+	ctxMan.StateStore = statestore.NewStore(opt.StateDir, name)
 
 	logrus.SetLevel(opt.LogLevel)
 
@@ -81,20 +89,25 @@ func StartPublisherWithContext(ctx context.Context, publisher plugin.Publisher,
 	}
 
 	if opt.PrintExampleTask {
-		printExampleTask(ctxMan.ExampleConfig, name, types.PluginTypePublisher)
+		printExampleTask(ctxMan.ExampleConfig, ctxMan.ConfigSchema, name, types.PluginTypePublisher)
 		os.Exit(normalExitStatus)
 	}
 
-	r, err := acquireResources(opt)
+	if opt.PrintConfigSchema {
+		printConfigSchema(ctxMan.ConfigSchema)
+		os.Exit(normalExitStatus)
+	}
+
+	err = ctxMan.Init(ctx)
 	if err != nil {
-		logF.WithError(err).Error("Can't acquire resources for plugin services")
+		logF.WithError(err).Error("Can't initialize plugin")
 		os.Exit(errorExitStatus)
 	}
 
-	jsonMeta := metaInformation(ctx, name, version, types.PluginTypePublisher, opt, r, ctxMan.TasksLimit, ctxMan.InstancesLimit)
-	if inProc {
-		inprocPlugin.MetaChannel() <- jsonMeta
-		close(inprocPlugin.MetaChannel())
+	r, err := acquireResources(opt)
+	if err != nil {
+		logF.WithError(err).Error("Can't acquire resources for plugin services")
+		os.Exit(errorExitStatus)
 	}
 
 	if opt.EnableProfiling {
@@ -103,10 +116,21 @@ func StartPublisherWithContext(ctx context.Context, publisher plugin.Publisher,
 	}
 
 	if opt.EnableStatsServer {
-		startStatsServer(ctx, r.statsListener, statsController)
+		startStatsServer(ctx, r.statsListener, statsController, ctxMan)
 		defer r.statsListener.Close() // close stats service when GRPC service has been shut down
 	}
 
+	if opt.DebugMode {
+		startPublisherInDebugMode(ctxMan, opt)
+		return
+	}
+
+	jsonMeta := metaInformation(ctx, name, version, types.PluginTypePublisher, opt, r, ctxMan.TasksLimit, ctxMan.InstancesLimit)
+	if inProc {
+		inprocPlugin.MetaChannel() <- jsonMeta
+		close(inprocPlugin.MetaChannel())
+	}
+
 	srv, err := service.NewGRPCServer(ctx, opt)
 	if err != nil {
 		logF.WithError(err).Error("Can't initialize GRPC Server")
@@ -119,5 +143,74 @@ func StartPublisherWithContext(ctx context.Context, publisher plugin.Publisher,
 	}
 
 	// main blocking operation
-	service.StartPublisherGRPC(ctx, srv, ctxMan, r.grpcListener, opt.GRPCPingTimeout, opt.GRPCPingMaxMissed)
+	service.StartPublisherGRPC(ctx, srv, ctxMan, r.grpcListener, opt.GRPCPingTimeout, opt.GRPCPingMaxMissed, opt.ShutdownTimeout)
+}
+
+func startPublisherInDebugMode(ctxManager *proxy.ContextManager, opt *plugin.Options) {
+	const debugModeTaskID = "task-1"
+
+	input, errInput := openDebugInput(opt.DebugInputFormat, opt.DebugInput)
+	if errInput != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Couldn't open input in a standalone mode (reason: %v)\n", errInput)
+		os.Exit(errorExitStatus)
+	}
+	defer input.Close()
+
+	errLoad := ctxManager.LoadTask(debugModeTaskID, []byte(opt.PluginConfig))
+	if errLoad != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Couldn't load a task in a standalone mode (reason: %v)\n", errLoad)
+		os.Exit(errorExitStatus)
+	}
+
+	failedCount := 0
+	for batchCount := 0; ; {
+		mts, events, errRead := input.ReadBatch(opt.DebugPublishBatch)
+		if errRead != nil && errRead != io.EOF {
+			_, _ = fmt.Fprintf(os.Stderr, "Couldn't read metrics in a standalone mode (reason: %v)\n", errRead)
+			os.Exit(errorExitStatus)
+		}
+
+		if len(mts) != 0 || len(events) != 0 {
+			batchCount++
+
+			// Request publishing and print out results
+			startTime := time.Now()
+			status := ctxManager.RequestPublish(debugModeTaskID, mts, events)
+			fmt.Printf("Published batch %d (metrics=%d, events=%d) in %s\n", batchCount, len(mts), len(events), time.Since(startTime))
+
+			if len(status.Warnings) != 0 {
+				fmt.Printf("Publishing warnings (length=%d): \n", len(status.Warnings))
+				for _, w := range status.Warnings {
+					fmt.Printf("%s\n", secrets.Redact(w.Message))
+				}
+			}
+
+			if status.Error != nil {
+				failedCount++
+				fmt.Printf("Publishing error: %v\n", secrets.RedactError(status.Error))
+			}
+			fmt.Printf("\n")
+		}
+
+		if errRead == io.EOF {
+			break
+		}
+
+		time.Sleep(opt.DebugPublishInterval)
+	}
+
+	errUnload := ctxManager.UnloadTask(debugModeTaskID)
+	if errUnload != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Couldn't unload a task in a standalone mode (reason: %v)\n", errUnload)
+		os.Exit(errorExitStatus)
+	}
+
+	shutdownCtx, cancelFn := context.WithTimeout(context.Background(), opt.ShutdownTimeout)
+	defer cancelFn()
+	ctxManager.Shutdown(shutdownCtx) // no tasks left, only plugin shutdown hook is called
+
+	if failedCount != 0 {
+		_, _ = fmt.Fprintf(os.Stderr, "Publishing has failed for %d batch(es) in a standalone mode\n", failedCount)
+		os.Exit(errorExitStatus)
+	}
 }
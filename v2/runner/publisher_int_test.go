@@ -22,7 +22,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
+	"os"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -84,7 +88,7 @@ func (s *PublisherMediumSuite) startCollector(collector plugin.Collector) net.Li
 	go func() {
 		statsController, _ := stats.NewEmptyController()
 		contextManager := collProxy.NewContextManager(context.Background(), types.NewCollector("test-collector", "1.0.0", collector), statsController)
-		service.StartCollectorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0)
+		service.StartCollectorGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0, 0)
 		s.endControllerCh <- true
 	}()
 
@@ -100,7 +104,7 @@ func (s *PublisherMediumSuite) startPublisher(publisher plugin.Publisher) net.Li
 	go func() {
 		statsController := &stats.EmptyController{}
 		contextManager := pubProxy.NewContextManager(publisher, statsController)
-		service.StartPublisherGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0)
+		service.StartPublisherGRPC(context.Background(), grpc.NewServer(), contextManager, ln, 0, 0, 0)
 		s.endPublisherCh <- true
 	}()
 
@@ -175,12 +179,12 @@ func (s *PublisherMediumSuite) sendPublisherUnload(taskID string) (*pluginrpc.Un
 }
 
 func (s *PublisherMediumSuite) requestCollectPublishCycle(collectTaskID, publishTaskID string) error {
-	mts, err := s.requestCollect(collectTaskID)
+	mts, events, err := s.requestCollect(collectTaskID)
 	if err != nil {
 		return fmt.Errorf("error when requesting collect from plugin: %v", err)
 	}
 
-	err = s.requestPublish(publishTaskID, mts)
+	err = s.requestPublish(publishTaskID, mts, events)
 	if err != nil {
 		return fmt.Errorf("error when requesting publish from plugin: %v", err)
 	}
@@ -188,15 +192,16 @@ func (s *PublisherMediumSuite) requestCollectPublishCycle(collectTaskID, publish
 	return nil
 }
 
-func (s *PublisherMediumSuite) requestCollect(collectTaskID string) ([]*pluginrpc.Metric, error) {
+func (s *PublisherMediumSuite) requestCollect(collectTaskID string) ([]*pluginrpc.Metric, []*pluginrpc.Event, error) {
 	stream, err := s.collectorClient.Collect(context.Background(), &pluginrpc.CollectRequest{
 		TaskId: collectTaskID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var mts []*pluginrpc.Metric
+	var events []*pluginrpc.Event
 
 	for {
 		partialResponse, err := stream.Recv()
@@ -204,16 +209,17 @@ func (s *PublisherMediumSuite) requestCollect(collectTaskID string) ([]*pluginrp
 			break
 		}
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		mts = append(mts, partialResponse.MetricSet...)
+		events = append(events, partialResponse.Events...)
 	}
 
-	return mts, nil
+	return mts, events, nil
 }
 
-func (s *PublisherMediumSuite) requestPublish(publishTaskID string, mts []*pluginrpc.Metric) error {
+func (s *PublisherMediumSuite) requestPublish(publishTaskID string, mts []*pluginrpc.Metric, events []*pluginrpc.Event) error {
 	stream, err := s.publisherClient.Publish(context.Background())
 	if err != nil {
 		return nil
@@ -223,6 +229,7 @@ func (s *PublisherMediumSuite) requestPublish(publishTaskID string, mts []*plugi
 	err = stream.Send(&pluginrpc.PublishRequest{
 		TaskId:    publishTaskID,
 		MetricSet: mts,
+		Events:    events,
 	})
 	if err != nil {
 		return err
@@ -634,3 +641,255 @@ func (s *PublisherMediumSuite) TestSimplePublisher() {
 		})
 	})
 }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type bufferedPublisher struct {
+	bufferDir        string
+	failing          int32
+	failingUnload    int32
+	publishCalls     int32
+	publishedMetrics int32
+}
+
+func (p *bufferedPublisher) PluginDefinition(def plugin.PublisherDefinition) error {
+	return def.DefineDiskBuffer(p.bufferDir, plugin.DiskBufferRetryInterval(50*time.Millisecond, 100*time.Millisecond))
+}
+
+func (p *bufferedPublisher) Publish(ctx plugin.PublishContext) error {
+	atomic.AddInt32(&p.publishCalls, 1)
+
+	if atomic.LoadInt32(&p.failing) == 1 {
+		return fmt.Errorf("destination is not available")
+	}
+
+	atomic.AddInt32(&p.publishedMetrics, int32(ctx.Count()))
+	return nil
+}
+
+func (p *bufferedPublisher) Unload(_ plugin.Context) error {
+	if atomic.LoadInt32(&p.failingUnload) == 1 {
+		return fmt.Errorf("resources can't be released")
+	}
+
+	return nil
+}
+
+func (s *PublisherMediumSuite) TestBufferedPublisher() {
+	// Arrange
+	bufferDir, _ := ioutil.TempDir("", "publisher-buffer")
+	defer os.RemoveAll(bufferDir)
+
+	collector := &oneMetricCollector{}
+	publisher := &bufferedPublisher{bufferDir: bufferDir, failing: 1}
+
+	lnColl := s.startCollector(collector)
+	lnPub := s.startPublisher(publisher)
+
+	s.startCollectorClient(lnColl.Addr().String())
+	s.startPublisherClient(lnPub.Addr().String())
+
+	Convey("Validate that metrics are buffered on disk until publisher is able to send them", s.T(), func() {
+		_, err := s.sendCollectorLoad("task-collector-1", []byte("{}"), []string{})
+		So(err, ShouldBeNil)
+
+		_, err = s.sendPublisherLoad("task-publisher-1", []byte("{}"))
+		So(err, ShouldBeNil)
+
+		// Act - publish requests are acknowledged even if destination is not available
+		for i := 0; i < 3; i++ {
+			err := s.requestCollectPublishCycle("task-collector-1", "task-publisher-1")
+			So(err, ShouldBeNil)
+		}
+
+		time.Sleep(300 * time.Millisecond)
+
+		// Assert - publishing is retried in background
+		So(atomic.LoadInt32(&publisher.publishCalls), ShouldBeGreaterThan, 1)
+		So(atomic.LoadInt32(&publisher.publishedMetrics), ShouldEqual, 0)
+
+		// Act - destination is available again
+		atomic.StoreInt32(&publisher.failing, 0)
+
+		// Assert - all buffered metrics are published
+		deadline := time.Now().Add(3 * time.Second)
+		for atomic.LoadInt32(&publisher.publishedMetrics) < 3 && time.Now().Before(deadline) {
+			time.Sleep(50 * time.Millisecond)
+		}
+		So(atomic.LoadInt32(&publisher.publishedMetrics), ShouldEqual, 3)
+
+		// Act - task stays loaded when user-defined Unload fails
+		atomic.StoreInt32(&publisher.failingUnload, 1)
+		atomic.StoreInt32(&publisher.failing, 1)
+
+		_, err = s.sendPublisherUnload("task-publisher-1")
+		So(err, ShouldNotBeNil)
+
+		// Assert - metrics are still buffered
+		err = s.requestCollectPublishCycle("task-collector-1", "task-publisher-1")
+		So(err, ShouldBeNil)
+
+		atomic.StoreInt32(&publisher.failing, 0)
+		deadline = time.Now().Add(3 * time.Second)
+		for atomic.LoadInt32(&publisher.publishedMetrics) < 4 && time.Now().Before(deadline) {
+			time.Sleep(50 * time.Millisecond)
+		}
+		So(atomic.LoadInt32(&publisher.publishedMetrics), ShouldEqual, 4)
+
+		atomic.StoreInt32(&publisher.failingUnload, 0)
+		_, err = s.sendPublisherUnload("task-publisher-1")
+		So(err, ShouldBeNil)
+
+		_, err = s.sendCollectorUnload("task-collector-1")
+		So(err, ShouldBeNil)
+	})
+}
+
+// Publisher which keeps unguarded state (requests for the same task are never processed concurrently)
+type racingBufferedPublisher struct {
+	bufferDir string
+	failing   int32
+	published int32
+
+	calls    int // modified by Publish and Reconfigure
+	panicked bool
+}
+
+func (p *racingBufferedPublisher) PluginDefinition(def plugin.PublisherDefinition) error {
+	return def.DefineDiskBuffer(p.bufferDir, plugin.DiskBufferRetryInterval(10*time.Millisecond, 10*time.Millisecond))
+}
+
+func (p *racingBufferedPublisher) Publish(ctx plugin.PublishContext) error {
+	p.calls++
+
+	if !p.panicked {
+		p.panicked = true
+		panic("destination client is not initialized")
+	}
+	if atomic.LoadInt32(&p.failing) == 1 {
+		return fmt.Errorf("destination is not available")
+	}
+
+	atomic.AddInt32(&p.published, int32(ctx.Count()))
+	return nil
+}
+
+func (p *racingBufferedPublisher) Reconfigure(_ plugin.Context, _ plugin.ConfigChange) error {
+	p.calls++
+	return nil
+}
+
+func (s *PublisherMediumSuite) TestBufferedPublisherIsNotCalledConcurrently() {
+	// Arrange
+	bufferDir, _ := ioutil.TempDir("", "publisher-buffer")
+	defer os.RemoveAll(bufferDir)
+
+	collector := &oneMetricCollector{}
+	publisher := &racingBufferedPublisher{bufferDir: bufferDir, failing: 1}
+
+	lnColl := s.startCollector(collector)
+	lnPub := s.startPublisher(publisher)
+
+	s.startCollectorClient(lnColl.Addr().String())
+	s.startPublisherClient(lnPub.Addr().String())
+
+	Convey("Validate that buffered metrics are published neither concurrently with other requests nor after panic", s.T(), func() {
+		_, err := s.sendCollectorLoad("task-collector-1", []byte("{}"), []string{})
+		So(err, ShouldBeNil)
+
+		_, err = s.sendPublisherLoad("task-publisher-1", []byte("{}"))
+		So(err, ShouldBeNil)
+
+		err = s.requestCollectPublishCycle("task-collector-1", "task-publisher-1")
+		So(err, ShouldBeNil)
+
+		// Act - publishing is retried while task is reconfigured
+		for i := 0; i < 20; i++ {
+			_, err := s.publisherClient.Reconfigure(context.Background(), &pluginrpc.ReconfigurePublisherRequest{
+				TaskId:     "task-publisher-1",
+				JsonConfig: []byte("{}"),
+			})
+			So(err, ShouldBeNil)
+			time.Sleep(5 * time.Millisecond)
+		}
+
+		atomic.StoreInt32(&publisher.failing, 0)
+
+		// Assert - metrics are published after panic and failed attempts
+		deadline := time.Now().Add(3 * time.Second)
+		for atomic.LoadInt32(&publisher.published) < 1 && time.Now().Before(deadline) {
+			time.Sleep(50 * time.Millisecond)
+		}
+		So(atomic.LoadInt32(&publisher.published), ShouldEqual, 1)
+
+		_, err = s.sendPublisherUnload("task-publisher-1")
+		So(err, ShouldBeNil)
+
+		_, err = s.sendCollectorUnload("task-collector-1")
+		So(err, ShouldBeNil)
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type eventCollector struct{}
+
+func (c *eventCollector) Collect(ctx plugin.CollectContext) error {
+	_ = ctx.AddMetric("/example/group1/metric1", 1)
+	return ctx.AddEvent("Service restarted", "nginx has been restarted", plugin.EventSeverityWarning,
+		plugin.EventTag("service", "nginx"), plugin.EventTimestamp(time.Unix(1600000000, 0)))
+}
+
+type eventPublisher struct {
+	mutex  sync.Mutex
+	events []plugin.Event
+}
+
+func (p *eventPublisher) Publish(ctx plugin.PublishContext) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.events = append(p.events, ctx.ListAllEvents()...)
+	return nil
+}
+
+func (s *PublisherMediumSuite) TestEvents() {
+	// Arrange
+	collector := &eventCollector{}
+	publisher := &eventPublisher{}
+
+	lnColl := s.startCollector(collector)
+	lnPub := s.startPublisher(publisher)
+
+	s.startCollectorClient(lnColl.Addr().String())
+	s.startPublisherClient(lnPub.Addr().String())
+
+	Convey("Validate that events added by collector are available for publisher", s.T(), func() {
+		_, err := s.sendCollectorLoad("task-collector-1", []byte("{}"), []string{})
+		So(err, ShouldBeNil)
+
+		_, err = s.sendPublisherLoad("task-publisher-1", []byte("{}"))
+		So(err, ShouldBeNil)
+
+		// Act
+		err = s.requestCollectPublishCycle("task-collector-1", "task-publisher-1")
+		So(err, ShouldBeNil)
+
+		// Assert
+		publisher.mutex.Lock()
+		defer publisher.mutex.Unlock()
+
+		So(len(publisher.events), ShouldEqual, 1)
+		So(publisher.events[0].Title(), ShouldEqual, "Service restarted")
+		So(publisher.events[0].Text(), ShouldEqual, "nginx has been restarted")
+		So(publisher.events[0].Severity(), ShouldEqual, plugin.EventSeverityWarning)
+		So(publisher.events[0].Tags(), ShouldResemble, map[string]string{"service": "nginx"})
+		So(publisher.events[0].Timestamp().Unix(), ShouldEqual, 1600000000)
+
+		_, err = s.sendPublisherUnload("task-publisher-1")
+		So(err, ShouldBeNil)
+
+		_, err = s.sendCollectorUnload("task-collector-1")
+		So(err, ShouldBeNil)
+	})
+}
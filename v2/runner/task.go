@@ -17,8 +17,11 @@
 package runner
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/configschema"
 	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
 	"gopkg.in/yaml.v3"
 )
@@ -32,32 +35,74 @@ schedule:
     interval: "0 * * * * *"
 plugins:
   - plugin_name: %s
-	# plugin_binary:
+    # plugin_binary:
  
-	# config:
+%s
+    # metrics:
 
-	# metrics:
+    publish:
+      - plugin_name: %s
+%s`
+
+const processorTemplate = `
+# THIS IS GENERIC EXAMPLE TASK TEMPLATE
+---
+version: 2
+schedule:
+    type: cron
+    interval: "0 * * * * *"
+plugins:
+  - plugin_name: %s
+    # plugin_binary:
+
+    # config:
+
+    # metrics:
 
+    process:
+      - plugin_name: %s
+%s
     publish:
       - plugin_name: %s
 `
 
-func printExampleTask(exampleConfig yaml.Node, pluginName string, pluginType types.PluginType) {
+const (
+	commentedConfig = "    # config:\n"
+
+	pluginIndent     = "    "     // indentation of plugin section (collector)
+	subPluginIndent  = "        " // indentation of process/publish sections
+	configYAMLIndent = 4
+)
+
+func printExampleTask(exampleConfig yaml.Node, configSchema *configschema.Schema, pluginName string, pluginType types.PluginType) {
+	task, err := exampleTask(exampleConfig, configSchema, pluginName, pluginType)
+	if err != nil {
+		fmt.Printf("Error: can't print task information (%v)", err)
+	}
+
+	fmt.Print(task)
+}
+
+// Example task file (readable by -debug-task-file) containing given plugin
+func exampleTask(exampleConfig yaml.Node, configSchema *configschema.Schema, pluginName string, pluginType types.PluginType) (string, error) {
 	var b []byte
 	var err error
 
 	if len(exampleConfig.Content) != 0 {
 		b, err = yaml.Marshal(&exampleConfig)
 	} else {
-		var filledTemplate string
+		var filledTemplate, cfg string
 
 		switch pluginType {
-		case types.PluginTypeCollector:
-			filledTemplate = fmt.Sprintf(template, pluginName, "publisher-appoptics")
-		case types.PluginTypeStreamingCollector:
-			filledTemplate = fmt.Sprintf(template, pluginName, "publisher-appoptics")
+		case types.PluginTypeCollector, types.PluginTypeStreamingCollector:
+			cfg, err = configSection(configSchema, pluginIndent, commentedConfig)
+			filledTemplate = fmt.Sprintf(template, pluginName, cfg, "publisher-appoptics", "")
 		case types.PluginTypePublisher:
-			filledTemplate = fmt.Sprintf(template, "collector-name", pluginName)
+			cfg, err = configSection(configSchema, subPluginIndent, "")
+			filledTemplate = fmt.Sprintf(template, "collector-name", commentedConfig, pluginName, cfg)
+		case types.PluginTypeProcessor:
+			cfg, err = configSection(configSchema, subPluginIndent, subPluginIndent+"# config:\n")
+			filledTemplate = fmt.Sprintf(processorTemplate, "collector-name", pluginName, cfg, "publisher-appoptics")
 		default:
 			err = fmt.Errorf("invalid plugin type")
 		}
@@ -65,9 +110,46 @@ func printExampleTask(exampleConfig yaml.Node, pluginName string, pluginType typ
 		b = []byte(filledTemplate)
 	}
 
+	return fmt.Sprintf("---\n%s\n", string(b)), err
+}
+
+// Build config section (of task) based on fields declared by plugin.
+// When plugin doesn't declare any field, defaultSection is returned.
+func configSection(configSchema *configschema.Schema, indent string, defaultSection string) (string, error) {
+	if configSchema == nil || configSchema.IsEmpty() {
+		return defaultSection, nil
+	}
+
+	cfgNode, err := configSchema.ExampleConfig()
 	if err != nil {
-		fmt.Printf("Error: can't print task information (%v)", err)
+		return defaultSection, err
+	}
+
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(configYAMLIndent)
+
+	err = enc.Encode(cfgNode)
+	if err != nil {
+		return defaultSection, err
+	}
+
+	var sb strings.Builder
+	sb.WriteString(indent + "config:\n")
+	for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		sb.WriteString(indent + strings.Repeat(" ", configYAMLIndent) + line + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+// Print declared configuration fields as JSON Schema
+func printConfigSchema(configSchema *configschema.Schema) {
+	b, err := configSchema.JSONSchema()
+	if err != nil {
+		fmt.Printf("Error: can't print config schema (%v)", err)
+		return
 	}
 
-	fmt.Printf("---\n%s\n", string(b))
+	fmt.Printf("%s\n", string(b))
 }
@@ -0,0 +1,154 @@
+/*
+ Copyright (c) 2021 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	taskManifestVersion = 2
+
+	scheduleTypeSimple = "simple"
+)
+
+// Task manifest (version 2), as printed with -print-example-task
+type taskManifest struct {
+	Version  int                `yaml:"version"`
+	Schedule taskSchedule       `yaml:"schedule"`
+	Plugins  []taskPluginConfig `yaml:"plugins"`
+}
+
+type taskSchedule struct {
+	Type     string `yaml:"type"`
+	Interval string `yaml:"interval"`
+}
+
+type taskPluginConfig struct {
+	PluginName string      `yaml:"plugin_name"`
+	Name       string      `yaml:"name"` // alternative to plugin_name
+	Config     interface{} `yaml:"config"`
+	Metrics    []string    `yaml:"metrics"`
+}
+
+// Task executed in debug mode
+type debugTask struct {
+	id     string
+	config []byte
+	filter []string
+}
+
+// Definition of tasks executed in debug mode based on task manifest
+type debugTaskFile struct {
+	tasks    []debugTask
+	interval time.Duration // 0 when manifest doesn't define simple schedule
+}
+
+// Read task manifest and extract tasks for a given plugin (each plugin entry is run as separate task)
+func loadTaskFile(path string, pluginName string) (debugTaskFile, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return debugTaskFile{}, fmt.Errorf("can't read task file: %w", err)
+	}
+
+	return parseTaskFile(content, pluginName)
+}
+
+func parseTaskFile(content []byte, pluginName string) (debugTaskFile, error) {
+	manifest, err := decodeTaskManifest(content)
+	if err != nil {
+		return debugTaskFile{}, fmt.Errorf("can't parse task file: %w", err)
+	}
+
+	if manifest.Version != taskManifestVersion {
+		return debugTaskFile{}, fmt.Errorf("unsupported version of task file (%d), expected: %d", manifest.Version, taskManifestVersion)
+	}
+
+	tf := debugTaskFile{}
+
+	if manifest.Schedule.Type == scheduleTypeSimple || (manifest.Schedule.Type == "" && manifest.Schedule.Interval != "") {
+		tf.interval, err = time.ParseDuration(manifest.Schedule.Interval)
+		if err != nil {
+			return debugTaskFile{}, fmt.Errorf("invalid schedule interval (%s): %w", manifest.Schedule.Interval, err)
+		}
+		if tf.interval <= 0 {
+			return debugTaskFile{}, fmt.Errorf("schedule interval should be positive (%s)", manifest.Schedule.Interval)
+		}
+	}
+
+	var otherPlugins []string
+	for _, p := range manifest.Plugins {
+		name := p.PluginName
+		if name == "" {
+			name = p.Name
+		}
+
+		if name != "" && name != pluginName {
+			otherPlugins = append(otherPlugins, name)
+			continue
+		}
+
+		cfg := []byte(defaultConfig)
+		if p.Config != nil {
+			cfg, err = json.Marshal(p.Config)
+			if err != nil {
+				return debugTaskFile{}, fmt.Errorf("invalid configuration of plugin entry %d: %w", len(tf.tasks)+1, err)
+			}
+		}
+
+		tf.tasks = append(tf.tasks, debugTask{
+			id:     fmt.Sprintf("task-%d", len(tf.tasks)+1),
+			config: cfg,
+			filter: p.Metrics,
+		})
+	}
+
+	if len(tf.tasks) == 0 {
+		return debugTaskFile{}, fmt.Errorf("task file doesn't contain entries for plugin %s (found: %s)", pluginName, strings.Join(otherPlugins, ", "))
+	}
+
+	return tf, nil
+}
+
+// Decode the first non-empty document (output of -print-example-task starts with document containing only comments)
+func decodeTaskManifest(content []byte) (taskManifest, error) {
+	manifest := taskManifest{}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		doc := yaml.Node{}
+		err := decoder.Decode(&doc)
+		if err == io.EOF {
+			return manifest, nil
+		}
+		if err != nil {
+			return manifest, err
+		}
+
+		if len(doc.Content) != 0 && doc.Content[0].Kind != yaml.ScalarNode { // document with comments only is null scalar
+			return manifest, doc.Decode(&manifest)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// +build small
+
+/*
+ Copyright (c) 2020 SolarWinds Worldwide, LLC
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+      http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+package runner
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gopkg.in/yaml.v3"
+
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/configschema"
+	"github.com/solarwinds/snap-plugin-lib/v2/internal/util/types"
+	"github.com/solarwinds/snap-plugin-lib/v2/plugin"
+)
+
+const exampleTaskFile = `
+---
+version: 2
+schedule:
+    type: simple
+    interval: 30s
+plugins:
+  - plugin_name: example
+    metrics:
+      - /example/date/day
+      - /example/time/*
+    config:
+        format: short
+        options:
+          - zone: UTC
+    publish:
+      - plugin_name: publisher-appoptics
+  - name: example
+  - plugin_name: other
+`
+
+func TestParseTaskFile(t *testing.T) {
+	Convey("Validate that tasks can be extracted from task file", t, func() {
+		Convey("Plugin entries are run as separate tasks", func() {
+			// Act
+			tf, err := parseTaskFile([]byte(exampleTaskFile), "example")
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(tf.interval, ShouldEqual, 30*time.Second)
+			So(tf.tasks, ShouldHaveLength, 2)
+
+			So(tf.tasks[0].id, ShouldEqual, "task-1")
+			So(string(tf.tasks[0].config), ShouldEqual, `{"format":"short","options":[{"zone":"UTC"}]}`)
+			So(tf.tasks[0].filter, ShouldResemble, []string{"/example/date/day", "/example/time/*"})
+
+			So(tf.tasks[1].id, ShouldEqual, "task-2")
+			So(string(tf.tasks[1].config), ShouldEqual, "{}")
+			So(tf.tasks[1].filter, ShouldBeEmpty)
+		})
+
+		Convey("Cron schedule doesn't define interval", func() {
+			// Act
+			tf, err := parseTaskFile([]byte("version: 2\nschedule:\n  type: cron\n  interval: \"0 * * * * *\"\nplugins:\n  - plugin_name: example\n"), "example")
+
+			// Assert
+			So(err, ShouldBeNil)
+			So(tf.interval, ShouldEqual, 0)
+			So(tf.tasks, ShouldHaveLength, 1)
+		})
+
+		Convey("Example task printed by plugin can be read back", func() {
+			schema := configschema.NewSchema()
+			So(schema.DefineField("server.port", plugin.ConfigTypeInt, plugin.ConfigDefault(8080)), ShouldBeNil)
+
+			// task file is used only by collectors (other plugins are placed within collector entry)
+			collectorNames := map[types.PluginType]string{
+				types.PluginTypeCollector: "example",
+				types.PluginTypePublisher: "collector-name",
+				types.PluginTypeProcessor: "collector-name",
+			}
+
+			for pluginType, collectorName := range collectorNames {
+				for _, cfgSchema := range []*configschema.Schema{configschema.NewSchema(), schema} {
+					// Arrange
+					task, err := exampleTask(yaml.Node{}, cfgSchema, "example", pluginType)
+					So(err, ShouldBeNil)
+
+					// Act
+					tf, err := parseTaskFile([]byte(task), collectorName)
+
+					// Assert
+					So(err, ShouldBeNil)
+					So(tf.tasks, ShouldHaveLength, 1)
+				}
+			}
+		})
+
+		Convey("Invalid task files are reported", func() {
+			invalidTaskFiles := []string{
+				"version: 1\nplugins:\n  - plugin_name: example\n",
+				"version: 2\nschedule:\n  type: simple\n  interval: often\nplugins:\n  - plugin_name: example\n",
+				"version: 2\nplugins:\n  - plugin_name: other\n",
+				"version: 2\nplugins: [",
+			}
+
+			for _, content := range invalidTaskFiles {
+				// Act
+				_, err := parseTaskFile([]byte(content), "example")
+
+				// Assert
+				So(err, ShouldBeError)
+			}
+		})
+	})
+}
@@ -52,6 +52,7 @@ type Options struct {
 	PluginIP           string
 	CollectorPort      int
 	PublisherPort      int
+	ProcessorPort      int
 	CollectInterval    time.Duration
 	PingInterval       time.Duration
 	MaxCollectRequests int
@@ -75,6 +76,7 @@ const (
 
 type collectChunk struct {
 	mts      []*pluginrpc.Metric
+	events   []*pluginrpc.Event
 	warnings []string
 	err      error
 }
@@ -96,6 +98,10 @@ func parseCmdLine() *Options {
 		"publisher-port", defaultGRPCPort,
 		"Port of GRPC Server run by publisher plugin")
 
+	flag.IntVar(&opt.ProcessorPort,
+		"processor-port", defaultGRPCPort,
+		"Port of GRPC Server run by processor plugin")
+
 	flag.StringVar(&opt.TaskId,
 		"task-id", defaultTaskID,
 		"Task identifier used to make GRPC requests ('' means random)")
@@ -156,6 +162,10 @@ func main() {
 	if opt.PublisherPort != defaultGRPCPort {
 		usePublisher = true
 	}
+	useProcessor := false
+	if opt.ProcessorPort != defaultGRPCPort {
+		useProcessor = true
+	}
 	// Create connection
 	grpcServerCollAddr := fmt.Sprintf("%s:%d", opt.PluginIP, opt.CollectorPort)
 	clColl, err := grpc.Dial(grpcServerCollAddr, grpc.WithInsecure())
@@ -175,11 +185,23 @@ func main() {
 		}
 		defer func() { _ = clPub.Close() }()
 	}
-	// Load, collect, publish, unload routine
+
+	var clProc *grpc.ClientConn
+	if useProcessor {
+		grpcServerProcAddr := fmt.Sprintf("%s:%d", opt.PluginIP, opt.ProcessorPort)
+		clProc, err = grpc.Dial(grpcServerProcAddr, grpc.WithInsecure())
+		if err != nil {
+			fmt.Printf("Can't start GRPC Server on %s (%v)", grpcServerProcAddr, err)
+			os.Exit(1)
+		}
+		defer func() { _ = clProc.Close() }()
+	}
+	// Load, collect, process, publish, unload routine
 	go func() {
 
 		collClient := pluginrpc.NewCollectorClient(clColl)
 		var publishClient pluginrpc.PublisherClient
+		var processClient pluginrpc.ProcessorClient
 
 		err := doLoadRequest(collClient, opt)
 		if err != nil {
@@ -193,6 +215,13 @@ func main() {
 				doneCh <- fmt.Errorf("can't send load request to plugin: %v", err)
 			}
 		}
+		if useProcessor {
+			processClient = pluginrpc.NewProcessorClient(clProc)
+			errProc := doProcLoadRequest(processClient, opt)
+			if errProc != nil {
+				doneCh <- fmt.Errorf("can't send load request to plugin: %v", errProc)
+			}
+		}
 		// Handle ctrl+C
 		notifyCh := make(chan os.Signal, 1)
 		signal.Notify(notifyCh, os.Interrupt)
@@ -222,6 +251,18 @@ func main() {
 					break
 				}
 			}
+			if useProcessor {
+				for i := 0; i < unloadMaxRetry; i++ {
+					err := doProcUnloadRequest(processClient, opt)
+					if err != nil {
+						fmt.Printf("!! Can't unload plugin (%v), will retry (%d/%d)...\n", err, i+1, unloadMaxRetry)
+						time.Sleep(unloadRetryDelay)
+						continue
+					}
+
+					break
+				}
+			}
 			os.Exit(stoppedByUser)
 		}()
 		time.Sleep(grpcLoadDelay)
@@ -244,6 +285,7 @@ func main() {
 			}
 
 			var mtsChunks [][]*pluginrpc.Metric
+			var events []*pluginrpc.Event
 
 			chunkCh := doCollectRequest(collClient, opt)
 			for chunk := range chunkCh {
@@ -261,14 +303,41 @@ func main() {
 					fmt.Printf(" %s\n", warn)
 				}
 
+				if len(chunk.events) != 0 {
+					fmt.Printf("\nReceived %d event(s)\n", len(chunk.events))
+					for _, ev := range chunk.events {
+						fmt.Printf(" %s\n", grpcEventToString(ev))
+					}
+				}
+
 				if chunk.err != nil {
 					fmt.Printf("\n!! Ended with error: %s\n", chunk.err)
 				}
 
 				mtsChunks = append(mtsChunks, chunk.mts)
+				events = append(events, chunk.events...)
+			}
+			if useProcessor {
+				procChunk := doProcessRequest(processClient, mtsChunks, opt)
+
+				fmt.Printf("\nProcessed %d metric(s)\n", len(procChunk.mts))
+				for _, mt := range procChunk.mts {
+					fmt.Printf(" %s\n", grpcMetricToString(mt))
+				}
+
+				fmt.Printf("\nReceived %d warning(s) from processor\n", len(procChunk.warnings))
+				for _, warn := range procChunk.warnings {
+					fmt.Printf(" %s\n", warn)
+				}
+
+				if procChunk.err != nil {
+					fmt.Printf("\n!! Process ended with error: %s\n", procChunk.err)
+				}
+
+				mtsChunks = [][]*pluginrpc.Metric{procChunk.mts}
 			}
 			if usePublisher {
-				err := doPublishRequest(publishClient, mtsChunks, opt)
+				err := doPublishRequest(publishClient, mtsChunks, events, opt)
 				if err != nil {
 					fmt.Printf("Publish request failed")
 				}
@@ -309,6 +378,12 @@ func main() {
 		contPubClient = pluginrpc.NewControllerClient(clPub)
 	}
 
+	var contProcClient pluginrpc.ControllerClient
+
+	if useProcessor {
+		contProcClient = pluginrpc.NewControllerClient(clProc)
+	}
+
 	go func() {
 		for {
 			req := &pluginrpc.PingRequest{}
@@ -322,6 +397,12 @@ func main() {
 					doneCh <- fmt.Errorf("ping response error: %v", err)
 				}
 			}
+			if useProcessor {
+				_, err := contProcClient.Ping(context.Background(), req)
+				if err != nil {
+					doneCh <- fmt.Errorf("ping response error: %v", err)
+				}
+			}
 			time.Sleep(opt.PingInterval)
 		}
 	}()
@@ -390,6 +471,33 @@ func doPubUnloadRequest(cc pluginrpc.PublisherClient, opt *Options) error {
 	return err
 }
 
+func doProcLoadRequest(cc pluginrpc.ProcessorClient, opt *Options) error {
+	reqLoad := &pluginrpc.LoadProcessorRequest{
+		TaskId:     opt.TaskId,
+		JsonConfig: []byte(opt.PluginConfig),
+	}
+
+	ctx, fn := context.WithTimeout(context.Background(), grpcRequestTimeout)
+	defer fn()
+
+	_, err := cc.Load(ctx, reqLoad)
+
+	return err
+}
+
+func doProcUnloadRequest(cc pluginrpc.ProcessorClient, opt *Options) error {
+	reqUnload := &pluginrpc.UnloadProcessorRequest{
+		TaskId: opt.TaskId,
+	}
+
+	ctx, fn := context.WithTimeout(context.Background(), grpcRequestTimeout)
+	defer fn()
+
+	_, err := cc.Unload(ctx, reqUnload)
+
+	return err
+}
+
 func doUnloadRequest(cc pluginrpc.CollectorClient, opt *Options) error {
 	reqUnload := &pluginrpc.UnloadCollectorRequest{
 		TaskId: opt.TaskId,
@@ -428,7 +536,7 @@ func doInfoRequest(cc pluginrpc.CollectorClient, opt *Options) ([]byte, error) {
 	return resp.Info, nil
 }
 
-func doPublishRequest(pc pluginrpc.PublisherClient, mts [][]*pluginrpc.Metric, opt *Options) error {
+func doPublishRequest(pc pluginrpc.PublisherClient, mts [][]*pluginrpc.Metric, events []*pluginrpc.Event, opt *Options) error {
 	stream, _ := pc.Publish(context.Background())
 
 	for _, chunk := range mts {
@@ -442,12 +550,77 @@ func doPublishRequest(pc pluginrpc.PublisherClient, mts [][]*pluginrpc.Metric, o
 		}
 	}
 
+	if len(events) != 0 {
+		err := stream.Send(&pluginrpc.PublishRequest{
+			TaskId: opt.TaskId,
+			Events: events,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err := stream.CloseAndRecv()
 	return err
 }
 
+func doProcessRequest(pc pluginrpc.ProcessorClient, mts [][]*pluginrpc.Metric, opt *Options) collectChunk {
+	var recvMts []*pluginrpc.Metric
+	var recvWarns []string
+
+	ctx, fn := context.WithTimeout(context.Background(), grpcRequestTimeout)
+	defer fn()
+
+	stream, err := pc.Process(ctx)
+	if err != nil {
+		return collectChunk{err: fmt.Errorf("can't send process request to plugin: %v", err)}
+	}
+
+	for _, chunk := range mts {
+		reqProc := &pluginrpc.ProcessRequest{
+			TaskId:    opt.TaskId,
+			MetricSet: chunk,
+		}
+		err := stream.Send(reqProc)
+		if err != nil {
+			return collectChunk{err: err}
+		}
+	}
+
+	err = stream.CloseSend()
+	if err != nil {
+		return collectChunk{err: err}
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return collectChunk{
+				mts:      recvMts,
+				warnings: recvWarns,
+				err:      fmt.Errorf("error when receiving process reply from plugin (%v)", err),
+			}
+		}
+
+		recvMts = append(recvMts, resp.MetricSet...)
+
+		for _, warns := range resp.Warnings {
+			recvWarns = append(recvWarns, grpcWarningToString(warns))
+		}
+	}
+
+	return collectChunk{
+		mts:      recvMts,
+		warnings: recvWarns,
+	}
+}
+
 func doCollectRequest(cc pluginrpc.CollectorClient, opt *Options) chan collectChunk {
 	var recvMts []*pluginrpc.Metric
+	var recvEvents []*pluginrpc.Event
 	var recvWarns []string
 
 	chunkCh := make(chan collectChunk)
@@ -492,6 +665,7 @@ func doCollectRequest(cc pluginrpc.CollectorClient, opt *Options) chan collectCh
 			}
 
 			recvMts = append(recvMts, resp.MetricSet...)
+			recvEvents = append(recvEvents, resp.Events...)
 
 			for _, warns := range resp.Warnings {
 				recvWarns = append(recvWarns, grpcWarningToString(warns))
@@ -500,17 +674,20 @@ func doCollectRequest(cc pluginrpc.CollectorClient, opt *Options) chan collectCh
 			if opt.IsStream {
 				chunkCh <- collectChunk{
 					mts:      recvMts,
+					events:   recvEvents,
 					warnings: recvWarns,
 					err:      nil,
 				}
 
 				recvMts = nil
+				recvEvents = nil
 				recvWarns = nil
 			}
 		}
 
 		chunkCh <- collectChunk{
 			mts:      recvMts,
+			events:   recvEvents,
 			warnings: recvWarns,
 			err:      nil,
 		}
@@ -533,3 +710,7 @@ func grpcMetricToString(metric *pluginrpc.Metric) string {
 func grpcWarningToString(warning *pluginrpc.Warning) string {
 	return fmt.Sprintf("[%s] %s", time.Unix(warning.Timestamp.Sec, warning.Timestamp.Nsec), warning.Message)
 }
+
+func grpcEventToString(event *pluginrpc.Event) string {
+	return fmt.Sprintf("[%s] [%s] %s: %s [%v]", time.Unix(event.Timestamp.Sec, event.Timestamp.Nsec), event.Severity, event.Title, event.Text, event.Tags)
+}
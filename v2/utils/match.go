@@ -23,3 +23,8 @@ import (
 func MatchNsToFilter(ns string, filter string) (bool, error) {
 	return metrictree.MatchNsToFilter(ns, filter)
 }
+
+// Match metric namespace and tags to filter with tag predicates (ie. /plugin/**{env=prod,device!~loop.*})
+func MatchNsToFilterWithTags(ns string, tags map[string]string, filter string) (bool, error) {
+	return metrictree.MatchNsToFilterWithTags(ns, tags, filter)
+}